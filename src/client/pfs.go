@@ -1249,12 +1249,7 @@ func (c APIClient) GetFileReadSeeker(repoName string, commitID string, path stri
 	if err != nil {
 		return nil, err
 	}
-	reader, err := c.GetFileReader(repoName, commitID, path, 0, 0)
-	if err != nil {
-		return nil, err
-	}
 	return &getFileReadSeeker{
-		Reader: reader,
 		file:   NewFile(repoName, commitID, path),
 		offset: 0,
 		size:   int64(fileInfo.SizeBytes),
@@ -1780,39 +1775,50 @@ func (w *putObjectSplitWriteCloser) Close() error {
 }
 
 type getFileReadSeeker struct {
-	io.Reader
+	reader io.Reader
 	file   *pfs.File
 	offset int64
 	size   int64
 	c      APIClient
 }
 
-func (r *getFileReadSeeker) Seek(offset int64, whence int) (int64, error) {
-	getFileReader := func(offset int64) (io.Reader, error) {
-		return r.c.GetFileReader(r.file.Commit.Repo.Name, r.file.Commit.ID, r.file.Path, offset, 0)
+func (r *getFileReadSeeker) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
 	}
-	switch whence {
-	case io.SeekStart:
-		reader, err := getFileReader(offset)
+	if r.reader == nil {
+		// The file is only streamed once its content is read, so that seeking
+		// (e.g. to find the size of the file, or to serve a range request)
+		// doesn't start streams that are never consumed.
+		reader, err := r.c.GetFileReader(r.file.Commit.Repo.Name, r.file.Commit.ID, r.file.Path, r.offset, 0)
 		if err != nil {
-			return r.offset, err
+			return 0, err
 		}
-		r.offset = offset
-		r.Reader = reader
+		r.reader = reader
+	}
+	n, err := r.reader.Read(p)
+	r.offset += int64(n)
+	return n, err
+}
+
+func (r *getFileReadSeeker) Seek(offset int64, whence int) (int64, error) {
+	var newOffset int64
+	switch whence {
+	case io.SeekStart:
+		newOffset = offset
 	case io.SeekCurrent:
-		reader, err := getFileReader(r.offset + offset)
-		if err != nil {
-			return r.offset, err
-		}
-		r.offset += offset
-		r.Reader = reader
+		newOffset = r.offset + offset
 	case io.SeekEnd:
-		reader, err := getFileReader(r.size - offset)
-		if err != nil {
-			return r.offset, err
-		}
-		r.offset = r.size - offset
-		r.Reader = reader
+		newOffset = r.size + offset
+	default:
+		return r.offset, errors.Errorf("invalid whence: %d", whence)
+	}
+	if newOffset < 0 {
+		return r.offset, errors.Errorf("cannot seek to negative offset %d", newOffset)
+	}
+	if newOffset != r.offset {
+		r.reader = nil
+		r.offset = newOffset
 	}
 	return r.offset, nil
 }
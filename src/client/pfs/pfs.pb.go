@@ -29,6 +29,38 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+// CronCatchUp controls what a cron schedule does about ticks that were missed.
+// It's used by both branch triggers and PPS cron inputs.
+type CronCatchUp int32
+
+const (
+	// Missed ticks are caught up on. A cron input makes a commit for each
+	// missed tick, oldest first. A trigger is satisfied by any commit after a
+	// tick, until the branch is triggered.
+	CronCatchUp_CRON_BACKFILL CronCatchUp = 0
+	// Missed ticks are skipped. A cron input waits for its next tick. A trigger
+	// is only satisfied by the first commit after a tick.
+	CronCatchUp_CRON_SKIP_MISSED CronCatchUp = 1
+)
+
+var CronCatchUp_name = map[int32]string{
+	0: "CRON_BACKFILL",
+	1: "CRON_SKIP_MISSED",
+}
+
+var CronCatchUp_value = map[string]int32{
+	"CRON_BACKFILL":    0,
+	"CRON_SKIP_MISSED": 1,
+}
+
+func (x CronCatchUp) String() string {
+	return proto.EnumName(CronCatchUp_name, int32(x))
+}
+
+func (CronCatchUp) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b48f014707f6595c, []int{0}
+}
+
 // These are the different places where a commit may be originated from
 type OriginKind int32
 
@@ -55,7 +87,7 @@ func (x OriginKind) String() string {
 }
 
 func (OriginKind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{0}
+	return fileDescriptor_b48f014707f6595c, []int{1}
 }
 
 type FileType int32
@@ -83,7 +115,7 @@ func (x FileType) String() string {
 }
 
 func (FileType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{1}
+	return fileDescriptor_b48f014707f6595c, []int{2}
 }
 
 // CommitState describes the states a commit can be in.
@@ -113,7 +145,7 @@ func (x CommitState) String() string {
 }
 
 func (CommitState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{2}
+	return fileDescriptor_b48f014707f6595c, []int{3}
 }
 
 type Delimiter int32
@@ -147,7 +179,7 @@ func (x Delimiter) String() string {
 }
 
 func (Delimiter) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_b48f014707f6595c, []int{3}
+	return fileDescriptor_b48f014707f6595c, []int{4}
 }
 
 type Repo struct {
@@ -763,10 +795,20 @@ type Trigger struct {
 	// Triggers if there's been `size` new data added since the last trigger.
 	Size_ string `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty"`
 	// Triggers if there's been `commits` new commits added since the last trigger.
-	Commits              int64    `protobuf:"varint,5,opt,name=commits,proto3" json:"commits,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Commits int64 `protobuf:"varint,5,opt,name=commits,proto3" json:"commits,omitempty"`
+	// cron_timezone is the IANA time zone, e.g. "America/New_York", in which
+	// cron_spec is evaluated. If it's empty, cron_spec is evaluated in UTC.
+	CronTimezone string `protobuf:"bytes,6,opt,name=cron_timezone,json=cronTimezone,proto3" json:"cron_timezone,omitempty"`
+	// If set, each tick of cron_spec is delayed by an offset of up to
+	// cron_jitter, which is fixed for each branch, to spread out branches that
+	// share a schedule
+	CronJitter *types.Duration `protobuf:"bytes,7,opt,name=cron_jitter,json=cronJitter,proto3" json:"cron_jitter,omitempty"`
+	// cron_catch_up controls whether a tick of cron_spec still triggers the
+	// branch if the commit following it didn't
+	CronCatchUp          CronCatchUp `protobuf:"varint,8,opt,name=cron_catch_up,json=cronCatchUp,proto3,enum=pfs.CronCatchUp" json:"cron_catch_up,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
 }
 
 func (m *Trigger) Reset()         { *m = Trigger{} }
@@ -837,6 +879,27 @@ func (m *Trigger) GetCommits() int64 {
 	return 0
 }
 
+func (m *Trigger) GetCronTimezone() string {
+	if m != nil {
+		return m.CronTimezone
+	}
+	return ""
+}
+
+func (m *Trigger) GetCronJitter() *types.Duration {
+	if m != nil {
+		return m.CronJitter
+	}
+	return nil
+}
+
+func (m *Trigger) GetCronCatchUp() CronCatchUp {
+	if m != nil {
+		return m.CronCatchUp
+	}
+	return CronCatchUp_CRON_BACKFILL
+}
+
 type CommitOrigin struct {
 	Kind                 OriginKind `protobuf:"varint,1,opt,name=kind,proto3,enum=pfs.OriginKind" json:"kind,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -2169,10 +2232,15 @@ type FinishCommitRequest struct {
 	SizeBytes   uint64    `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
 	// If set, 'commit' will be closed (its 'finished' field will be set to the
 	// current time) but its 'tree' will be left nil.
-	Empty                bool     `protobuf:"varint,4,opt,name=empty,proto3" json:"empty,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Empty bool `protobuf:"varint,4,opt,name=empty,proto3" json:"empty,omitempty"`
+	// provenance is added to 'commit's provenance (along with the provenance of
+	// each commit in it) when it's finished. It's used by PPS to record the
+	// older commits in the windows of a job's inputs, which aren't the heads of
+	// their branches, so each of them is keyed by its own ID as branch name.
+	Provenance           []*CommitProvenance `protobuf:"bytes,8,rep,name=provenance,proto3" json:"provenance,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *FinishCommitRequest) Reset()         { *m = FinishCommitRequest{} }
@@ -2257,6 +2325,13 @@ func (m *FinishCommitRequest) GetEmpty() bool {
 	return false
 }
 
+func (m *FinishCommitRequest) GetProvenance() []*CommitProvenance {
+	if m != nil {
+		return m.Provenance
+	}
+	return nil
+}
+
 type InspectCommitRequest struct {
 	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
 	// BlockState causes inspect commit to block until the commit is in the desired state.
@@ -3397,14 +3472,20 @@ type ListFileRequest struct {
 	// History indicates how many historical versions you want returned. Its
 	// semantics are:
 	// 0: Return the files as they are at the commit in `file`. FileInfo.File
-	//    will equal File in this request.
+	//
+	//	will equal File in this request.
+	//
 	// 1: Return the files as they are in the last commit they were modified in.
-	//    (This will have the same hash as if you'd passed 0, but
-	//    FileInfo.File.Commit will be different.
+	//
+	//	(This will have the same hash as if you'd passed 0, but
+	//	FileInfo.File.Commit will be different.
+	//
 	// 2: Return the above and the files as they are in the next-last commit they
-	//    were modified in.
+	//
+	//	were modified in.
+	//
 	// 3: etc.
-	//-1: Return all historical versions.
+	// -1: Return all historical versions.
 	History              int64    `protobuf:"varint,3,opt,name=history,proto3" json:"history,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -3887,6 +3968,7 @@ func (m *FsckResponse) GetError() string {
 type FileOperationRequestV2 struct {
 	Commit *Commit `protobuf:"bytes,1,opt,name=commit,proto3" json:"commit,omitempty"`
 	// Types that are valid to be assigned to Operation:
+	//
 	//	*FileOperationRequestV2_PutTar
 	//	*FileOperationRequestV2_DeleteFiles
 	Operation            isFileOperationRequestV2_Operation `protobuf_oneof:"operation"`
@@ -5493,6 +5575,7 @@ func (m *ObjectIndex) GetTags() map[string]*Object {
 }
 
 func init() {
+	proto.RegisterEnum("pfs.CronCatchUp", CronCatchUp_name, CronCatchUp_value)
 	proto.RegisterEnum("pfs.OriginKind", OriginKind_name, OriginKind_value)
 	proto.RegisterEnum("pfs.FileType", FileType_name, FileType_value)
 	proto.RegisterEnum("pfs.CommitState", CommitState_name, CommitState_value)
@@ -5591,259 +5674,267 @@ func init() {
 func init() { proto.RegisterFile("client/pfs/pfs.proto", fileDescriptor_b48f014707f6595c) }
 
 var fileDescriptor_b48f014707f6595c = []byte{
-	// 4021 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5b, 0x4b, 0x73, 0xdb, 0x58,
-	0x76, 0x16, 0xf8, 0x04, 0x0e, 0x29, 0x11, 0xba, 0x92, 0x69, 0x9a, 0x6e, 0xb7, 0xdd, 0x70, 0x77,
-	0x8f, 0xdb, 0xdd, 0x23, 0x69, 0xa8, 0xf4, 0xc3, 0xf6, 0xb4, 0x5d, 0xd6, 0xab, 0x4d, 0x8f, 0xcb,
-	0x56, 0x40, 0xb5, 0xf2, 0xa8, 0x24, 0x2c, 0x90, 0xbc, 0x24, 0xd1, 0x86, 0x08, 0x0e, 0x00, 0x5a,
-	0xad, 0x59, 0x24, 0xbb, 0xcc, 0x8f, 0x48, 0xa5, 0x2a, 0x35, 0xeb, 0x2c, 0x52, 0xd9, 0xa5, 0xb2,
-	0xc8, 0x22, 0x9b, 0x54, 0x52, 0xa9, 0x9a, 0x5f, 0x90, 0x4a, 0xf5, 0xcf, 0xc8, 0x2a, 0x75, 0x5f,
-	0xc0, 0xc5, 0x83, 0x0f, 0xb9, 0x92, 0x45, 0xb7, 0x80, 0x7b, 0xcf, 0xb9, 0xf7, 0xdc, 0x73, 0xce,
-	0x3d, 0x8f, 0x0f, 0x34, 0x6c, 0xf7, 0x1d, 0x1b, 0x4f, 0x82, 0xdd, 0xe9, 0xd0, 0x27, 0xff, 0xed,
-	0x4c, 0x3d, 0x37, 0x70, 0x51, 0x7e, 0x3a, 0xf4, 0x9b, 0xb7, 0x47, 0xae, 0x3b, 0x72, 0xf0, 0x2e,
-	0x1d, 0xea, 0xcd, 0x86, 0xbb, 0xf8, 0x62, 0x1a, 0x5c, 0x31, 0x8a, 0xe6, 0xdd, 0xe4, 0x64, 0x60,
-	0x5f, 0x60, 0x3f, 0xb0, 0x2e, 0xa6, 0x9c, 0xe0, 0xc3, 0x24, 0xc1, 0xa5, 0x67, 0x4d, 0xa7, 0xd8,
-	0xe3, 0x5b, 0x34, 0xb7, 0x47, 0xee, 0xc8, 0xa5, 0x8f, 0xbb, 0xe4, 0x89, 0x8f, 0xd6, 0xb9, 0x38,
-	0xd6, 0x2c, 0x18, 0xd3, 0xff, 0xb1, 0x71, 0xa3, 0x09, 0x05, 0x13, 0x4f, 0x5d, 0x84, 0xa0, 0x30,
-	0xb1, 0x2e, 0x70, 0x43, 0xb9, 0xa7, 0x3c, 0xd0, 0x4c, 0xfa, 0x6c, 0x3c, 0x81, 0xd2, 0x81, 0x67,
-	0x4d, 0xfa, 0x63, 0x74, 0x07, 0x0a, 0x1e, 0x9e, 0xba, 0x74, 0xb6, 0xd2, 0xd2, 0x76, 0xc8, 0x81,
-	0x08, 0x9b, 0x49, 0x87, 0x43, 0xe6, 0x9c, 0xc4, 0xfc, 0x0c, 0x0a, 0x27, 0xb6, 0x83, 0xd1, 0x7d,
-	0x28, 0xf5, 0xdd, 0x8b, 0x0b, 0x3b, 0xe0, 0xcc, 0x15, 0xca, 0x7c, 0x48, 0x87, 0x4c, 0x3e, 0x45,
-	0x16, 0x98, 0x5a, 0xc1, 0x58, 0x2c, 0x40, 0x9e, 0x8d, 0xdb, 0x50, 0x3c, 0x70, 0xdc, 0xfe, 0x5b,
-	0x32, 0x39, 0xb6, 0xfc, 0xb1, 0x10, 0x8d, 0x3c, 0x1b, 0x1f, 0x40, 0xe9, 0x4d, 0xef, 0x07, 0xdc,
-	0x0f, 0x32, 0x67, 0x6f, 0x41, 0xfe, 0xcc, 0x1a, 0x65, 0x9e, 0xe9, 0x6f, 0x73, 0xa0, 0x12, 0xc9,
-	0xdb, 0x93, 0xa1, 0xbb, 0xec, 0x58, 0x7f, 0x00, 0xe5, 0xbe, 0x87, 0xad, 0x00, 0x0f, 0xa8, 0x60,
-	0x95, 0x56, 0x73, 0x87, 0xe9, 0x7e, 0x47, 0xe8, 0x7e, 0xe7, 0x4c, 0x18, 0xc7, 0x14, 0xa4, 0xe8,
-	0x0e, 0x80, 0x6f, 0xff, 0x06, 0x77, 0x7b, 0x57, 0x01, 0xf6, 0x1b, 0xf9, 0x7b, 0xca, 0x83, 0x82,
-	0xa9, 0x91, 0x91, 0x03, 0x32, 0x80, 0xee, 0x41, 0x65, 0x80, 0xfd, 0xbe, 0x67, 0x4f, 0x03, 0xdb,
-	0x9d, 0x34, 0x8a, 0x54, 0x36, 0x79, 0x08, 0xfd, 0x0c, 0xd4, 0x1e, 0x55, 0x3b, 0xf6, 0x1b, 0xe5,
-	0x7b, 0xf9, 0x50, 0x67, 0xcc, 0x16, 0x66, 0x38, 0x89, 0x76, 0x40, 0x23, 0x96, 0xec, 0xda, 0x93,
-	0xa1, 0xdb, 0x28, 0x51, 0x09, 0x37, 0xc3, 0x33, 0x3c, 0x9f, 0x05, 0x63, 0x72, 0x48, 0x53, 0xb5,
-	0xf8, 0x13, 0xfa, 0x00, 0xb4, 0xc0, 0xbd, 0xe8, 0xf9, 0x81, 0x3b, 0xc1, 0x0d, 0xf5, 0x9e, 0xf2,
-	0x40, 0x35, 0xa3, 0x81, 0x97, 0x05, 0xb5, 0xa0, 0x17, 0x8d, 0xa7, 0x50, 0x95, 0xb9, 0xd1, 0x0e,
-	0x54, 0xad, 0x7e, 0x1f, 0xfb, 0x7e, 0xd7, 0xc1, 0xef, 0xb0, 0x43, 0x55, 0xb5, 0xd1, 0xaa, 0xec,
-	0x50, 0x17, 0xea, 0xf4, 0xdd, 0x29, 0x36, 0x2b, 0x8c, 0xe0, 0x15, 0x99, 0x37, 0x7e, 0x97, 0x03,
-	0x60, 0x82, 0x52, 0xf6, 0xfb, 0x50, 0x62, 0xe2, 0x36, 0x0a, 0x92, 0xf5, 0xf9, 0x49, 0xf8, 0x14,
-	0xba, 0x0b, 0x85, 0x31, 0xb6, 0x84, 0x92, 0x63, 0x0e, 0x42, 0x27, 0xd0, 0xe7, 0x00, 0x53, 0xcf,
-	0x7d, 0x87, 0x27, 0xd6, 0xa4, 0x8f, 0x1b, 0xf9, 0xb4, 0x4e, 0xa4, 0x69, 0x42, 0xec, 0xcf, 0x7a,
-	0x82, 0xb8, 0x98, 0x41, 0x1c, 0x4d, 0xa3, 0x6f, 0x60, 0x73, 0x60, 0x7b, 0xb8, 0x1f, 0x74, 0xa5,
-	0x0d, 0x4a, 0x69, 0x1e, 0x9d, 0x51, 0x9d, 0x46, 0xdb, 0x7c, 0x0a, 0xe5, 0xc0, 0xb3, 0x47, 0x23,
-	0xec, 0x35, 0xca, 0x54, 0xee, 0x2a, 0xa5, 0x3f, 0x63, 0x63, 0xa6, 0x98, 0xcc, 0x74, 0xc2, 0x67,
-	0x50, 0x89, 0x74, 0xe4, 0xa3, 0x3d, 0xa8, 0x30, 0x4d, 0x30, 0x4b, 0x2a, 0x74, 0xfb, 0x9a, 0xb4,
-	0x3d, 0xb5, 0x23, 0xf4, 0xc2, 0x67, 0xe3, 0x2f, 0xa1, 0xcc, 0x37, 0x42, 0xf5, 0x50, 0xc3, 0x6c,
-	0x07, 0xa1, 0x54, 0x1d, 0xf2, 0x96, 0xe3, 0x50, 0x9d, 0xaa, 0x26, 0x79, 0x44, 0xb7, 0x41, 0xeb,
-	0x7b, 0xee, 0xa4, 0xeb, 0x4f, 0x71, 0x9f, 0xfa, 0xa5, 0x66, 0xaa, 0x64, 0xa0, 0x33, 0xc5, 0x7d,
-	0x22, 0x26, 0xf1, 0x51, 0x6a, 0x26, 0xcd, 0xa4, 0xcf, 0xa8, 0x01, 0x65, 0x76, 0x3f, 0x7d, 0xea,
-	0xa6, 0x79, 0x53, 0xbc, 0x1a, 0xfb, 0x50, 0x65, 0x06, 0x7a, 0xe3, 0xd9, 0x23, 0x7b, 0x82, 0xee,
-	0x43, 0xe1, 0xad, 0x3d, 0x19, 0x70, 0xef, 0x60, 0xa2, 0xb3, 0xa9, 0x5f, 0xd9, 0x93, 0x81, 0x49,
-	0x27, 0x8d, 0x67, 0x50, 0x62, 0x4c, 0xcb, 0xee, 0x5d, 0x1d, 0x72, 0x36, 0xf3, 0x06, 0xed, 0xa0,
-	0xf4, 0xd3, 0x7f, 0xdd, 0xcd, 0xb5, 0x8f, 0xcc, 0x9c, 0x3d, 0x30, 0x3a, 0x50, 0xe1, 0x6e, 0x61,
-	0x4d, 0x46, 0x18, 0x7d, 0x04, 0x45, 0xc7, 0xbd, 0xc4, 0x5e, 0x56, 0x60, 0x61, 0x33, 0x84, 0x64,
-	0x46, 0x62, 0x63, 0x96, 0x6b, 0xb1, 0x19, 0xe3, 0xcf, 0x40, 0x67, 0x03, 0x92, 0x6d, 0x57, 0x8a,
-	0x59, 0x91, 0x6b, 0xe7, 0xe6, 0xba, 0xb6, 0xf1, 0x9f, 0x25, 0x00, 0xc6, 0x27, 0xae, 0xc3, 0x75,
-	0x16, 0xae, 0xcd, 0xbf, 0x33, 0x9f, 0x41, 0xc9, 0xa5, 0x0a, 0x6e, 0x6c, 0x4a, 0x17, 0x5f, 0x36,
-	0x8a, 0xc9, 0x09, 0x92, 0x11, 0x47, 0x4d, 0x47, 0x9c, 0x3d, 0x58, 0x9f, 0x5a, 0x1e, 0x9e, 0x04,
-	0x5d, 0x2e, 0x5d, 0x86, 0xba, 0xaa, 0x8c, 0x82, 0x5b, 0x70, 0x0f, 0xd6, 0xfb, 0x63, 0xdb, 0x19,
-	0x74, 0x85, 0x83, 0x54, 0xa4, 0x3b, 0x23, 0x38, 0x28, 0x05, 0x7b, 0xf1, 0x49, 0x30, 0xf5, 0x03,
-	0xcb, 0x23, 0xc1, 0x34, 0xbf, 0x3c, 0x98, 0x72, 0x52, 0xf4, 0x15, 0xa8, 0x43, 0x7b, 0x62, 0xfb,
-	0x63, 0x3c, 0xe0, 0x11, 0x64, 0x11, 0x5b, 0x48, 0x9b, 0x08, 0xc2, 0xc5, 0x64, 0x10, 0xfe, 0x32,
-	0x16, 0x50, 0x74, 0x2a, 0xfb, 0x0d, 0x49, 0xf6, 0xc8, 0x17, 0x62, 0xa1, 0xe5, 0x33, 0xd0, 0x3d,
-	0x6c, 0x0d, 0xae, 0xe4, 0x60, 0x51, 0xa5, 0x37, 0xa3, 0x46, 0xc7, 0x25, 0x17, 0xda, 0x8b, 0x45,
-	0x21, 0x8d, 0xee, 0xa0, 0xcb, 0xda, 0x21, 0x2e, 0x1c, 0x0b, 0x45, 0x77, 0xa1, 0x10, 0x78, 0x18,
-	0xf3, 0x68, 0xc2, 0x34, 0xc9, 0x72, 0x9c, 0x49, 0x27, 0x88, 0x33, 0x93, 0xbf, 0x7e, 0x63, 0x5d,
-	0xd2, 0x35, 0xa7, 0x60, 0x33, 0xc4, 0x75, 0x06, 0x56, 0x30, 0xbb, 0xf0, 0x1b, 0x1b, 0xe9, 0x55,
-	0xf8, 0x14, 0x7a, 0x0c, 0xb7, 0xc4, 0xb6, 0xc2, 0xe0, 0x7e, 0xd7, 0x9f, 0xd1, 0x20, 0xde, 0x40,
-	0xf4, 0x38, 0x37, 0x43, 0x02, 0x6e, 0xbe, 0x0e, 0x9b, 0xce, 0xe6, 0x1d, 0x5a, 0xb6, 0x33, 0xf3,
-	0x70, 0x63, 0x2b, 0x9b, 0xf7, 0x84, 0x4d, 0xa3, 0xaf, 0xe0, 0x66, 0x9a, 0x37, 0x70, 0x03, 0xcb,
-	0x69, 0x6c, 0x53, 0xce, 0x1b, 0x49, 0xce, 0x33, 0x32, 0xf9, 0xb2, 0xa0, 0x96, 0xf4, 0xf2, 0xcb,
-	0x82, 0x0a, 0x7a, 0xc5, 0xf8, 0xc7, 0x1c, 0xa8, 0xa4, 0xac, 0x10, 0xe9, 0x7b, 0x68, 0x3b, 0x38,
-	0x16, 0x46, 0xc8, 0xa4, 0x49, 0x87, 0xd1, 0x43, 0xd0, 0xc8, 0xdf, 0x6e, 0x70, 0x35, 0x65, 0xa5,
-	0xc9, 0x46, 0x6b, 0x3d, 0xa4, 0x39, 0xbb, 0x9a, 0x62, 0xe2, 0x2f, 0xec, 0x69, 0x59, 0xd2, 0xfe,
-	0x06, 0x34, 0x26, 0x30, 0x71, 0x5f, 0x58, 0xea, 0x87, 0x11, 0x31, 0x6a, 0x82, 0x4a, 0xaf, 0x81,
-	0x87, 0x27, 0x34, 0xaf, 0x90, 0x98, 0xcb, 0xdf, 0xd1, 0x27, 0x50, 0x76, 0xa9, 0x69, 0xfc, 0x86,
-	0x9a, 0x36, 0xa9, 0x98, 0x43, 0x9f, 0x83, 0xd6, 0x23, 0x85, 0x90, 0x89, 0x87, 0x3e, 0xf7, 0x24,
-	0x76, 0x8e, 0x03, 0x3e, 0x6a, 0x46, 0xf3, 0x61, 0x39, 0x44, 0xbc, 0xa8, 0xca, 0xcb, 0xa1, 0xaf,
-	0x41, 0x23, 0xc7, 0x60, 0x51, 0x73, 0x5b, 0x8e, 0x9a, 0x05, 0x11, 0x28, 0xb7, 0xe5, 0x40, 0x59,
-	0x10, 0xb1, 0xd1, 0x04, 0x55, 0xec, 0x81, 0xee, 0x41, 0x91, 0xee, 0xc2, 0xb5, 0x0d, 0x92, 0x04,
-	0x6c, 0x02, 0x7d, 0x0c, 0x45, 0x8f, 0x6c, 0xc1, 0xa3, 0xc7, 0x06, 0xa3, 0x10, 0x1b, 0x9b, 0x6c,
-	0xd2, 0xf8, 0x73, 0x00, 0x76, 0x40, 0x11, 0x10, 0xd9, 0x31, 0x63, 0x01, 0x51, 0x38, 0x2c, 0x9b,
-	0x22, 0x86, 0xa4, 0x3b, 0x74, 0x3d, 0x3c, 0xe4, 0x8b, 0x27, 0x14, 0xa0, 0x0a, 0x05, 0x18, 0xfb,
-	0x34, 0xde, 0x4e, 0xad, 0x3e, 0x0d, 0x6c, 0x9f, 0xc0, 0x86, 0x3d, 0x99, 0xce, 0x48, 0x76, 0xc7,
-	0x43, 0xfb, 0x47, 0xec, 0x37, 0x72, 0xd4, 0x06, 0xeb, 0x74, 0xf4, 0x94, 0x0f, 0x1a, 0x7f, 0x05,
-	0xc5, 0xce, 0xd8, 0xf2, 0x06, 0x68, 0x17, 0xa0, 0x1f, 0x72, 0x73, 0x91, 0x6a, 0xe2, 0xd6, 0xf2,
-	0x61, 0x53, 0x22, 0xc9, 0x3e, 0xf3, 0xa9, 0x15, 0x8c, 0xe5, 0x33, 0xa3, 0xbb, 0x50, 0x71, 0x67,
-	0x01, 0x95, 0x83, 0x54, 0xb9, 0x2c, 0xf7, 0x02, 0x1b, 0x22, 0xc4, 0xc4, 0x42, 0x21, 0x53, 0xdc,
-	0x42, 0x5a, 0xa6, 0x85, 0x34, 0x61, 0x21, 0x0f, 0x36, 0x0f, 0x69, 0xdd, 0x49, 0xd3, 0x27, 0xfe,
-	0xf5, 0x0c, 0xfb, 0x4b, 0xd3, 0x6b, 0x22, 0x1f, 0xe4, 0xd3, 0xf9, 0xa0, 0x0e, 0xa5, 0xd9, 0x74,
-	0x60, 0x05, 0xac, 0x1c, 0x50, 0x4d, 0xfe, 0xf6, 0xb2, 0xa0, 0xe6, 0xf4, 0xbc, 0xb1, 0x0f, 0xa8,
-	0x3d, 0x21, 0x45, 0x44, 0xb0, 0xfa, 0xa6, 0xc6, 0x4d, 0xa8, 0xbd, 0xb2, 0x7d, 0x99, 0xe3, 0x65,
-	0x41, 0x55, 0xf4, 0x9c, 0xf1, 0x14, 0xf4, 0x68, 0xc2, 0x9f, 0xba, 0x13, 0x9f, 0xde, 0x5c, 0xc2,
-	0x24, 0x97, 0x43, 0xeb, 0xe1, 0x82, 0xac, 0xa8, 0xf5, 0xf8, 0x93, 0xf1, 0x5b, 0x05, 0x36, 0x8f,
-	0xb0, 0x83, 0xaf, 0xa5, 0x82, 0x6d, 0x28, 0x0e, 0x5d, 0xaf, 0x8f, 0x79, 0x79, 0xc4, 0x5e, 0x44,
-	0xc9, 0x94, 0x8f, 0x4a, 0xa6, 0xcf, 0x61, 0xd3, 0x9f, 0x3a, 0x76, 0xd0, 0x0d, 0x3c, 0x6b, 0xe2,
-	0x73, 0xb7, 0x60, 0x3a, 0xd1, 0xe9, 0xc4, 0x59, 0x34, 0x6e, 0xfc, 0x83, 0x02, 0xa8, 0x43, 0xf2,
-	0x16, 0x8f, 0xf0, 0x5c, 0x94, 0xfb, 0x50, 0x62, 0xa9, 0x33, 0x33, 0xe7, 0xb3, 0xa9, 0xa4, 0x4d,
-	0x0a, 0x99, 0x36, 0xe1, 0x55, 0x41, 0x3e, 0x56, 0xe7, 0xc5, 0x53, 0x59, 0x71, 0xc5, 0x54, 0xc6,
-	0x4d, 0xf9, 0x2f, 0x79, 0x40, 0x07, 0xb3, 0x30, 0x4b, 0x5f, 0x4b, 0xe4, 0x7a, 0xac, 0xb4, 0xd7,
-	0x32, 0x2a, 0x93, 0xea, 0xb2, 0xca, 0x24, 0x2e, 0x7b, 0x69, 0xd5, 0x34, 0x2c, 0x32, 0x65, 0x7e,
-	0x69, 0xa6, 0x2c, 0xaf, 0x90, 0x29, 0xd5, 0xf9, 0x99, 0x72, 0x03, 0x72, 0xed, 0x23, 0xde, 0xa2,
-	0xe5, 0xda, 0x47, 0x89, 0x2c, 0xa1, 0x25, 0xb3, 0x84, 0x54, 0xe2, 0xc0, 0xfb, 0x95, 0x38, 0x95,
-	0xd5, 0x4b, 0x1c, 0x6e, 0xc1, 0xff, 0x51, 0x60, 0xeb, 0x84, 0x0e, 0xa5, 0x4c, 0xb8, 0xbc, 0xd2,
-	0x4c, 0x78, 0x5d, 0x2e, 0xed, 0x75, 0xab, 0xab, 0xba, 0xb8, 0x82, 0xaa, 0xcb, 0xf3, 0x55, 0x1d,
-	0x57, 0x6d, 0x29, 0xa9, 0xda, 0x6d, 0x28, 0x52, 0x90, 0x84, 0x5f, 0x3e, 0xf6, 0x62, 0x4c, 0x60,
-	0x9b, 0x47, 0xa2, 0xf7, 0x38, 0xfc, 0x2f, 0xa0, 0xc2, 0xb2, 0x8a, 0x1f, 0x90, 0x48, 0xc7, 0x0a,
-	0x04, 0xb9, 0x44, 0xeb, 0x90, 0x71, 0x13, 0x28, 0x11, 0x7d, 0x36, 0x7e, 0xa7, 0xc0, 0x26, 0x09,
-	0x56, 0xf1, 0xdd, 0x96, 0xc4, 0x9a, 0xbb, 0x50, 0x18, 0x7a, 0xee, 0x45, 0x66, 0x77, 0x4b, 0x26,
-	0xd0, 0x6d, 0xc8, 0x05, 0x6e, 0x4c, 0xc3, 0x7c, 0x3a, 0x17, 0x90, 0x5e, 0xa8, 0x34, 0x99, 0x5d,
-	0xf4, 0xb0, 0x47, 0x4f, 0x5e, 0x30, 0xf9, 0x1b, 0xe9, 0xcd, 0x3c, 0xfc, 0x0e, 0x7b, 0x3e, 0xa6,
-	0xfe, 0xa9, 0x9a, 0xe2, 0x95, 0x34, 0x97, 0x51, 0xc7, 0x41, 0x9b, 0x4b, 0x76, 0xe0, 0x74, 0x73,
-	0x19, 0x91, 0xd1, 0x9c, 0xc6, 0x9f, 0x8d, 0xff, 0x50, 0x60, 0x8b, 0x25, 0x15, 0xde, 0x73, 0xf0,
-	0x73, 0x8a, 0x36, 0x5d, 0x99, 0xd7, 0xa6, 0xdf, 0x02, 0xd5, 0xef, 0x4a, 0x3d, 0x91, 0x66, 0x96,
-	0x7d, 0x0e, 0x20, 0xdd, 0x8f, 0x45, 0xaf, 0x39, 0x3d, 0x4d, 0xbc, 0xcd, 0x2f, 0x2c, 0x6e, 0xf3,
-	0xa5, 0xfe, 0xbb, 0xb8, 0xa0, 0xff, 0x36, 0x9e, 0x84, 0x3e, 0x12, 0x3f, 0xcd, 0xfd, 0x58, 0xdf,
-	0x3c, 0xa7, 0x7d, 0x7b, 0xc5, 0xec, 0x1d, 0xe7, 0x5c, 0x62, 0x6f, 0xc9, 0x32, 0xb9, 0xb8, 0x65,
-	0x4e, 0x61, 0x8b, 0x65, 0xaa, 0xeb, 0x4b, 0x92, 0x9d, 0xb1, 0x8c, 0xc7, 0x62, 0xc5, 0xeb, 0xfb,
-	0xbf, 0x61, 0x01, 0x3a, 0x71, 0x66, 0xc9, 0xb8, 0xf1, 0x49, 0xd4, 0xf3, 0x2b, 0xe9, 0x96, 0x4e,
-	0xcc, 0xa1, 0x8f, 0x41, 0x0d, 0xdc, 0x2e, 0x39, 0x2f, 0x2b, 0xa9, 0x62, 0x7a, 0x28, 0x07, 0x2e,
-	0xf9, 0xeb, 0x1b, 0xff, 0xaa, 0x40, 0xbd, 0x33, 0xeb, 0x91, 0x70, 0xd2, 0xc3, 0xd7, 0xba, 0x34,
-	0xf5, 0x58, 0x73, 0x2d, 0x27, 0x97, 0x02, 0xf1, 0x01, 0x6e, 0xf2, 0x39, 0xb9, 0x82, 0x92, 0x84,
-	0xf7, 0x2e, 0x3f, 0xef, 0xde, 0x7d, 0x0a, 0x45, 0x76, 0xf5, 0x0b, 0x73, 0xae, 0x3e, 0x9b, 0x36,
-	0x7e, 0x0d, 0x1b, 0xdf, 0xe1, 0x80, 0x36, 0x16, 0x91, 0xf0, 0x8b, 0x1a, 0x8f, 0x8f, 0xa0, 0xea,
-	0x0e, 0x87, 0x3e, 0x0e, 0x78, 0x34, 0xcb, 0xd1, 0xee, 0xa6, 0xc2, 0xc6, 0x58, 0x3c, 0x4b, 0xf7,
-	0x1b, 0x79, 0x29, 0xdc, 0x19, 0x9f, 0xc2, 0xc6, 0x9b, 0x77, 0xd8, 0xbb, 0xf4, 0xec, 0x00, 0xb7,
-	0x27, 0x03, 0xfc, 0x23, 0xb1, 0xbf, 0x4d, 0x1e, 0xe8, 0x9e, 0x79, 0x93, 0xbd, 0x18, 0x7f, 0x9d,
-	0x87, 0x8d, 0xd3, 0xd9, 0x75, 0x64, 0xdb, 0x86, 0xe2, 0x3b, 0xcb, 0x99, 0xb1, 0x88, 0x5e, 0x35,
-	0xd9, 0x0b, 0xa9, 0x7c, 0x66, 0x9e, 0xc3, 0x33, 0x1d, 0x79, 0x44, 0x1f, 0x90, 0x12, 0xac, 0x3f,
-	0xf3, 0x7c, 0xfb, 0x1d, 0xa6, 0xe1, 0x58, 0x35, 0xa3, 0x01, 0xf4, 0x05, 0x68, 0x03, 0xec, 0xd8,
-	0x17, 0x76, 0xc0, 0xe1, 0xaf, 0x0d, 0x5e, 0xfa, 0x1e, 0x89, 0x51, 0x33, 0x22, 0x40, 0x5f, 0x00,
-	0x0a, 0x2c, 0x6f, 0x84, 0x83, 0x2e, 0xed, 0xc7, 0xa4, 0xbc, 0x9b, 0x37, 0x75, 0x36, 0x43, 0x24,
-	0x3c, 0x62, 0x99, 0xe0, 0x21, 0x6c, 0xca, 0xd4, 0x51, 0xae, 0xcd, 0x9b, 0xb5, 0x88, 0x98, 0xa9,
-	0xf1, 0x13, 0xd8, 0x20, 0x91, 0x07, 0x7b, 0x5d, 0x0f, 0xf7, 0x5d, 0x6f, 0xe0, 0xd3, 0x0c, 0x9a,
-	0x37, 0xd7, 0xd9, 0xa8, 0xc9, 0x06, 0xd1, 0x2f, 0xa1, 0xe6, 0x0a, 0x75, 0x76, 0x99, 0x1a, 0x59,
-	0x82, 0xde, 0x62, 0xa9, 0x28, 0xa6, 0x6a, 0x73, 0xc3, 0x8d, 0xab, 0xbe, 0x0e, 0xa5, 0x01, 0xbd,
-	0x64, 0xb4, 0xa0, 0x51, 0x4d, 0xfe, 0xc6, 0x12, 0x30, 0x87, 0x4d, 0xff, 0x49, 0x81, 0xf5, 0xd0,
-	0x10, 0x64, 0xd3, 0x84, 0x85, 0x95, 0x84, 0x85, 0x69, 0x4b, 0x40, 0x33, 0x60, 0x97, 0xb6, 0x6b,
-	0x39, 0xde, 0x12, 0xd0, 0xa1, 0x17, 0x96, 0x3f, 0xce, 0x92, 0x39, 0xbf, 0xba, 0xcc, 0xb1, 0x96,
-	0xa9, 0xb0, 0xb8, 0x65, 0xfa, 0x77, 0x45, 0x72, 0x22, 0xa6, 0xb0, 0x6d, 0x28, 0xd2, 0xf2, 0x96,
-	0xca, 0xad, 0x9a, 0xec, 0x05, 0x7d, 0x41, 0x22, 0x1b, 0x53, 0x33, 0xbb, 0xf3, 0x88, 0xb5, 0x3b,
-	0x32, 0xaf, 0x29, 0x48, 0xe2, 0x68, 0x73, 0x3e, 0x81, 0x36, 0xa3, 0x87, 0x50, 0x62, 0x36, 0xe2,
-	0xd2, 0x65, 0x2d, 0xc5, 0x29, 0x08, 0xed, 0xd0, 0x75, 0x83, 0x30, 0xd2, 0x67, 0xd2, 0x32, 0x0a,
-	0xc3, 0x86, 0xda, 0xa1, 0x3b, 0xbd, 0x92, 0x6f, 0xc4, 0x6d, 0xc8, 0xfb, 0x5e, 0x3f, 0x7d, 0x21,
-	0xc8, 0x28, 0x99, 0x1c, 0xf8, 0x02, 0xf0, 0x92, 0x27, 0x07, 0x7e, 0x40, 0x8e, 0x10, 0xea, 0x55,
-	0x1c, 0x21, 0x1c, 0x90, 0xfa, 0xa0, 0xd5, 0xef, 0x9f, 0xf1, 0x17, 0xac, 0x0f, 0xba, 0xc6, 0x8d,
-	0x45, 0x50, 0x18, 0xce, 0x42, 0x24, 0x97, 0x3e, 0x93, 0x1c, 0x33, 0xb6, 0xfd, 0xc0, 0xf5, 0xae,
-	0x78, 0xec, 0x10, 0xaf, 0xc6, 0x1e, 0xd4, 0xfe, 0xc8, 0x72, 0xde, 0x5e, 0x43, 0xa2, 0x53, 0xa8,
-	0x7d, 0xe7, 0xb8, 0x3d, 0x99, 0x63, 0xa5, 0xfa, 0xa9, 0x01, 0xe5, 0xa9, 0x15, 0x04, 0xd8, 0x13,
-	0x85, 0xa3, 0x78, 0x25, 0xdd, 0xac, 0xc0, 0x68, 0xfc, 0x10, 0x85, 0x49, 0xf5, 0x72, 0x82, 0x84,
-	0xa1, 0x30, 0xb4, 0xf2, 0xb8, 0x84, 0xda, 0x91, 0x3d, 0x1c, 0xca, 0xa2, 0x7c, 0x0c, 0xea, 0x04,
-	0x5f, 0x76, 0xb3, 0x0f, 0x50, 0x9e, 0xe0, 0x4b, 0xfa, 0x91, 0xe9, 0x63, 0x50, 0x5d, 0x67, 0xc0,
-	0xa8, 0x52, 0xa6, 0x2c, 0xbb, 0xce, 0x80, 0x52, 0x35, 0xa0, 0xec, 0x8f, 0x2d, 0xc7, 0x71, 0x2f,
-	0xb9, 0x31, 0xc5, 0xab, 0xf1, 0x03, 0xe8, 0xd1, 0xc6, 0x51, 0x13, 0x2a, 0x76, 0xf6, 0xe7, 0x08,
-	0xce, 0xb7, 0xa7, 0x87, 0x14, 0xfb, 0x8b, 0xbb, 0x91, 0xa4, 0xe5, 0x42, 0xf8, 0x46, 0x4b, 0xf4,
-	0xab, 0xd7, 0xb0, 0xd1, 0x5d, 0xa8, 0x9c, 0xf8, 0xe4, 0xb6, 0x32, 0x6a, 0x1d, 0xf2, 0x43, 0xfb,
-	0x47, 0x7e, 0x39, 0xc9, 0xa3, 0xf1, 0x15, 0x54, 0x19, 0x01, 0x17, 0x5e, 0xa2, 0xd0, 0x28, 0x05,
-	0xad, 0xa0, 0x3d, 0xcf, 0x0d, 0xf1, 0x03, 0xfa, 0x62, 0xfc, 0xb3, 0x02, 0x75, 0xb2, 0xcf, 0x9b,
-	0x29, 0xf6, 0x2c, 0x8a, 0x6e, 0xb0, 0x2d, 0xce, 0x5b, 0xab, 0x39, 0xc1, 0x2e, 0x94, 0xa7, 0xb3,
-	0xa0, 0x1b, 0x58, 0x02, 0x62, 0xdf, 0x16, 0x77, 0xf3, 0xcc, 0xf2, 0xc2, 0xb5, 0x5e, 0xac, 0x99,
-	0xa5, 0x29, 0x1d, 0x42, 0x4f, 0xa1, 0xca, 0xc2, 0x27, 0x57, 0x16, 0x8b, 0x69, 0xb7, 0x44, 0xf2,
-	0xe0, 0x6a, 0xf1, 0x65, 0xd6, 0xca, 0x20, 0x1a, 0x3f, 0xa8, 0x80, 0xe6, 0x0a, 0x59, 0x8d, 0xef,
-	0xa1, 0x96, 0xd8, 0x29, 0x7e, 0x65, 0x95, 0xc4, 0x95, 0x25, 0x6a, 0x09, 0xac, 0x11, 0x57, 0x01,
-	0x79, 0x24, 0xb7, 0x6b, 0x60, 0x05, 0x16, 0x4f, 0x87, 0xf4, 0xd9, 0x78, 0x0a, 0xdb, 0x59, 0xa2,
-	0xd0, 0x1a, 0x2c, 0xf4, 0x06, 0xcd, 0x64, 0x2f, 0xe9, 0x35, 0xc9, 0x1d, 0xfc, 0x0e, 0xc7, 0xc5,
-	0x5a, 0x62, 0xdf, 0x31, 0xa0, 0xa4, 0xff, 0x9d, 0xb7, 0xd0, 0x03, 0xc9, 0xab, 0x15, 0x29, 0x86,
-	0x87, 0x4e, 0x15, 0x7a, 0xf6, 0x03, 0xe9, 0x96, 0xe4, 0x32, 0x29, 0xb9, 0xab, 0x1a, 0x8f, 0xa0,
-	0xc1, 0x6a, 0xfb, 0xb3, 0x8b, 0x29, 0x19, 0xe8, 0xe0, 0x20, 0x74, 0x9a, 0x3b, 0x00, 0xf4, 0x48,
-	0x38, 0xe8, 0xda, 0x03, 0xee, 0x3b, 0x1a, 0x1f, 0x69, 0x0f, 0x8c, 0x3f, 0x86, 0xba, 0x89, 0x27,
-	0xf8, 0x52, 0xe6, 0x14, 0xde, 0xbb, 0x88, 0x91, 0xe4, 0xba, 0x20, 0x70, 0xba, 0x3e, 0xee, 0xbb,
-	0x93, 0x81, 0x28, 0x87, 0x20, 0x08, 0x9c, 0x0e, 0x1b, 0x21, 0x35, 0xfa, 0xa1, 0x83, 0x2d, 0x2f,
-	0x56, 0x22, 0xae, 0xe8, 0x82, 0xc6, 0x18, 0xf4, 0xd3, 0x59, 0xc0, 0xdb, 0x49, 0x2e, 0x50, 0x58,
-	0xe5, 0x28, 0x72, 0x95, 0xf3, 0x01, 0x14, 0x02, 0x6b, 0x24, 0x2e, 0xa8, 0xca, 0xfa, 0x05, 0x6b,
-	0x64, 0xd2, 0xd1, 0x08, 0xe0, 0xcc, 0xcf, 0x01, 0x38, 0x8d, 0xa1, 0xe8, 0x8b, 0xe2, 0x9b, 0xfd,
-	0x9f, 0x63, 0x98, 0x7f, 0xa3, 0xc0, 0xe6, 0x77, 0x98, 0x1f, 0xc9, 0x97, 0x2a, 0x73, 0x81, 0x16,
-	0x2b, 0x0b, 0xd0, 0xe2, 0xac, 0xe2, 0xb3, 0xb0, 0xac, 0xf8, 0x8c, 0xf5, 0xda, 0x77, 0x00, 0x28,
-	0x2a, 0xdf, 0x0d, 0x3f, 0x08, 0x16, 0x48, 0xe6, 0x0e, 0x2c, 0xa7, 0x63, 0xff, 0x06, 0x1b, 0x6d,
-	0x7a, 0xe9, 0xb8, 0xd8, 0x4c, 0xb4, 0xe5, 0xd8, 0x70, 0x68, 0x90, 0x9c, 0x64, 0x10, 0x63, 0x9f,
-	0x5e, 0x94, 0xeb, 0x2d, 0x65, 0xfc, 0x9d, 0x02, 0xba, 0xe0, 0x0a, 0x95, 0x13, 0xc3, 0xc8, 0x95,
-	0x25, 0x18, 0xf9, 0xff, 0xbb, 0x8a, 0x10, 0xc3, 0x34, 0xe5, 0x83, 0x19, 0xdf, 0x83, 0x7e, 0x66,
-	0x8d, 0xde, 0xc3, 0x73, 0x16, 0x7a, 0xad, 0xb1, 0x0d, 0x88, 0x6c, 0x15, 0xf7, 0x15, 0x92, 0xd3,
-	0xc9, 0xe8, 0x99, 0x35, 0x0a, 0x35, 0x54, 0x87, 0x12, 0x03, 0xc1, 0xc5, 0x77, 0x62, 0xf6, 0xc6,
-	0x20, 0xf2, 0xbe, 0x33, 0x1b, 0xe0, 0x2e, 0x97, 0x85, 0x15, 0x1a, 0xeb, 0x7c, 0x94, 0xad, 0x6c,
-	0x74, 0xd8, 0x91, 0xd8, 0x8a, 0x3c, 0x5e, 0x34, 0x59, 0xe4, 0x63, 0xb2, 0x47, 0x82, 0xd1, 0xb8,
-	0x1a, 0x1d, 0x2d, 0x37, 0xf7, 0x68, 0xc6, 0xb7, 0x22, 0xd0, 0xbe, 0x97, 0xab, 0x1b, 0x37, 0xe1,
-	0x46, 0x82, 0x9d, 0x09, 0x66, 0xfc, 0x42, 0xa4, 0x58, 0x59, 0x01, 0x42, 0x8f, 0xca, 0x3c, 0x3d,
-	0xca, 0x2c, 0x7c, 0xa1, 0x47, 0x80, 0x0e, 0xc7, 0xb8, 0xff, 0xf6, 0xfa, 0x66, 0x33, 0x7e, 0x0e,
-	0x5b, 0x31, 0x56, 0xae, 0xb3, 0x3a, 0x94, 0xf0, 0x8f, 0xb6, 0x1f, 0xf8, 0x3c, 0x39, 0xf1, 0x37,
-	0x63, 0x0f, 0xca, 0xfc, 0x14, 0xab, 0x9e, 0xfe, 0x5b, 0xd8, 0x62, 0x71, 0xef, 0x88, 0xfe, 0x34,
-	0x41, 0xaa, 0x0d, 0xdc, 0xde, 0x0f, 0x22, 0xf3, 0xbb, 0xbd, 0x1f, 0xe6, 0xdc, 0xbd, 0x9f, 0xc1,
-	0x16, 0x8b, 0x31, 0x4b, 0xd8, 0x8d, 0x17, 0x50, 0x0f, 0xb5, 0x1c, 0xa7, 0xad, 0xc7, 0xf4, 0xa0,
-	0x85, 0x1e, 0x1b, 0xb9, 0x5a, 0x4e, 0x76, 0x35, 0xe3, 0xb7, 0x39, 0xa8, 0x88, 0x6f, 0x3f, 0xa4,
-	0x49, 0xf9, 0x3a, 0x79, 0xd0, 0x3b, 0xd2, 0x41, 0x29, 0x09, 0x7f, 0xf6, 0x8f, 0x27, 0x81, 0x77,
-	0x15, 0xc5, 0xb8, 0x9d, 0xd8, 0x95, 0x68, 0xa6, 0xb8, 0x88, 0x0d, 0x19, 0x0b, 0xa5, 0x6b, 0xb6,
-	0xa1, 0x2a, 0x2f, 0x44, 0x0e, 0xf9, 0x16, 0x5f, 0x89, 0x43, 0xbe, 0xc5, 0x57, 0xe8, 0xbe, 0xac,
-	0xa3, 0x54, 0xec, 0x60, 0x73, 0x8f, 0x73, 0xdf, 0x28, 0xcd, 0x23, 0xd0, 0xc2, 0xd5, 0x33, 0xd6,
-	0xf9, 0x28, 0xbe, 0x4e, 0x1c, 0x0e, 0x0d, 0x57, 0x79, 0xf8, 0x10, 0x20, 0xfa, 0x79, 0x04, 0x52,
-	0xa1, 0xf0, 0x7d, 0xe7, 0xd8, 0xd4, 0xd7, 0xc8, 0xd3, 0xf3, 0xef, 0xcf, 0xde, 0xe8, 0x0a, 0x79,
-	0x3a, 0xe9, 0x1c, 0xfe, 0x4a, 0xcf, 0x3d, 0xfc, 0x9c, 0x7d, 0xf1, 0xa4, 0x9f, 0x29, 0xab, 0xa0,
-	0x9a, 0xc7, 0x9d, 0x63, 0xf3, 0xfc, 0xf8, 0x88, 0x51, 0x9f, 0xb4, 0x5f, 0x1d, 0xeb, 0x0a, 0x2a,
-	0x43, 0xfe, 0xa8, 0x6d, 0xea, 0xb9, 0x87, 0xfb, 0x02, 0xfc, 0xa3, 0x08, 0x06, 0xaa, 0x40, 0xb9,
-	0x73, 0xf6, 0xdc, 0x3c, 0xa3, 0xe4, 0x1a, 0x14, 0xcd, 0xe3, 0xe7, 0x47, 0x7f, 0xa2, 0x2b, 0x64,
-	0x9d, 0x93, 0xf6, 0xeb, 0x76, 0xe7, 0xc5, 0xf1, 0x91, 0x9e, 0x7b, 0xf8, 0x04, 0xb4, 0xb0, 0x6f,
-	0x27, 0x8b, 0xbe, 0x7e, 0xf3, 0xfa, 0x98, 0x2d, 0xff, 0xb2, 0xf3, 0xe6, 0x35, 0x13, 0xe6, 0x55,
-	0xfb, 0xf5, 0xb1, 0x9e, 0x23, 0x1b, 0x75, 0xfe, 0xf0, 0x95, 0x9e, 0x27, 0x0f, 0x87, 0x9d, 0x73,
-	0xbd, 0xd0, 0xfa, 0xfd, 0x26, 0xe4, 0x9f, 0x9f, 0xb6, 0xd1, 0x53, 0x80, 0xe8, 0x4b, 0x14, 0xaa,
-	0xb3, 0x4c, 0x9d, 0xfc, 0x34, 0xd5, 0xac, 0xa7, 0xa0, 0xed, 0x63, 0x8a, 0xe4, 0xae, 0xa1, 0xaf,
-	0xa1, 0x22, 0x7d, 0x55, 0x42, 0x37, 0xe9, 0x02, 0xe9, 0xef, 0x4c, 0xcd, 0xf8, 0x87, 0x20, 0x63,
-	0x0d, 0x3d, 0x02, 0x55, 0x7c, 0x40, 0x42, 0xac, 0xfa, 0x4c, 0x7c, 0x68, 0x6a, 0xde, 0x48, 0x8c,
-	0xf2, 0xcb, 0xbd, 0x46, 0x64, 0x8e, 0x3e, 0x1d, 0x71, 0x99, 0x53, 0xdf, 0x92, 0x16, 0xc8, 0xfc,
-	0x25, 0x54, 0xa4, 0x0f, 0x3e, 0x5c, 0xe6, 0xf4, 0x27, 0xa0, 0xa6, 0x5c, 0xb7, 0x18, 0x6b, 0xe8,
-	0x00, 0xaa, 0x32, 0x64, 0x8f, 0x1a, 0xbc, 0x56, 0x4b, 0xa1, 0xf8, 0x0b, 0xb6, 0xfe, 0x16, 0xd6,
-	0x63, 0xd0, 0x37, 0xba, 0x25, 0x2b, 0x2c, 0xbe, 0x4a, 0x12, 0xed, 0x35, 0xd6, 0xd0, 0x37, 0x00,
-	0x11, 0x90, 0xcd, 0x4f, 0x9e, 0x42, 0xb6, 0x9b, 0x7a, 0x82, 0xd1, 0x37, 0xd6, 0xd0, 0x33, 0x96,
-	0x08, 0x84, 0x97, 0x79, 0xd8, 0xba, 0x98, 0xcb, 0x9f, 0xde, 0x78, 0x4f, 0x21, 0xa7, 0x97, 0x31,
-	0x4b, 0x7e, 0xfa, 0x0c, 0x18, 0x73, 0xc1, 0xe9, 0x9f, 0x40, 0x45, 0xc2, 0x2e, 0xb9, 0xe2, 0xd3,
-	0x68, 0x66, 0xb6, 0x00, 0x87, 0x50, 0x4b, 0x80, 0x92, 0xe8, 0x36, 0xb3, 0x5c, 0x26, 0x54, 0x99,
-	0xbd, 0xc8, 0x97, 0x50, 0x91, 0x3e, 0x9c, 0x71, 0x09, 0xd2, 0x9f, 0xd2, 0x32, 0x4c, 0x2f, 0x43,
-	0xeb, 0xfc, 0xf0, 0x19, 0x68, 0xfb, 0x4a, 0xa6, 0xe7, 0x8b, 0xc4, 0x4c, 0x1f, 0x5f, 0x25, 0xf9,
-	0x2b, 0xb2, 0xc8, 0xf4, 0x9c, 0x37, 0x32, 0x5d, 0x9c, 0x51, 0x4f, 0x30, 0xfa, 0x4c, 0x78, 0x19,
-	0xbf, 0x8e, 0x59, 0x6e, 0x55, 0xe1, 0x1f, 0x43, 0x99, 0x03, 0x37, 0x68, 0x2b, 0x0e, 0xe3, 0x2c,
-	0xe1, 0x7c, 0xa0, 0xa0, 0xc7, 0xa0, 0x0a, 0x6c, 0x87, 0xdf, 0xf4, 0x04, 0xd4, 0xb3, 0x60, 0xdf,
-	0x67, 0x50, 0xe6, 0x20, 0x2e, 0xdf, 0x37, 0x0e, 0xe9, 0x36, 0x6f, 0xa7, 0x38, 0x69, 0xa5, 0x77,
-	0x4e, 0x73, 0x25, 0x31, 0x78, 0x14, 0x9f, 0xe8, 0x22, 0xb1, 0xf8, 0x24, 0x2f, 0x14, 0x6f, 0xbc,
-	0x8c, 0x35, 0xd4, 0x62, 0xf1, 0x49, 0x92, 0x3a, 0x01, 0x00, 0x35, 0x37, 0x62, 0x2c, 0x3e, 0x8d,
-	0x69, 0x1b, 0x82, 0x88, 0x5f, 0xb1, 0x6c, 0xce, 0xe4, 0x66, 0x7b, 0x0a, 0xda, 0x07, 0x55, 0x00,
-	0x40, 0x9c, 0x29, 0x81, 0x07, 0x65, 0x31, 0xb5, 0x40, 0x15, 0x18, 0x10, 0x67, 0x4a, 0x40, 0x42,
-	0xd9, 0x32, 0x0a, 0xa2, 0x98, 0x8c, 0x49, 0xce, 0x8c, 0xed, 0x1e, 0x81, 0x2a, 0xda, 0x5d, 0xce,
-	0x94, 0x80, 0x7d, 0x78, 0xc8, 0x4e, 0xf6, 0xc4, 0x72, 0xc8, 0xa6, 0xcc, 0xf5, 0x04, 0x6e, 0xb0,
-	0xca, 0xe5, 0xd1, 0x18, 0xf9, 0x73, 0xc7, 0x41, 0x73, 0xc8, 0x16, 0xb0, 0xef, 0x42, 0xe1, 0xc4,
-	0xef, 0xbf, 0x45, 0xec, 0x7a, 0x48, 0x98, 0x4c, 0x73, 0x53, 0x1a, 0x11, 0xd2, 0xee, 0x29, 0xe8,
-	0x25, 0xd4, 0x62, 0xf8, 0xca, 0x79, 0x8b, 0x07, 0x9b, 0x6c, 0xd4, 0x65, 0xa1, 0xff, 0x3f, 0x07,
-	0x95, 0xe1, 0x0a, 0xe7, 0x2d, 0xa1, 0xeb, 0x38, 0xcc, 0xb0, 0xdc, 0x8b, 0x9f, 0x01, 0x08, 0xa5,
-	0x86, 0x8b, 0x24, 0x75, 0x7f, 0x33, 0x53, 0xf7, 0xe7, 0x2d, 0xba, 0x80, 0x09, 0x7a, 0x12, 0x3f,
-	0x58, 0x7c, 0xa0, 0x3b, 0x52, 0x84, 0x4b, 0x63, 0x0e, 0xf4, 0x5c, 0x2f, 0xa0, 0x96, 0x00, 0x16,
-	0xf8, 0x92, 0xd9, 0x70, 0xc3, 0x02, 0xf3, 0x1c, 0xc1, 0xba, 0x04, 0x24, 0x9c, 0xb7, 0x78, 0x68,
-	0xcc, 0x02, 0x17, 0xe6, 0xaf, 0xd2, 0xfa, 0xfb, 0x0a, 0x68, 0xac, 0x66, 0x23, 0x85, 0xcd, 0x3e,
-	0x68, 0x21, 0xbe, 0x80, 0x6e, 0x88, 0x98, 0x15, 0xeb, 0x08, 0x9a, 0x72, 0x9d, 0x47, 0x8f, 0xf4,
-	0x88, 0x42, 0xea, 0x6c, 0xa0, 0x43, 0xc1, 0xf3, 0x39, 0x9c, 0x55, 0x89, 0xd3, 0xa7, 0xac, 0xcf,
-	0x00, 0x42, 0x2a, 0x7f, 0x1e, 0xdb, 0x22, 0x37, 0x09, 0x73, 0x0c, 0x97, 0x59, 0xce, 0x31, 0x2b,
-	0xae, 0x82, 0x1e, 0x81, 0x16, 0x22, 0x10, 0x48, 0x3e, 0xdd, 0x72, 0x17, 0x3b, 0x06, 0x88, 0xc0,
-	0x0b, 0x7e, 0x43, 0x53, 0x68, 0xc6, 0xf2, 0x65, 0x7e, 0x09, 0xaa, 0x80, 0x19, 0x50, 0x08, 0x2a,
-	0xca, 0x1d, 0xf5, 0x0a, 0x57, 0x45, 0xe6, 0x4e, 0x00, 0x0d, 0xcb, 0x05, 0x38, 0xa4, 0x2a, 0x60,
-	0x30, 0x03, 0x37, 0x43, 0x12, 0x76, 0x58, 0xbe, 0x48, 0x0b, 0xb4, 0x10, 0x09, 0x40, 0x51, 0x1d,
-	0x1a, 0x93, 0x44, 0xc2, 0x38, 0xf8, 0xc9, 0xb5, 0x10, 0x29, 0xe0, 0x3c, 0x49, 0xe4, 0x60, 0x61,
-	0x84, 0x12, 0xd5, 0x41, 0x96, 0xf5, 0x6a, 0xb1, 0x5e, 0x89, 0xe6, 0xa7, 0x03, 0xa8, 0x48, 0x8d,
-	0x2a, 0x4f, 0x6c, 0xe9, 0xae, 0xb7, 0xd9, 0x48, 0x4f, 0x84, 0x51, 0xf9, 0x09, 0x54, 0x24, 0x14,
-	0x82, 0xaf, 0x91, 0xc6, 0x25, 0x32, 0xb6, 0xdf, 0x23, 0xd7, 0x7f, 0x3d, 0xd6, 0xc6, 0x23, 0x19,
-	0x0d, 0x4e, 0x2c, 0xd0, 0xcc, 0x9a, 0x0a, 0xc5, 0xd8, 0x87, 0x12, 0x8d, 0x88, 0x23, 0x14, 0xb6,
-	0xf7, 0xcb, 0x4d, 0xf4, 0x19, 0x00, 0x57, 0x58, 0x9c, 0x31, 0x43, 0x55, 0x4f, 0x58, 0x2a, 0x27,
-	0x0d, 0xa0, 0x94, 0x90, 0x25, 0x90, 0x41, 0x6a, 0x35, 0x62, 0x38, 0x82, 0x08, 0xbd, 0x21, 0xc2,
-	0x10, 0xcb, 0x5c, 0xf2, 0x02, 0x37, 0x53, 0xe3, 0x92, 0x92, 0xcb, 0xfc, 0x57, 0x88, 0xef, 0x91,
-	0xb8, 0x8e, 0xa0, 0x2a, 0xa3, 0x05, 0x3c, 0x28, 0x64, 0x00, 0x08, 0x0b, 0xaf, 0x55, 0x1b, 0xaa,
-	0x32, 0x68, 0xc0, 0x57, 0xc9, 0xc0, 0x11, 0x96, 0xab, 0xfd, 0x05, 0xd4, 0x12, 0xb0, 0x02, 0x0f,
-	0xfa, 0xd9, 0x60, 0xc3, 0x7c, 0xb1, 0x0e, 0x9e, 0xfc, 0xdb, 0x4f, 0x1f, 0x2a, 0xbf, 0xff, 0xe9,
-	0x43, 0xe5, 0xbf, 0x7f, 0xfa, 0x50, 0xf9, 0xd3, 0x9f, 0x8f, 0xec, 0x60, 0x3c, 0xeb, 0xed, 0xf4,
-	0xdd, 0x8b, 0xdd, 0xa9, 0xd5, 0x1f, 0x5f, 0x0d, 0xb0, 0x27, 0x3f, 0xf9, 0x5e, 0x7f, 0x37, 0xfa,
-	0xa7, 0x59, 0xbd, 0x12, 0x5d, 0x6e, 0xff, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff, 0x25, 0xbb, 0x66,
-	0x2a, 0xaf, 0x35, 0x00, 0x00,
+	// 4152 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x5b, 0x4b, 0x73, 0xdb, 0xc8,
+	0x76, 0x16, 0xf8, 0x04, 0x0e, 0x29, 0x09, 0x6a, 0xc9, 0x34, 0x4d, 0x8f, 0xc7, 0x1e, 0x78, 0x66,
+	0xae, 0x47, 0x33, 0x57, 0xd2, 0xa5, 0xe6, 0x65, 0xfb, 0x8e, 0x5d, 0x96, 0x28, 0x8d, 0xe9, 0x71,
+	0x6c, 0x05, 0x94, 0x95, 0x47, 0x25, 0x61, 0x41, 0x64, 0x93, 0x84, 0x4d, 0x11, 0xb8, 0x00, 0x68,
+	0x8f, 0x66, 0x93, 0x5d, 0xee, 0x2f, 0xc8, 0x2a, 0x95, 0xaa, 0xd4, 0x5d, 0x67, 0x91, 0x64, 0x97,
+	0xca, 0x22, 0x8b, 0x6c, 0x52, 0x49, 0xa5, 0xea, 0xfe, 0x82, 0x54, 0x6a, 0x7e, 0x49, 0xaa, 0x5f,
+	0x40, 0xe3, 0xc1, 0x87, 0x5c, 0xc9, 0x62, 0x46, 0x40, 0xf7, 0x39, 0xdd, 0xa7, 0xcf, 0x39, 0x7d,
+	0x1e, 0x1f, 0x68, 0xd8, 0xea, 0x8d, 0x6d, 0x3c, 0x09, 0x76, 0xdd, 0x81, 0x4f, 0xfe, 0xdb, 0x71,
+	0x3d, 0x27, 0x70, 0x50, 0xde, 0x1d, 0xf8, 0x8d, 0x0f, 0x87, 0x8e, 0x33, 0x1c, 0xe3, 0x5d, 0x3a,
+	0x74, 0x3e, 0x1d, 0xec, 0xf6, 0xa7, 0x9e, 0x15, 0xd8, 0xce, 0x84, 0x11, 0x35, 0x6e, 0x26, 0xe7,
+	0xf1, 0x85, 0x1b, 0x5c, 0xf2, 0xc9, 0xdb, 0xc9, 0xc9, 0xc0, 0xbe, 0xc0, 0x7e, 0x60, 0x5d, 0xb8,
+	0x9c, 0x20, 0xb5, 0xfa, 0x3b, 0xcf, 0x72, 0x5d, 0xec, 0x71, 0x11, 0x1a, 0x5b, 0x43, 0x67, 0xe8,
+	0xd0, 0xc7, 0x5d, 0xf2, 0xc4, 0x47, 0x6b, 0x5c, 0x5c, 0x6b, 0x1a, 0x8c, 0xe8, 0xff, 0xd8, 0xb8,
+	0xd1, 0x80, 0x82, 0x89, 0x5d, 0x07, 0x21, 0x28, 0x4c, 0xac, 0x0b, 0x5c, 0x57, 0xee, 0x28, 0xf7,
+	0x34, 0x93, 0x3e, 0x1b, 0x0f, 0xa1, 0x74, 0xe0, 0x59, 0x93, 0xde, 0x08, 0xdd, 0x82, 0x82, 0x87,
+	0x5d, 0x87, 0xce, 0x56, 0x9a, 0xda, 0x0e, 0x39, 0x30, 0x61, 0x33, 0xe9, 0x70, 0xc8, 0x9c, 0x93,
+	0x98, 0x1f, 0x43, 0xe1, 0xd8, 0x1e, 0x63, 0x74, 0x17, 0x4a, 0x3d, 0xe7, 0xe2, 0xc2, 0x0e, 0x38,
+	0x73, 0x85, 0x32, 0x1f, 0xd2, 0x21, 0x93, 0x4f, 0x91, 0x05, 0x5c, 0x2b, 0x18, 0x89, 0x05, 0xc8,
+	0xb3, 0x71, 0x13, 0x8a, 0x07, 0x63, 0xa7, 0xf7, 0x86, 0x4c, 0x8e, 0x2c, 0x7f, 0x24, 0x44, 0x23,
+	0xcf, 0xc6, 0x07, 0x50, 0x7a, 0x79, 0xfe, 0x1a, 0xf7, 0x82, 0xcc, 0xd9, 0x1b, 0x90, 0x3f, 0xb5,
+	0x86, 0x99, 0x67, 0xfa, 0xdb, 0x1c, 0xa8, 0x44, 0xf2, 0xf6, 0x64, 0xe0, 0x2c, 0x3a, 0xd6, 0x97,
+	0x50, 0xee, 0x79, 0xd8, 0x0a, 0x70, 0x9f, 0x0a, 0x56, 0x69, 0x36, 0x76, 0x98, 0xee, 0x77, 0x84,
+	0xee, 0x77, 0x4e, 0x85, 0x71, 0x4c, 0x41, 0x8a, 0x6e, 0x01, 0xf8, 0xf6, 0x4f, 0xb8, 0x7b, 0x7e,
+	0x19, 0x60, 0xbf, 0x9e, 0xbf, 0xa3, 0xdc, 0x2b, 0x98, 0x1a, 0x19, 0x39, 0x20, 0x03, 0xe8, 0x0e,
+	0x54, 0xfa, 0xd8, 0xef, 0x79, 0xb6, 0x4b, 0x3c, 0xa2, 0x5e, 0xa4, 0xb2, 0xc9, 0x43, 0xe8, 0x17,
+	0xa0, 0x9e, 0x53, 0xb5, 0x63, 0xbf, 0x5e, 0xbe, 0x93, 0x0f, 0x75, 0xc6, 0x6c, 0x61, 0x86, 0x93,
+	0x68, 0x07, 0x34, 0x62, 0xc9, 0xae, 0x3d, 0x19, 0x38, 0xf5, 0x12, 0x95, 0x70, 0x23, 0x3c, 0xc3,
+	0x93, 0x69, 0x30, 0x22, 0x87, 0x34, 0x55, 0x8b, 0x3f, 0xa1, 0x0f, 0x40, 0x0b, 0x9c, 0x8b, 0x73,
+	0x3f, 0x70, 0x26, 0xb8, 0xae, 0xde, 0x51, 0xee, 0xa9, 0x66, 0x34, 0xf0, 0xac, 0xa0, 0x16, 0xf4,
+	0xa2, 0xf1, 0x08, 0xaa, 0x32, 0x37, 0xda, 0x81, 0xaa, 0xd5, 0xeb, 0x61, 0xdf, 0xef, 0x8e, 0xf1,
+	0x5b, 0x3c, 0xa6, 0xaa, 0x5a, 0x6b, 0x56, 0x76, 0xa8, 0x0b, 0x75, 0x7a, 0x8e, 0x8b, 0xcd, 0x0a,
+	0x23, 0x78, 0x4e, 0xe6, 0x8d, 0xdf, 0xe5, 0x00, 0x98, 0xa0, 0x94, 0xfd, 0x2e, 0x94, 0x98, 0xb8,
+	0xf5, 0x82, 0x64, 0x7d, 0x7e, 0x12, 0x3e, 0x85, 0x6e, 0x43, 0x61, 0x84, 0x2d, 0xa1, 0xe4, 0x98,
+	0x83, 0xd0, 0x09, 0xf4, 0x39, 0x80, 0xeb, 0x39, 0x6f, 0xf1, 0xc4, 0x9a, 0xf4, 0x70, 0x3d, 0x9f,
+	0xd6, 0x89, 0x34, 0x4d, 0x88, 0xfd, 0xe9, 0xb9, 0x20, 0x2e, 0x66, 0x10, 0x47, 0xd3, 0xe8, 0x5b,
+	0xd8, 0xe8, 0xdb, 0x1e, 0xee, 0x05, 0x5d, 0x69, 0x83, 0x52, 0x9a, 0x47, 0x67, 0x54, 0x27, 0xd1,
+	0x36, 0x9f, 0x42, 0x39, 0xf0, 0xec, 0xe1, 0x10, 0x7b, 0xf5, 0x32, 0x95, 0xbb, 0x4a, 0xe9, 0x4f,
+	0xd9, 0x98, 0x29, 0x26, 0x33, 0x9d, 0xf0, 0x31, 0x54, 0x22, 0x1d, 0xf9, 0x68, 0x0f, 0x2a, 0x4c,
+	0x13, 0xcc, 0x92, 0x0a, 0xdd, 0x7e, 0x5d, 0xda, 0x9e, 0xda, 0x11, 0xce, 0xc3, 0x67, 0xe3, 0xaf,
+	0x73, 0x50, 0xe6, 0x3b, 0xa1, 0x5a, 0xa8, 0x62, 0xb6, 0x85, 0xd0, 0xaa, 0x0e, 0x79, 0x6b, 0x3c,
+	0xa6, 0x4a, 0x55, 0x4d, 0xf2, 0x88, 0x6e, 0x82, 0xd6, 0xf3, 0x9c, 0x49, 0xd7, 0x77, 0x71, 0x8f,
+	0x3a, 0xa6, 0x66, 0xaa, 0x64, 0xa0, 0xe3, 0xe2, 0x1e, 0x91, 0x93, 0x38, 0x29, 0xb5, 0x93, 0x66,
+	0xd2, 0x67, 0x54, 0x87, 0x32, 0xbb, 0xa0, 0x3e, 0xf5, 0xd3, 0xbc, 0x29, 0x5e, 0xd1, 0x5d, 0x58,
+	0xa5, 0x4b, 0x91, 0xe0, 0xf4, 0x13, 0x71, 0xa7, 0x12, 0x65, 0xab, 0x92, 0xc1, 0x53, 0x3e, 0x86,
+	0x1e, 0x40, 0x85, 0x12, 0xbd, 0xb6, 0x83, 0x20, 0x54, 0xd3, 0x8d, 0xd4, 0x1d, 0x6a, 0xf1, 0xe8,
+	0x68, 0x02, 0xa1, 0x7e, 0x46, 0x89, 0xd1, 0x97, 0x7c, 0x83, 0x9e, 0x15, 0xf4, 0x46, 0xdd, 0xa9,
+	0x4b, 0xfd, 0x75, 0xad, 0xa9, 0x33, 0xe7, 0xf0, 0x9c, 0xc9, 0x21, 0x99, 0x78, 0xe5, 0x9a, 0x74,
+	0x0b, 0xfe, 0x62, 0xec, 0x43, 0x95, 0x39, 0xce, 0x4b, 0xcf, 0x1e, 0xda, 0x13, 0x74, 0x17, 0x0a,
+	0x6f, 0xec, 0x49, 0x9f, 0x7b, 0x2d, 0x53, 0x29, 0x9b, 0xfa, 0xc1, 0x9e, 0xf4, 0x4d, 0x3a, 0x69,
+	0x3c, 0x86, 0x12, 0x63, 0x5a, 0x14, 0x0f, 0x6a, 0x90, 0xb3, 0x99, 0x97, 0x6a, 0x07, 0xa5, 0x9f,
+	0xff, 0xfb, 0x76, 0xae, 0xdd, 0x32, 0x73, 0x76, 0xdf, 0xe8, 0x40, 0x85, 0xbb, 0xab, 0x35, 0x19,
+	0x62, 0xf4, 0x11, 0x14, 0xc7, 0xce, 0x3b, 0xec, 0x65, 0x05, 0x3c, 0x36, 0x43, 0x48, 0xa6, 0x24,
+	0x66, 0x67, 0xb9, 0x3c, 0x9b, 0x31, 0xfe, 0x0c, 0x74, 0x36, 0x20, 0xf9, 0xdc, 0x52, 0xb1, 0x34,
+	0xba, 0x72, 0xb9, 0x99, 0x57, 0xce, 0xf8, 0xaf, 0x12, 0x00, 0xe3, 0x13, 0xd7, 0xf4, 0x2a, 0x0b,
+	0xaf, 0xcf, 0xbe, 0xcb, 0x9f, 0x41, 0xc9, 0xa1, 0x0a, 0xae, 0x6f, 0x48, 0x01, 0x49, 0x36, 0x8a,
+	0xc9, 0x09, 0x92, 0x91, 0x50, 0x4d, 0x47, 0xc2, 0x3d, 0x58, 0x75, 0x2d, 0x0f, 0x4f, 0x82, 0x2e,
+	0x97, 0x2e, 0x43, 0x5d, 0x55, 0x46, 0xc1, 0x2d, 0xb8, 0x07, 0xab, 0xbd, 0x91, 0x3d, 0xee, 0x77,
+	0x85, 0xdf, 0x56, 0xa4, 0xbb, 0x2c, 0x38, 0x28, 0xc5, 0x21, 0xf7, 0xe4, 0x2f, 0xa1, 0xec, 0x07,
+	0x96, 0x47, 0x82, 0x7c, 0x7e, 0x71, 0x90, 0xe7, 0xa4, 0xe8, 0x6b, 0x50, 0x07, 0xf6, 0xc4, 0xf6,
+	0x47, 0xb8, 0xcf, 0x23, 0xdb, 0x3c, 0xb6, 0x90, 0x36, 0x91, 0x1c, 0x8a, 0xc9, 0xe4, 0xf0, 0x55,
+	0x2c, 0xd0, 0xe9, 0x54, 0xf6, 0x6b, 0x92, 0xec, 0x91, 0x2f, 0xc4, 0x42, 0xde, 0x67, 0xa0, 0x7b,
+	0xd8, 0xea, 0x5f, 0xca, 0x41, 0xac, 0x4a, 0x2f, 0xec, 0x3a, 0x1d, 0x97, 0x5c, 0x68, 0x2f, 0x16,
+	0x1d, 0x35, 0xba, 0x83, 0x2e, 0x6b, 0x87, 0xb8, 0x70, 0x2c, 0x44, 0xde, 0x86, 0x42, 0xe0, 0x61,
+	0xcc, 0xaf, 0x2f, 0xd3, 0x24, 0xcb, 0xbd, 0x26, 0x9d, 0x20, 0xce, 0x4c, 0xfe, 0xfa, 0xf5, 0x55,
+	0x49, 0xd7, 0x9c, 0x82, 0xcd, 0x10, 0xd7, 0xe9, 0x5b, 0xc1, 0xf4, 0xc2, 0xaf, 0xaf, 0xa5, 0x57,
+	0xe1, 0x53, 0xe8, 0x01, 0xdc, 0x10, 0xdb, 0x0a, 0x83, 0xfb, 0x5d, 0x7f, 0x4a, 0x93, 0x4b, 0x1d,
+	0xd1, 0xe3, 0x5c, 0x0f, 0x09, 0xb8, 0xf9, 0x3a, 0x6c, 0x3a, 0x9b, 0x77, 0x60, 0xd9, 0xe3, 0xa9,
+	0x87, 0xeb, 0x9b, 0xd9, 0xbc, 0xc7, 0x6c, 0x1a, 0x7d, 0x0d, 0xd7, 0xd3, 0xbc, 0x81, 0x13, 0x58,
+	0xe3, 0xfa, 0x16, 0xe5, 0xbc, 0x96, 0xe4, 0x3c, 0x25, 0x93, 0xcf, 0x0a, 0x6a, 0x49, 0x2f, 0x3f,
+	0x2b, 0xa8, 0xa0, 0x57, 0x8c, 0x7f, 0xca, 0x81, 0x4a, 0xca, 0x1d, 0x51, 0x56, 0x0c, 0xec, 0x31,
+	0x8e, 0x85, 0x11, 0x32, 0x69, 0xd2, 0x61, 0xb4, 0x0d, 0x1a, 0xf9, 0xdb, 0x0d, 0x2e, 0x5d, 0x56,
+	0x32, 0xad, 0x35, 0x57, 0x43, 0x9a, 0xd3, 0x4b, 0x17, 0x13, 0x7f, 0x61, 0x4f, 0x8b, 0x8a, 0x89,
+	0x6f, 0x41, 0x63, 0x02, 0x13, 0xf7, 0x85, 0x85, 0x7e, 0x18, 0x11, 0xa3, 0x06, 0xa8, 0xf4, 0x1a,
+	0x78, 0x78, 0x42, 0xf3, 0x1d, 0x49, 0x05, 0xfc, 0x1d, 0x7d, 0x02, 0x65, 0x87, 0x9a, 0xc6, 0xaf,
+	0xab, 0x69, 0x93, 0x8a, 0x39, 0xf4, 0x39, 0x68, 0xe7, 0xa4, 0x40, 0x33, 0xf1, 0xc0, 0xe7, 0x9e,
+	0xc4, 0xce, 0x71, 0xc0, 0x47, 0xcd, 0x68, 0x3e, 0x2c, 0xd3, 0x88, 0x17, 0x55, 0x79, 0x99, 0xf6,
+	0x0d, 0x68, 0xe4, 0x18, 0x2c, 0x6a, 0x6e, 0xc9, 0x51, 0xb3, 0x20, 0x02, 0xe5, 0x96, 0x1c, 0x28,
+	0x0b, 0x22, 0x36, 0x9a, 0xa0, 0x8a, 0x3d, 0xd0, 0x1d, 0x28, 0xd2, 0x5d, 0xb8, 0xb6, 0x41, 0x92,
+	0x80, 0x4d, 0xa0, 0x8f, 0xa1, 0xe8, 0x91, 0x2d, 0x78, 0xf4, 0x58, 0x63, 0x14, 0x62, 0x63, 0x93,
+	0x4d, 0x1a, 0x7f, 0x0e, 0xc0, 0x0e, 0x28, 0x02, 0x22, 0x3b, 0x66, 0x2c, 0x20, 0x0a, 0x87, 0x65,
+	0x53, 0xc4, 0x90, 0x74, 0x87, 0xae, 0x87, 0x07, 0x7c, 0xf1, 0x84, 0x02, 0x54, 0xa1, 0x00, 0x63,
+	0x9f, 0xc6, 0x5b, 0xd7, 0xea, 0xd1, 0xc0, 0xf6, 0x09, 0xac, 0xd9, 0x13, 0x77, 0x4a, 0xaa, 0x0e,
+	0x3c, 0xb0, 0x7f, 0xc4, 0x7e, 0x3d, 0x47, 0x6d, 0xb0, 0x4a, 0x47, 0x4f, 0xf8, 0xa0, 0xf1, 0x97,
+	0x50, 0xec, 0x8c, 0x2c, 0xaf, 0x8f, 0x76, 0x01, 0x7a, 0x21, 0x37, 0x17, 0x69, 0x5d, 0xdc, 0x5a,
+	0x3e, 0x6c, 0x4a, 0x24, 0xd9, 0x67, 0x3e, 0xb1, 0x82, 0x91, 0x7c, 0x66, 0x74, 0x1b, 0x2a, 0xce,
+	0x34, 0xa0, 0x72, 0x90, 0xea, 0x9b, 0x95, 0x04, 0xc0, 0x86, 0x08, 0x31, 0xb1, 0x50, 0xc8, 0x14,
+	0xb7, 0x90, 0x96, 0x69, 0x21, 0x4d, 0x58, 0xc8, 0x83, 0x8d, 0x43, 0x5a, 0x0f, 0xd3, 0xf4, 0x89,
+	0x7f, 0x33, 0xc5, 0xfe, 0xc2, 0xf4, 0x9a, 0xc8, 0x07, 0xf9, 0x74, 0x3e, 0xa8, 0x41, 0x69, 0xea,
+	0xf6, 0xad, 0x80, 0x55, 0x29, 0xaa, 0xc9, 0xdf, 0x9e, 0x15, 0xd4, 0x9c, 0x9e, 0x37, 0xf6, 0x01,
+	0xb5, 0x27, 0xa4, 0xb6, 0x09, 0x96, 0xdf, 0xd4, 0xb8, 0x0e, 0xeb, 0xcf, 0x6d, 0x5f, 0xe6, 0x78,
+	0x56, 0x50, 0x15, 0x3d, 0x67, 0x3c, 0x02, 0x3d, 0x9a, 0xf0, 0x5d, 0x67, 0xe2, 0xd3, 0x9b, 0x4b,
+	0x98, 0xe4, 0x32, 0x6d, 0x35, 0x5c, 0x90, 0x15, 0xdb, 0x1e, 0x7f, 0x32, 0x7e, 0xab, 0xc0, 0x46,
+	0x0b, 0x8f, 0xf1, 0x95, 0x54, 0xb0, 0x05, 0xc5, 0x81, 0xe3, 0xf5, 0x30, 0xaf, 0xda, 0xd8, 0x8b,
+	0xa8, 0xe4, 0xf2, 0x51, 0x25, 0xf7, 0x39, 0x6c, 0xf8, 0xee, 0xd8, 0x0e, 0xba, 0x81, 0x67, 0x4d,
+	0x7c, 0xee, 0x16, 0x4c, 0x27, 0x3a, 0x9d, 0x38, 0x8d, 0xc6, 0x8d, 0x7f, 0x50, 0x00, 0x75, 0x48,
+	0xde, 0xe2, 0x11, 0x9e, 0x8b, 0x72, 0x17, 0x4a, 0x2c, 0x75, 0x66, 0xe6, 0x7c, 0x36, 0x95, 0xb4,
+	0x49, 0x21, 0xd3, 0x26, 0xbc, 0x2a, 0xc8, 0xc7, 0xca, 0xcf, 0x78, 0x2a, 0x2b, 0x2e, 0x99, 0xca,
+	0xb8, 0x29, 0xff, 0x35, 0x0f, 0xe8, 0x60, 0x1a, 0x66, 0xe9, 0x2b, 0x89, 0x5c, 0x8b, 0xb5, 0x1c,
+	0x5a, 0x46, 0x65, 0x52, 0x5d, 0x54, 0x99, 0xc4, 0x65, 0x2f, 0x2d, 0x9b, 0x86, 0x45, 0xa6, 0xcc,
+	0x2f, 0xcc, 0x94, 0xe5, 0x25, 0x32, 0xa5, 0x3a, 0x3b, 0x53, 0xae, 0x41, 0xae, 0xdd, 0xe2, 0xad,
+	0x63, 0xae, 0xdd, 0x4a, 0x64, 0x09, 0x2d, 0x99, 0x25, 0xa4, 0x12, 0x07, 0xde, 0xaf, 0xc4, 0xa9,
+	0x2c, 0x5f, 0xe2, 0x70, 0x0b, 0xfe, 0x63, 0x0e, 0x36, 0x8f, 0xe9, 0x50, 0xca, 0x84, 0x8b, 0x2b,
+	0xcd, 0x84, 0xd7, 0xe5, 0xd2, 0x5e, 0xb7, 0xbc, 0xaa, 0x8b, 0x4b, 0xa8, 0xba, 0x3c, 0x5b, 0xd5,
+	0x71, 0xd5, 0x96, 0x92, 0xaa, 0xdd, 0x82, 0x22, 0x05, 0x6f, 0xf8, 0xe5, 0x63, 0x2f, 0x09, 0xff,
+	0x51, 0x97, 0xf4, 0x1f, 0x63, 0x02, 0x5b, 0x3c, 0x80, 0xbd, 0x87, 0xce, 0x7e, 0x05, 0x15, 0x96,
+	0x8c, 0xfc, 0x80, 0x04, 0xc8, 0x9c, 0xdc, 0x2e, 0x51, 0x8a, 0x0e, 0x19, 0x37, 0x81, 0x12, 0xd1,
+	0x67, 0xe3, 0x77, 0x0a, 0x6c, 0x90, 0x18, 0x17, 0xdf, 0x6d, 0x41, 0x88, 0xba, 0x0d, 0x85, 0x81,
+	0xe7, 0x5c, 0x64, 0x36, 0xeb, 0x64, 0x02, 0xdd, 0x84, 0x5c, 0xe0, 0xc4, 0x0c, 0xc3, 0xa7, 0x73,
+	0x01, 0x69, 0xa1, 0x4a, 0x93, 0xe9, 0xc5, 0x39, 0xf6, 0xa8, 0xc2, 0x0a, 0x26, 0x7f, 0x23, 0x9d,
+	0xa6, 0x87, 0xdf, 0x62, 0xcf, 0xc7, 0xd4, 0xad, 0x55, 0x53, 0xbc, 0x92, 0x5e, 0x39, 0x6a, 0x54,
+	0x68, 0xaf, 0xcc, 0x0e, 0x9c, 0xee, 0x95, 0x23, 0x32, 0x9a, 0x0a, 0xf9, 0xb3, 0xf1, 0x9f, 0x0a,
+	0x6c, 0xb2, 0x5c, 0xc4, 0x5b, 0x15, 0x7e, 0x4e, 0x81, 0x3a, 0x28, 0xb3, 0x50, 0x87, 0x1b, 0xa0,
+	0xfa, 0x5d, 0xa9, 0x95, 0xd2, 0xcc, 0xb2, 0xcf, 0xf1, 0xb0, 0xbb, 0xb1, 0xa0, 0x37, 0xa3, 0x15,
+	0x8a, 0xa3, 0x16, 0x85, 0xf9, 0xa8, 0x85, 0x04, 0x27, 0x14, 0xe7, 0xc0, 0x09, 0xc6, 0xc3, 0xd0,
+	0x47, 0xe2, 0xa7, 0xb9, 0x1b, 0x43, 0x01, 0x66, 0x74, 0x7d, 0xcf, 0x99, 0xbd, 0xe3, 0x9c, 0x0b,
+	0xec, 0x2d, 0x59, 0x26, 0x17, 0xb7, 0xcc, 0x09, 0x6c, 0xb2, 0x04, 0x77, 0x75, 0x49, 0xb2, 0x13,
+	0x9d, 0xf1, 0x40, 0xac, 0x78, 0x75, 0xff, 0x37, 0x2c, 0x40, 0xc7, 0xe3, 0x69, 0x32, 0xdc, 0x7c,
+	0x12, 0x21, 0x18, 0x4a, 0xba, 0x13, 0x0c, 0xe1, 0x8c, 0x8f, 0x41, 0x0d, 0x9c, 0x2e, 0x39, 0x2f,
+	0xab, 0xc4, 0x62, 0x7a, 0x28, 0x07, 0x0e, 0xf9, 0xeb, 0x1b, 0xff, 0xa6, 0x40, 0xad, 0x33, 0x3d,
+	0x27, 0x51, 0xe8, 0x1c, 0x5f, 0xe9, 0xd2, 0xd4, 0x62, 0x3d, 0xb9, 0x9c, 0x93, 0x0a, 0xc4, 0x07,
+	0xb8, 0xc9, 0x67, 0x84, 0x08, 0x4a, 0x12, 0xde, 0xbb, 0xfc, 0xac, 0x7b, 0xf7, 0x29, 0x14, 0xd9,
+	0xd5, 0x2f, 0xcc, 0xb8, 0xfa, 0x6c, 0xda, 0xf8, 0x0d, 0xac, 0x7d, 0x8f, 0x03, 0xda, 0x8f, 0x44,
+	0xc2, 0xcf, 0xeb, 0x57, 0x3e, 0x82, 0xaa, 0x33, 0x18, 0xf8, 0x38, 0xe0, 0x41, 0x30, 0x47, 0x9b,
+	0xa2, 0x0a, 0x1b, 0x63, 0x61, 0x30, 0xdd, 0xa6, 0xe4, 0xa5, 0x28, 0x69, 0x7c, 0x0a, 0x6b, 0x2f,
+	0xdf, 0x62, 0xef, 0x9d, 0x67, 0x07, 0xb8, 0x3d, 0xe9, 0xe3, 0x1f, 0x89, 0xfd, 0x6d, 0xf2, 0x40,
+	0xf7, 0xcc, 0x9b, 0xec, 0xc5, 0xf8, 0xab, 0x3c, 0xac, 0x9d, 0x4c, 0xaf, 0x22, 0xdb, 0x16, 0x14,
+	0xdf, 0x5a, 0xe3, 0x29, 0x4b, 0x04, 0x55, 0x93, 0xbd, 0x90, 0x82, 0x69, 0xea, 0x8d, 0x79, 0x82,
+	0x24, 0x8f, 0xe8, 0x03, 0x52, 0xb9, 0xf5, 0xa6, 0x9e, 0x6f, 0xbf, 0x65, 0x58, 0x95, 0x6a, 0x46,
+	0x03, 0xe8, 0x0b, 0xd0, 0xfa, 0x78, 0x6c, 0x5f, 0xd8, 0x02, 0xa6, 0x5a, 0xe3, 0x15, 0x73, 0x4b,
+	0x8c, 0x9a, 0x11, 0x01, 0xfa, 0x02, 0x50, 0x60, 0x79, 0x43, 0x1c, 0x74, 0x69, 0x1b, 0x27, 0xa5,
+	0xeb, 0xbc, 0xa9, 0xb3, 0x19, 0x22, 0x61, 0x8b, 0x25, 0x90, 0x6d, 0xd8, 0x90, 0xa9, 0xa3, 0x14,
+	0x9d, 0x37, 0xd7, 0x23, 0x62, 0xa6, 0xc6, 0x4f, 0x60, 0x8d, 0x44, 0x1e, 0xec, 0x75, 0x3d, 0xdc,
+	0x73, 0xbc, 0xbe, 0x4f, 0x13, 0x6f, 0xde, 0x5c, 0x65, 0xa3, 0x26, 0x1b, 0x44, 0xbf, 0x86, 0x75,
+	0x47, 0xa8, 0xb3, 0xcb, 0xd4, 0xc8, 0xf2, 0xfa, 0x26, 0xcb, 0x60, 0x31, 0x55, 0x9b, 0x6b, 0x4e,
+	0x5c, 0xf5, 0x35, 0x28, 0xf5, 0xe9, 0x25, 0xa3, 0x75, 0x90, 0x6a, 0xf2, 0x37, 0x96, 0xb7, 0x39,
+	0x0a, 0xfc, 0xcf, 0x0a, 0xac, 0x86, 0x86, 0x20, 0x9b, 0x26, 0x2c, 0xac, 0x24, 0x2c, 0x4c, 0x3b,
+	0x09, 0x9a, 0x38, 0xbb, 0xb4, 0xcb, 0xcb, 0xf1, 0x4e, 0x82, 0x0e, 0x3d, 0xb5, 0xfc, 0x51, 0x96,
+	0xcc, 0xf9, 0xe5, 0x65, 0x8e, 0x75, 0x5a, 0x85, 0xf9, 0x9d, 0xd6, 0x7f, 0x28, 0x92, 0x13, 0x31,
+	0x85, 0x6d, 0x41, 0x91, 0x56, 0xc5, 0x54, 0x6e, 0xd5, 0x64, 0x2f, 0xe8, 0x0b, 0x12, 0xd9, 0x98,
+	0x9a, 0xd9, 0x9d, 0x47, 0xac, 0x4b, 0x92, 0x79, 0x4d, 0x41, 0x12, 0x07, 0xcf, 0xf3, 0x09, 0xf0,
+	0x1c, 0x6d, 0x43, 0x89, 0xd9, 0x88, 0x4b, 0x97, 0xb5, 0x14, 0xa7, 0x20, 0xb4, 0x03, 0xc7, 0x09,
+	0xc2, 0x48, 0x9f, 0x49, 0xcb, 0x28, 0x0c, 0x1b, 0xd6, 0x0f, 0x1d, 0xf7, 0x52, 0xbe, 0x11, 0x37,
+	0x21, 0xef, 0x7b, 0xbd, 0xf4, 0x85, 0x20, 0xa3, 0x64, 0xb2, 0xef, 0x0b, 0x9c, 0x4c, 0x9e, 0xec,
+	0xfb, 0x01, 0x39, 0x42, 0xa8, 0x57, 0x71, 0x84, 0x70, 0x40, 0x6a, 0x9f, 0x96, 0xbf, 0x7f, 0xc6,
+	0x5f, 0xb0, 0xf6, 0xe9, 0x0a, 0x37, 0x16, 0x41, 0x61, 0x30, 0x0d, 0x71, 0x69, 0xfa, 0x4c, 0x72,
+	0xcc, 0xc8, 0xf6, 0x03, 0xc7, 0xbb, 0xe4, 0xb1, 0x43, 0xbc, 0x1a, 0x7b, 0xb0, 0xfe, 0x47, 0xd6,
+	0xf8, 0xcd, 0x15, 0x24, 0x3a, 0x81, 0xf5, 0xef, 0xc7, 0xce, 0xb9, 0xcc, 0xb1, 0x54, 0xfd, 0x54,
+	0x87, 0xb2, 0x6b, 0x05, 0x01, 0xf6, 0x44, 0xbd, 0x29, 0x5e, 0x49, 0x13, 0x2c, 0xa0, 0x1d, 0x3f,
+	0x04, 0x6f, 0x52, 0x2d, 0xa0, 0x20, 0x61, 0xe0, 0x0d, 0xad, 0x3c, 0xde, 0xc1, 0x7a, 0xcb, 0x1e,
+	0x0c, 0x64, 0x51, 0x3e, 0x06, 0x75, 0x82, 0xdf, 0x75, 0xb3, 0x0f, 0x50, 0x9e, 0xe0, 0x77, 0xf4,
+	0x9b, 0xd9, 0xc7, 0xa0, 0x3a, 0xe3, 0x3e, 0xa3, 0x4a, 0x99, 0xb2, 0xec, 0x8c, 0xfb, 0x94, 0xaa,
+	0x0e, 0x65, 0x7f, 0x64, 0x8d, 0xc7, 0xce, 0x3b, 0x6e, 0x4c, 0xf1, 0x6a, 0xbc, 0x06, 0x3d, 0xda,
+	0x38, 0xea, 0x5d, 0xc5, 0xce, 0xfe, 0x0c, 0xc1, 0xf9, 0xf6, 0xf4, 0x90, 0x62, 0x7f, 0x71, 0x37,
+	0x92, 0xb4, 0x5c, 0x08, 0xdf, 0x68, 0x8a, 0x36, 0xf7, 0x0a, 0x36, 0xba, 0x0d, 0x95, 0x63, 0x9f,
+	0xdc, 0x56, 0x46, 0xad, 0x43, 0x7e, 0x60, 0xff, 0xc8, 0x2f, 0x27, 0x79, 0x34, 0xbe, 0x86, 0x2a,
+	0x23, 0xe0, 0xc2, 0x4b, 0x14, 0x1a, 0xa5, 0xa0, 0x85, 0xb7, 0xe7, 0x39, 0x21, 0xec, 0x40, 0x5f,
+	0x8c, 0x7f, 0x51, 0xa0, 0x46, 0xf6, 0x79, 0xe9, 0x62, 0xfe, 0x4d, 0x81, 0x6d, 0x71, 0xd6, 0x5c,
+	0xce, 0x09, 0x76, 0xa1, 0xec, 0x4e, 0x83, 0x6e, 0x60, 0x09, 0x64, 0x7e, 0x4b, 0xdc, 0xcd, 0x53,
+	0xcb, 0x0b, 0xd7, 0x7a, 0xba, 0x62, 0x96, 0x5c, 0x3a, 0x84, 0x1e, 0x41, 0x95, 0x85, 0x4f, 0xae,
+	0xac, 0x3c, 0xff, 0xc6, 0xc1, 0x93, 0x07, 0x57, 0x8b, 0x2f, 0xb3, 0x56, 0xfa, 0xd1, 0xf8, 0x41,
+	0x05, 0x34, 0x47, 0xc8, 0x6a, 0xbc, 0x82, 0xf5, 0xc4, 0x4e, 0xf1, 0x2b, 0xab, 0x24, 0xae, 0x2c,
+	0x51, 0x4b, 0x60, 0x0d, 0xb9, 0x0a, 0xc8, 0x23, 0xb9, 0x5d, 0x7d, 0x2b, 0xb0, 0x78, 0x3a, 0xa4,
+	0xcf, 0xc6, 0x23, 0xd8, 0xca, 0x12, 0x85, 0xd6, 0x60, 0xa1, 0x37, 0x68, 0x26, 0x7b, 0x49, 0xaf,
+	0x49, 0xee, 0xe0, 0xf7, 0x38, 0x2e, 0xd6, 0x02, 0xfb, 0x8e, 0x00, 0x25, 0xfd, 0xef, 0xac, 0x89,
+	0xee, 0x49, 0x5e, 0xad, 0x48, 0x31, 0x3c, 0x74, 0xaa, 0xd0, 0xb3, 0xef, 0x49, 0xb7, 0x24, 0x97,
+	0x49, 0xc9, 0x5d, 0xd5, 0xb8, 0x0f, 0x75, 0x56, 0xdb, 0x9f, 0x5e, 0xb8, 0x64, 0xa0, 0x83, 0x83,
+	0xd0, 0x69, 0x6e, 0x01, 0xd0, 0x23, 0xe1, 0xa0, 0x6b, 0xf7, 0xb9, 0xef, 0x68, 0x7c, 0xa4, 0xdd,
+	0x37, 0xfe, 0x18, 0x6a, 0x26, 0x9e, 0xe0, 0x77, 0x32, 0xa7, 0xf0, 0xde, 0x79, 0x8c, 0x24, 0xd7,
+	0x05, 0xc1, 0xb8, 0xeb, 0xe3, 0x9e, 0x33, 0xe9, 0x8b, 0x72, 0x08, 0x82, 0x60, 0xdc, 0x61, 0x23,
+	0xa4, 0x46, 0x3f, 0x1c, 0x63, 0xcb, 0x8b, 0x95, 0x88, 0x4b, 0xba, 0xa0, 0x31, 0x02, 0xfd, 0x64,
+	0x1a, 0xf0, 0x2e, 0x94, 0x0b, 0x14, 0x56, 0x39, 0x8a, 0x5c, 0xe5, 0x7c, 0x00, 0x85, 0xc0, 0x1a,
+	0x8a, 0x0b, 0xaa, 0xb2, 0x7e, 0xc1, 0x1a, 0x9a, 0x74, 0x34, 0xc2, 0x45, 0xf3, 0x33, 0x70, 0x51,
+	0x63, 0x20, 0xfa, 0xa2, 0xf8, 0x66, 0xff, 0xe7, 0xd0, 0xe7, 0xdf, 0x28, 0xb0, 0xf1, 0x3d, 0xe6,
+	0x47, 0xf2, 0xa5, 0xca, 0x5c, 0x80, 0xcc, 0xca, 0x1c, 0x90, 0x39, 0xab, 0xf8, 0x2c, 0x2c, 0x2a,
+	0x3e, 0x63, 0x2d, 0xfa, 0x2d, 0x00, 0x0a, 0xe6, 0x77, 0xc3, 0xcf, 0x9b, 0x05, 0x92, 0xb9, 0x03,
+	0x6b, 0xdc, 0xb1, 0x7f, 0xc2, 0x46, 0x9b, 0x5e, 0x3a, 0x2e, 0x36, 0x13, 0x6d, 0x31, 0xa4, 0x1c,
+	0x1a, 0x24, 0x27, 0x19, 0xc4, 0xd8, 0xa7, 0x17, 0xe5, 0x6a, 0x4b, 0x19, 0x7f, 0xa7, 0x80, 0x2e,
+	0xb8, 0x42, 0xe5, 0xc4, 0xa0, 0x75, 0x65, 0x01, 0xb4, 0xfe, 0xff, 0xae, 0x22, 0xc4, 0xa0, 0x50,
+	0xf9, 0x60, 0xc6, 0x2b, 0xd0, 0x4f, 0xad, 0xe1, 0x7b, 0x78, 0xce, 0x5c, 0xaf, 0x35, 0xb6, 0x00,
+	0x91, 0xad, 0xe2, 0xbe, 0x42, 0x72, 0x3a, 0x19, 0x3d, 0xb5, 0x86, 0xa1, 0x86, 0x6a, 0x50, 0x62,
+	0xd8, 0xb9, 0xf8, 0xea, 0xcd, 0xde, 0x18, 0xb2, 0xde, 0x1b, 0x4f, 0xfb, 0xb8, 0xcb, 0x65, 0x61,
+	0x85, 0xc6, 0x2a, 0x1f, 0x65, 0x2b, 0x1b, 0x1d, 0x76, 0x24, 0xb6, 0x22, 0x8f, 0x17, 0x0d, 0x16,
+	0xf9, 0x98, 0xec, 0x91, 0x60, 0x34, 0xae, 0x46, 0x47, 0xcb, 0xcd, 0x3c, 0x9a, 0xf1, 0x9d, 0x08,
+	0xb4, 0xef, 0xe5, 0xea, 0xc6, 0x75, 0xb8, 0x96, 0x60, 0x67, 0x82, 0x19, 0xbf, 0x12, 0x29, 0x56,
+	0x56, 0x80, 0xd0, 0xa3, 0x32, 0x4b, 0x8f, 0x32, 0x0b, 0x5f, 0xe8, 0x3e, 0xa0, 0xc3, 0x11, 0xee,
+	0xbd, 0xb9, 0xba, 0xd9, 0x8c, 0x5f, 0xc2, 0x66, 0x8c, 0x95, 0xeb, 0xac, 0x06, 0x25, 0xfc, 0xa3,
+	0xed, 0x07, 0x3e, 0x4f, 0x4e, 0xfc, 0xcd, 0xd8, 0x83, 0x32, 0x3f, 0xc5, 0xb2, 0xa7, 0xff, 0x0e,
+	0x36, 0x59, 0xdc, 0x6b, 0xd1, 0x5f, 0x5a, 0x48, 0xb5, 0x81, 0x73, 0xfe, 0x5a, 0x64, 0x7e, 0xe7,
+	0xfc, 0xf5, 0x8c, 0xbb, 0xf7, 0x0b, 0xd8, 0x64, 0x31, 0x66, 0x01, 0xbb, 0xf1, 0x14, 0x6a, 0xa1,
+	0x96, 0xe3, 0xb4, 0xb5, 0x98, 0x1e, 0xb4, 0xd0, 0x63, 0x23, 0x57, 0xcb, 0xc9, 0xae, 0x66, 0xfc,
+	0x36, 0x07, 0x15, 0xf1, 0xc9, 0x88, 0x34, 0x29, 0xdf, 0x24, 0x0f, 0x7a, 0x4b, 0x3a, 0x28, 0x25,
+	0xe1, 0xcf, 0xfe, 0xd1, 0x24, 0xf0, 0x2e, 0xa3, 0x18, 0xb7, 0x13, 0xbb, 0x12, 0x8d, 0x14, 0x17,
+	0xb1, 0x21, 0x63, 0xa1, 0x74, 0x8d, 0x36, 0x54, 0xe5, 0x85, 0xc8, 0x21, 0xdf, 0xe0, 0x4b, 0x71,
+	0xc8, 0x37, 0xf8, 0x12, 0xdd, 0x95, 0x75, 0x94, 0x8a, 0x1d, 0x6c, 0xee, 0x41, 0xee, 0x5b, 0xa5,
+	0xd1, 0x02, 0x2d, 0x5c, 0x3d, 0x63, 0x9d, 0x8f, 0xe2, 0xeb, 0xc4, 0x51, 0xd4, 0x70, 0x95, 0xed,
+	0xaf, 0xa1, 0x22, 0xfd, 0x24, 0x03, 0x6d, 0xc0, 0xea, 0xa1, 0xf9, 0xf2, 0x45, 0xf7, 0xe0, 0xc9,
+	0xe1, 0x0f, 0xc7, 0xed, 0xe7, 0xcf, 0xf5, 0x15, 0xb4, 0x05, 0x3a, 0x1d, 0xea, 0xfc, 0xd0, 0x3e,
+	0xe9, 0xfe, 0x41, 0xbb, 0xd3, 0x39, 0x6a, 0xe9, 0xca, 0xf6, 0x36, 0x40, 0xf4, 0x6b, 0x0c, 0xa4,
+	0x42, 0xe1, 0x55, 0xe7, 0xc8, 0xd4, 0x57, 0xc8, 0xd3, 0x93, 0x57, 0xa7, 0x2f, 0x75, 0x85, 0x3c,
+	0x1d, 0x77, 0x0e, 0x7f, 0xd0, 0x73, 0xdb, 0x9f, 0xb3, 0x0f, 0xac, 0xf4, 0xab, 0x68, 0x15, 0x54,
+	0xf3, 0xa8, 0x73, 0x64, 0x9e, 0x1d, 0xb5, 0x18, 0xf5, 0x71, 0xfb, 0xf9, 0x91, 0xae, 0xa0, 0x32,
+	0xe4, 0x5b, 0x6d, 0x53, 0xcf, 0x6d, 0xef, 0x0b, 0xd0, 0x90, 0x22, 0x1f, 0xa8, 0x02, 0xe5, 0xce,
+	0xe9, 0x13, 0xf3, 0x94, 0x92, 0x6b, 0x50, 0x34, 0x8f, 0x9e, 0xb4, 0xfe, 0x44, 0x57, 0xc8, 0x3a,
+	0xc7, 0xed, 0x17, 0xed, 0xce, 0xd3, 0xa3, 0x96, 0x9e, 0xdb, 0x7e, 0x08, 0x5a, 0xd8, 0xef, 0x93,
+	0x45, 0x5f, 0xbc, 0x7c, 0x71, 0xc4, 0x96, 0x7f, 0xd6, 0x79, 0xf9, 0x82, 0x09, 0xf3, 0xbc, 0xfd,
+	0xe2, 0x48, 0xcf, 0x91, 0x8d, 0x3a, 0x7f, 0xf8, 0x5c, 0xcf, 0x93, 0x87, 0xc3, 0xce, 0x99, 0x5e,
+	0x68, 0xfe, 0x7e, 0x03, 0xf2, 0x4f, 0x4e, 0xda, 0xe8, 0x11, 0x40, 0xf4, 0xe1, 0x0b, 0xd5, 0xf8,
+	0xcf, 0x55, 0x12, 0x5f, 0xc2, 0x1a, 0xb5, 0x14, 0x92, 0x7e, 0x74, 0xe1, 0x06, 0x97, 0xc6, 0x0a,
+	0xfa, 0x06, 0x2a, 0xd2, 0x47, 0x2c, 0x74, 0x9d, 0x2e, 0x90, 0xfe, 0xac, 0xd5, 0x88, 0x7f, 0x77,
+	0x32, 0x56, 0xd0, 0x7d, 0x50, 0xc5, 0xf7, 0x2a, 0xc4, 0xaa, 0xd6, 0xc4, 0x77, 0xad, 0xc6, 0xb5,
+	0xc4, 0x28, 0x0f, 0x0a, 0x2b, 0x44, 0xe6, 0xe8, 0x4b, 0x15, 0x97, 0x39, 0xf5, 0xe9, 0x6a, 0x8e,
+	0xcc, 0x5f, 0x41, 0x45, 0xfa, 0xbe, 0xc4, 0x65, 0x4e, 0x7f, 0x71, 0x6a, 0xc8, 0xf5, 0x8e, 0xb1,
+	0x82, 0x0e, 0xa0, 0x2a, 0x7f, 0x21, 0x40, 0x75, 0x5e, 0xe3, 0xa5, 0x3e, 0x1a, 0xcc, 0xd9, 0xfa,
+	0x3b, 0x58, 0x8d, 0x41, 0xe6, 0xe8, 0x86, 0xac, 0xb0, 0xf8, 0x2a, 0x49, 0x94, 0xd8, 0x58, 0x41,
+	0xdf, 0x02, 0x44, 0x00, 0x38, 0x3f, 0x79, 0x0a, 0x11, 0x6f, 0xe8, 0x09, 0x46, 0xdf, 0x58, 0x41,
+	0x8f, 0x59, 0x02, 0x11, 0x5e, 0xe6, 0x61, 0xeb, 0x62, 0x26, 0x7f, 0x7a, 0xe3, 0x3d, 0x85, 0x9c,
+	0x5e, 0xc6, 0x3a, 0xf9, 0xe9, 0x33, 0xe0, 0xcf, 0x39, 0xa7, 0x7f, 0x08, 0x15, 0x09, 0xf3, 0xe4,
+	0x8a, 0x4f, 0xa3, 0xa0, 0xd9, 0x02, 0x1c, 0xc2, 0x7a, 0x02, 0xcc, 0x44, 0x37, 0x99, 0xe5, 0x32,
+	0x21, 0xce, 0xec, 0x45, 0xbe, 0x82, 0x8a, 0xf4, 0x9d, 0x8e, 0x4b, 0x90, 0xfe, 0x72, 0x97, 0x61,
+	0x7a, 0x19, 0x92, 0xe7, 0x87, 0xcf, 0x40, 0xe9, 0x97, 0x32, 0x3d, 0x5f, 0x24, 0x66, 0xfa, 0xf8,
+	0x2a, 0xc9, 0x1f, 0xd3, 0x45, 0xa6, 0xe7, 0xbc, 0x91, 0xe9, 0xe2, 0x8c, 0x7a, 0x82, 0xd1, 0x67,
+	0xc2, 0xcb, 0xb8, 0x77, 0xcc, 0x72, 0xcb, 0x0a, 0xff, 0x00, 0xca, 0x1c, 0xf0, 0x41, 0x9b, 0x71,
+	0xf8, 0x67, 0x01, 0xe7, 0x3d, 0x05, 0x3d, 0x00, 0x55, 0x60, 0x42, 0xfc, 0xa6, 0x27, 0x20, 0xa2,
+	0x39, 0xfb, 0x3e, 0x86, 0x32, 0x07, 0x7f, 0xf9, 0xbe, 0x71, 0x28, 0xb8, 0x71, 0x33, 0xc5, 0x49,
+	0x2b, 0xc4, 0x33, 0x9a, 0x63, 0x89, 0xc1, 0xa3, 0xf8, 0x44, 0x17, 0x89, 0xc5, 0x27, 0x79, 0xa1,
+	0x78, 0xc3, 0x66, 0xac, 0xa0, 0x26, 0x8b, 0x4f, 0x92, 0xd4, 0x09, 0xe0, 0xa8, 0xb1, 0x16, 0x63,
+	0xf1, 0x69, 0x4c, 0x5b, 0x13, 0x44, 0xfc, 0x8a, 0x65, 0x73, 0x26, 0x37, 0xdb, 0x53, 0xd0, 0x3e,
+	0xa8, 0x02, 0x38, 0xe2, 0x4c, 0x09, 0x1c, 0x29, 0x8b, 0xa9, 0x09, 0xaa, 0xc0, 0x8e, 0x38, 0x53,
+	0x02, 0x4a, 0xca, 0x96, 0x51, 0x10, 0xc5, 0x64, 0x4c, 0x72, 0x66, 0x6c, 0x77, 0x1f, 0x54, 0xd1,
+	0x26, 0x73, 0xa6, 0x04, 0x5c, 0xc4, 0x43, 0x76, 0xb2, 0x97, 0x96, 0x43, 0x36, 0x65, 0xae, 0x25,
+	0xf0, 0x86, 0x65, 0x2e, 0x8f, 0xc6, 0xc8, 0x9f, 0x8c, 0xc7, 0x68, 0x06, 0xd9, 0x1c, 0xf6, 0x5d,
+	0x28, 0x1c, 0xfb, 0xbd, 0x37, 0x88, 0x5d, 0x0f, 0x09, 0xcb, 0x69, 0x6c, 0x48, 0x23, 0x42, 0xda,
+	0x3d, 0x05, 0x3d, 0x83, 0xf5, 0x18, 0x2e, 0x73, 0xd6, 0xe4, 0xc1, 0x26, 0x1b, 0xad, 0x99, 0xeb,
+	0xff, 0x4f, 0x40, 0x65, 0x78, 0xc4, 0x59, 0x53, 0xe8, 0x3a, 0x0e, 0x4f, 0x2c, 0xf6, 0xe2, 0xc7,
+	0x00, 0x42, 0xa9, 0xe1, 0x22, 0x49, 0xdd, 0x5f, 0xcf, 0xd4, 0xfd, 0x59, 0x93, 0x2e, 0x60, 0x82,
+	0x9e, 0xc4, 0x1d, 0xe6, 0x1f, 0xe8, 0x96, 0x14, 0xe1, 0xd2, 0x58, 0x05, 0x3d, 0xd7, 0x53, 0x58,
+	0x4f, 0x00, 0x12, 0x7c, 0xc9, 0x6c, 0x98, 0x62, 0x8e, 0x79, 0x5a, 0xb0, 0x2a, 0x01, 0x10, 0x67,
+	0x4d, 0x1e, 0x1a, 0xb3, 0x40, 0x89, 0xd9, 0xab, 0x34, 0xff, 0xbe, 0x02, 0x1a, 0xab, 0xf5, 0x48,
+	0x61, 0xb3, 0x0f, 0x5a, 0x88, 0x4b, 0xa0, 0x6b, 0x22, 0x66, 0xc5, 0x3a, 0x89, 0x86, 0x5c, 0x1f,
+	0xd2, 0x23, 0xdd, 0xa7, 0x50, 0x3c, 0x1b, 0xe8, 0x50, 0xd0, 0x7d, 0x06, 0x67, 0x55, 0xe2, 0xf4,
+	0x29, 0xeb, 0x63, 0x80, 0x90, 0xca, 0x9f, 0xc5, 0x36, 0xcf, 0x4d, 0xc2, 0x1c, 0xc3, 0x65, 0x96,
+	0x73, 0xcc, 0x92, 0xab, 0xa0, 0xfb, 0xa0, 0x85, 0xc8, 0x05, 0x92, 0x4f, 0xb7, 0xd8, 0xc5, 0x8e,
+	0x00, 0x22, 0xd0, 0x83, 0xdf, 0xd0, 0x14, 0x0a, 0xb2, 0x78, 0x99, 0x5f, 0x83, 0x2a, 0xe0, 0x09,
+	0x14, 0x82, 0x91, 0x72, 0x27, 0xbe, 0xc4, 0x55, 0x91, 0xb9, 0x13, 0x00, 0xc5, 0x62, 0x01, 0x0e,
+	0xa9, 0x0a, 0x18, 0x3c, 0xc1, 0xcd, 0x90, 0x84, 0x2b, 0x16, 0x2f, 0xd2, 0x04, 0x2d, 0x44, 0x10,
+	0x50, 0x54, 0x87, 0xc6, 0x24, 0x91, 0xb0, 0x11, 0x7e, 0x72, 0x2d, 0x44, 0x18, 0x38, 0x4f, 0x12,
+	0x71, 0x98, 0x1b, 0xa1, 0x44, 0x75, 0x90, 0x65, 0xbd, 0xf5, 0x58, 0x8f, 0x45, 0xf3, 0xd3, 0x01,
+	0x54, 0xa4, 0x06, 0x97, 0x27, 0xb6, 0x74, 0xb7, 0xdc, 0xa8, 0xa7, 0x27, 0xc2, 0xa8, 0xfc, 0x10,
+	0x2a, 0x12, 0x7a, 0xc1, 0xd7, 0x48, 0xe3, 0x19, 0x19, 0xdb, 0xef, 0x91, 0xeb, 0xbf, 0x1a, 0x6b,
+	0xff, 0x91, 0x8c, 0x22, 0x27, 0x16, 0x68, 0x64, 0x4d, 0x85, 0x62, 0xec, 0x43, 0x89, 0x46, 0xc4,
+	0x21, 0x0a, 0x61, 0x81, 0xc5, 0x26, 0xfa, 0x0c, 0x80, 0x2b, 0x2c, 0xce, 0x98, 0xa1, 0xaa, 0x87,
+	0x2c, 0x95, 0x93, 0xc6, 0x51, 0x4a, 0xc8, 0x12, 0x38, 0x21, 0xb5, 0x1a, 0x31, 0xfc, 0x41, 0x84,
+	0xde, 0x10, 0x99, 0x88, 0x65, 0x2e, 0x79, 0x81, 0xeb, 0xa9, 0x71, 0x49, 0xc9, 0x65, 0xfe, 0xa3,
+	0xc7, 0xf7, 0x48, 0x5c, 0x2d, 0xa8, 0xca, 0x28, 0x03, 0x0f, 0x0a, 0x19, 0xc0, 0xc3, 0xdc, 0x6b,
+	0xd5, 0x86, 0xaa, 0x0c, 0x36, 0xf0, 0x55, 0x32, 0xf0, 0x87, 0xc5, 0x6a, 0x7f, 0x0a, 0xeb, 0x09,
+	0x38, 0x82, 0x07, 0xfd, 0x6c, 0x90, 0x62, 0xb6, 0x58, 0x07, 0x0f, 0xff, 0xfd, 0xe7, 0x0f, 0x95,
+	0xdf, 0xff, 0xfc, 0xa1, 0xf2, 0x3f, 0x3f, 0x7f, 0xa8, 0xfc, 0xe9, 0x2f, 0x87, 0x76, 0x30, 0x9a,
+	0x9e, 0xef, 0xf4, 0x9c, 0x8b, 0x5d, 0xd7, 0xea, 0x8d, 0x2e, 0xfb, 0xd8, 0x93, 0x9f, 0x7c, 0xaf,
+	0xb7, 0x1b, 0xfd, 0x0b, 0xb6, 0xf3, 0x12, 0x5d, 0x6e, 0xff, 0x7f, 0x03, 0x00, 0x00, 0xff, 0xff,
+	0xf0, 0x4c, 0x13, 0x2e, 0xd6, 0x36, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -9479,6 +9570,30 @@ func (m *Trigger) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.CronCatchUp != 0 {
+		i = encodeVarintPfs(dAtA, i, uint64(m.CronCatchUp))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.CronJitter != nil {
+		{
+			size, err := m.CronJitter.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPfs(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.CronTimezone) > 0 {
+		i -= len(m.CronTimezone)
+		copy(dAtA[i:], m.CronTimezone)
+		i = encodeVarintPfs(dAtA, i, uint64(len(m.CronTimezone)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.Commits != 0 {
 		i = encodeVarintPfs(dAtA, i, uint64(m.Commits))
 		i--
@@ -10767,6 +10882,20 @@ func (m *FinishCommitRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.Provenance) > 0 {
+		for iNdEx := len(m.Provenance) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Provenance[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPfs(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
 	if m.Datums != nil {
 		{
 			size, err := m.Datums.MarshalToSizedBuffer(dAtA[:i])
@@ -13844,6 +13973,17 @@ func (m *Trigger) Size() (n int) {
 	if m.Commits != 0 {
 		n += 1 + sovPfs(uint64(m.Commits))
 	}
+	l = len(m.CronTimezone)
+	if l > 0 {
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.CronJitter != nil {
+		l = m.CronJitter.Size()
+		n += 1 + l + sovPfs(uint64(l))
+	}
+	if m.CronCatchUp != 0 {
+		n += 1 + sovPfs(uint64(m.CronCatchUp))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -14397,6 +14537,12 @@ func (m *FinishCommitRequest) Size() (n int) {
 		l = m.Datums.Size()
 		n += 1 + l + sovPfs(uint64(l))
 	}
+	if len(m.Provenance) > 0 {
+		for _, e := range m.Provenance {
+			l = e.Size()
+			n += 1 + l + sovPfs(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -17065,6 +17211,93 @@ func (m *Trigger) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CronTimezone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CronTimezone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CronJitter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CronJitter == nil {
+				m.CronJitter = &types.Duration{}
+			}
+			if err := m.CronJitter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CronCatchUp", wireType)
+			}
+			m.CronCatchUp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CronCatchUp |= CronCatchUp(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -20521,6 +20754,40 @@ func (m *FinishCommitRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Provenance", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPfs
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPfs
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPfs
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Provenance = append(m.Provenance, &CommitProvenance{})
+			if err := m.Provenance[len(m.Provenance)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPfs(dAtA[iNdEx:])
@@ -36,6 +36,12 @@ const (
 	PPSJobIDEnv = "PPS_JOB_ID"
 	// PPSSpecCommitEnv is the namespace in which pachyderm is deployed
 	PPSSpecCommitEnv = "PPS_SPEC_COMMIT"
+	// PPSEgressSecretEnv is the env var that holds the value of the secret
+	// referenced by a pipeline's egress target, if it has one.
+	PPSEgressSecretEnv = "PPS_EGRESS_SECRET"
+	// PPSSpoutSecretEnv is the env var that holds the value of the secret
+	// referenced by a built-in HTTP spout, if it has one.
+	PPSSpoutSecretEnv = "PPS_SPOUT_SECRET"
 	// PPSInputPrefix is the prefix of the path where datums are downloaded
 	// to.  A datum of an input named `XXX` is downloaded to `/pfs/XXX/`.
 	PPSInputPrefix = "/pfs"
@@ -83,6 +89,25 @@ func DatumTagPrefix(salt string) string {
 	return hex.EncodeToString(h.Sum(nil))[:4]
 }
 
+// OutputRepo returns the name of the repo that the named output 'output' of
+// the pipeline 'pipeline' is committed to. The repo is created with the output,
+// and a pipeline can't declare an output whose repo already exists.
+func OutputRepo(pipeline, output string) string {
+	return pipeline + "_" + output
+}
+
+// CanaryPipeline returns the name of the canary of the pipeline 'pipeline',
+// which is created by 'pachctl update pipeline --canary'.
+func CanaryPipeline(pipeline string) string {
+	return pipeline + "-canary"
+}
+
+// GlobalDatumTagPrefix is the prefix of the tags of datum output trees in the
+// global datum cache, which is shared by every pipeline that sets
+// global_datum_cache. It can't collide with a DatumTagPrefix, as it isn't
+// hex.
+const GlobalDatumTagPrefix = "global_"
+
 // NewPFSInput returns a new PFS input. It only includes required options.
 func NewPFSInput(repo string, glob string) *pps.Input {
 	return &pps.Input{
@@ -611,6 +636,93 @@ func (c APIClient) GetLogsLoki(
 	return resp
 }
 
+// GetFilteredLogs is like GetLogs, but only returns the messages logged
+// between 'since' and 'until' that match the regular expression 'grep'. Pass
+// zero values to skip those filters.
+func (c APIClient) GetFilteredLogs(
+	pipelineName string,
+	jobID string,
+	data []string,
+	datumID string,
+	master bool,
+	follow bool,
+	tail int64,
+	since time.Time,
+	until time.Time,
+	grep string,
+) *LogsIter {
+	request := pps.GetLogsRequest{
+		Master: master,
+		Follow: follow,
+		Tail:   tail,
+	}
+	return c.getFilteredLogs(&request, pipelineName, jobID, data, datumID, since, until, grep)
+}
+
+// GetPersistedLogs gets the logs that were persisted in the stats commits of
+// finished jobs. 'pipelineName', 'jobID', 'data', and 'datumID' are filters,
+// as in GetLogs, and one of 'pipelineName' and 'jobID' must be set. Only
+// messages logged between 'since' and 'until' that match the regular
+// expression 'grep' are returned; pass zero values to skip those filters.
+func (c APIClient) GetPersistedLogs(
+	pipelineName string,
+	jobID string,
+	data []string,
+	datumID string,
+	since time.Time,
+	until time.Time,
+	grep string,
+) *LogsIter {
+	request := pps.GetLogsRequest{
+		Persisted: true,
+	}
+	return c.getFilteredLogs(&request, pipelineName, jobID, data, datumID, since, until, grep)
+}
+
+func (c APIClient) getFilteredLogs(
+	request *pps.GetLogsRequest,
+	pipelineName string,
+	jobID string,
+	data []string,
+	datumID string,
+	since time.Time,
+	until time.Time,
+	grep string,
+) *LogsIter {
+	resp := &LogsIter{}
+	if pipelineName != "" {
+		request.Pipeline = NewPipeline(pipelineName)
+	}
+	if jobID != "" {
+		request.Job = NewJob(jobID)
+	}
+	request.DataFilters = data
+	if datumID != "" {
+		request.Datum = &pps.Datum{
+			Job: NewJob(jobID),
+			ID:  datumID,
+		}
+	}
+	if !since.IsZero() {
+		var err error
+		if request.Since, err = types.TimestampProto(since); err != nil {
+			resp.err = errors.EnsureStack(err)
+			return resp
+		}
+	}
+	if !until.IsZero() {
+		var err error
+		if request.Until, err = types.TimestampProto(until); err != nil {
+			resp.err = errors.EnsureStack(err)
+			return resp
+		}
+	}
+	request.Grep = grep
+	resp.logsClient, resp.err = c.PpsAPIClient.GetLogs(c.Ctx(), request)
+	resp.err = grpcutil.ScrubGRPC(resp.err)
+	return resp
+}
+
 // CreatePipeline creates a new pipeline, pipelines are the main computation
 // object in PPS they create a flow of data from a set of input Repos to an
 // output Repo (which has the same name as the pipeline). Whenever new data is
@@ -658,6 +770,20 @@ func (c APIClient) CreatePipeline(
 	return grpcutil.ScrubGRPC(err)
 }
 
+// DryRunPipeline computes the datums that the pipeline described by 'request'
+// would process against the current heads of its input branches, without
+// creating it. At most 'sampleSize' of the datums are returned.
+func (c APIClient) DryRunPipeline(request *pps.CreatePipelineRequest, sampleSize int64) (*pps.DryRunPipelineResponse, error) {
+	resp, err := c.PpsAPIClient.DryRunPipeline(
+		c.Ctx(),
+		&pps.DryRunPipelineRequest{
+			Pipeline:   request,
+			SampleSize: sampleSize,
+		},
+	)
+	return resp, grpcutil.ScrubGRPC(err)
+}
+
 // InspectPipeline returns info about a specific pipeline.
 func (c APIClient) InspectPipeline(pipelineName string) (*pps.PipelineInfo, error) {
 	pipelineInfo, err := c.PpsAPIClient.InspectPipeline(
@@ -681,6 +807,32 @@ func (c APIClient) ListPipeline() ([]*pps.PipelineInfo, error) {
 	return pipelineInfos.PipelineInfo, nil
 }
 
+// DrawPipeline returns the graph of the repos and pipelines upstream of
+// 'pipelines' (or of every pipeline, if 'pipelines' is empty), along with the
+// graph rendered in 'format'.
+func (c APIClient) DrawPipeline(format pps.GraphFormat, pipelines ...string) (*pps.DrawPipelineResponse, error) {
+	request := &pps.DrawPipelineRequest{Format: format}
+	for _, pipeline := range pipelines {
+		request.Pipelines = append(request.Pipelines, NewPipeline(pipeline))
+	}
+	resp, err := c.PpsAPIClient.DrawPipeline(c.Ctx(), request)
+	return resp, grpcutil.ScrubGRPC(err)
+}
+
+// InspectCanary compares the outputs of the canary of 'pipeline' with the
+// outputs of 'pipeline'. At most 'maxPaths' paths are returned in each of the
+// response's lists (or 100, if 'maxPaths' is 0).
+func (c APIClient) InspectCanary(pipeline string, maxPaths int64) (*pps.InspectCanaryResponse, error) {
+	resp, err := c.PpsAPIClient.InspectCanary(
+		c.Ctx(),
+		&pps.InspectCanaryRequest{
+			Pipeline: NewPipeline(pipeline),
+			MaxPaths: maxPaths,
+		},
+	)
+	return resp, grpcutil.ScrubGRPC(err)
+}
+
 // ListPipelineHistory returns historical information about pipelines.
 // `pipeline` specifies which pipeline to return history about, if it's equal
 // to "" then ListPipelineHistory returns historical information about all
@@ -708,6 +860,21 @@ func (c APIClient) ListPipelineHistory(pipeline string, history int64) ([]*pps.P
 	return pipelineInfos.PipelineInfo, nil
 }
 
+// InspectPipelineVersion returns info about a specific version of a
+// pipeline, which may be an earlier version than the pipeline's current one.
+func (c APIClient) InspectPipelineVersion(pipeline string, version uint64) (*pps.PipelineInfo, error) {
+	pipelineInfos, err := c.ListPipelineHistory(pipeline, -1)
+	if err != nil {
+		return nil, err
+	}
+	for _, pipelineInfo := range pipelineInfos {
+		if pipelineInfo.Version == version {
+			return pipelineInfo, nil
+		}
+	}
+	return nil, errors.Errorf("pipeline %q has no version %d", pipeline, version)
+}
+
 // DeletePipeline deletes a pipeline along with its output Repo.
 func (c APIClient) DeletePipeline(name string, force bool, splitTransaction ...bool) error {
 	req := &pps.DeletePipelineRequest{
@@ -815,6 +982,75 @@ func (c APIClient) ListSecret() ([]*pps.SecretInfo, error) {
 	return secretInfos.SecretInfo, grpcutil.ScrubGRPC(err)
 }
 
+// CreateTemplate creates a pipeline template, which is a pipeline spec (in
+// JSON or YAML) that is rendered as a Go text/template with 'parameters'. If
+// 'update' is set, an existing template is replaced, and if 'propagate' is also
+// set, every pipeline instantiated from the template is updated.
+func (c APIClient) CreateTemplate(name string, spec string, parameters []*pps.TemplateParameter, update bool, propagate bool) error {
+	_, err := c.PpsAPIClient.CreateTemplate(
+		c.Ctx(),
+		&pps.CreateTemplateRequest{
+			Template:   &pps.PipelineTemplate{Name: name},
+			Parameters: parameters,
+			Spec:       spec,
+			Update:     update,
+			Propagate:  propagate,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// InspectTemplate returns info about a specific pipeline template.
+func (c APIClient) InspectTemplate(name string) (*pps.TemplateInfo, error) {
+	templateInfo, err := c.PpsAPIClient.InspectTemplate(
+		c.Ctx(),
+		&pps.InspectTemplateRequest{
+			Template: &pps.PipelineTemplate{Name: name},
+		},
+	)
+	return templateInfo, grpcutil.ScrubGRPC(err)
+}
+
+// ListTemplate returns info about all pipeline templates.
+func (c APIClient) ListTemplate() ([]*pps.TemplateInfo, error) {
+	templateInfos, err := c.PpsAPIClient.ListTemplate(
+		c.Ctx(),
+		&types.Empty{},
+	)
+	if err != nil {
+		return nil, grpcutil.ScrubGRPC(err)
+	}
+	return templateInfos.TemplateInfo, nil
+}
+
+// DeleteTemplate deletes a pipeline template. Pipelines instantiated from the
+// template are not deleted.
+func (c APIClient) DeleteTemplate(name string) error {
+	_, err := c.PpsAPIClient.DeleteTemplate(
+		c.Ctx(),
+		&pps.DeleteTemplateRequest{
+			Template: &pps.PipelineTemplate{Name: name},
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+// CreatePipelineFromTemplate creates a pipeline by instantiating the latest
+// version of a pipeline template with 'args'.
+func (c APIClient) CreatePipelineFromTemplate(template string, args map[string]string, update bool) error {
+	_, err := c.PpsAPIClient.CreatePipeline(
+		c.Ctx(),
+		&pps.CreatePipelineRequest{
+			Template: &pps.TemplateRef{
+				Template: &pps.PipelineTemplate{Name: template},
+				Args:     args,
+			},
+			Update: update,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
 // CreatePipelineService creates a new pipeline service.
 func (c APIClient) CreatePipelineService(
 	name string,
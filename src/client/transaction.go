@@ -511,9 +511,18 @@ func (c *ppsBuilderClient) RestartDatum(ctx context.Context, req *pps.RestartDat
 func (c *ppsBuilderClient) CreatePipeline(ctx context.Context, req *pps.CreatePipelineRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("CreatePipeline")
 }
+func (c *ppsBuilderClient) DryRunPipeline(ctx context.Context, req *pps.DryRunPipelineRequest, opts ...grpc.CallOption) (*pps.DryRunPipelineResponse, error) {
+	return nil, unsupportedError("DryRunPipeline")
+}
 func (c *ppsBuilderClient) InspectPipeline(ctx context.Context, req *pps.InspectPipelineRequest, opts ...grpc.CallOption) (*pps.PipelineInfo, error) {
 	return nil, unsupportedError("InspectPipeline")
 }
+func (c *ppsBuilderClient) DrawPipeline(ctx context.Context, req *pps.DrawPipelineRequest, opts ...grpc.CallOption) (*pps.DrawPipelineResponse, error) {
+	return nil, unsupportedError("DrawPipeline")
+}
+func (c *ppsBuilderClient) InspectCanary(ctx context.Context, req *pps.InspectCanaryRequest, opts ...grpc.CallOption) (*pps.InspectCanaryResponse, error) {
+	return nil, unsupportedError("InspectCanary")
+}
 func (c *ppsBuilderClient) ListPipeline(ctx context.Context, req *pps.ListPipelineRequest, opts ...grpc.CallOption) (*pps.PipelineInfos, error) {
 	return nil, unsupportedError("ListPipeline")
 }
@@ -547,6 +556,18 @@ func (c *ppsBuilderClient) RunCron(ctx context.Context, req *pps.RunCronRequest,
 func (c *ppsBuilderClient) CreateSecret(ctx context.Context, req *pps.CreateSecretRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("CreateSecret")
 }
+func (c *ppsBuilderClient) CreateTemplate(ctx context.Context, req *pps.CreateTemplateRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("CreateTemplate")
+}
+func (c *ppsBuilderClient) InspectTemplate(ctx context.Context, req *pps.InspectTemplateRequest, opts ...grpc.CallOption) (*pps.TemplateInfo, error) {
+	return nil, unsupportedError("InspectTemplate")
+}
+func (c *ppsBuilderClient) ListTemplate(ctx context.Context, req *types.Empty, opts ...grpc.CallOption) (*pps.TemplateInfos, error) {
+	return nil, unsupportedError("ListTemplate")
+}
+func (c *ppsBuilderClient) DeleteTemplate(ctx context.Context, req *pps.DeleteTemplateRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	return nil, unsupportedError("DeleteTemplate")
+}
 func (c *ppsBuilderClient) DeleteSecret(ctx context.Context, req *pps.DeleteSecretRequest, opts ...grpc.CallOption) (*types.Empty, error) {
 	return nil, unsupportedError("DeleteSecret")
 }
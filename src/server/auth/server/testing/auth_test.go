@@ -1785,6 +1785,36 @@ func TestListRepoNotLoggedInError(t *testing.T) {
 	require.Matches(t, "no authentication token", err.Error())
 }
 
+// TestTemplateNotLoggedInError tests that if a user isn't logged in, and they
+// call InspectTemplate() or ListTemplate(), they get an error.
+func TestTemplateNotLoggedInError(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	deleteAll(t)
+	defer deleteAll(t)
+	alice := tu.UniqueString("alice")
+	aliceClient, anonClient := getPachClient(t, alice), getPachClient(t, "")
+
+	// alice creates a template, and can read it
+	template := tu.UniqueString("template")
+	require.NoError(t, aliceClient.CreateTemplate(template, "pipeline: {name: {{ .name }}}",
+		[]*pps.TemplateParameter{{Name: "name"}}, false, false))
+	defer func() {
+		require.NoError(t, aliceClient.DeleteTemplate(template))
+	}()
+	_, err := aliceClient.InspectTemplate(template)
+	require.NoError(t, err)
+
+	// Anon (non-logged-in user) can't inspect or list templates
+	_, err = anonClient.InspectTemplate(template)
+	require.YesError(t, err)
+	require.Matches(t, "no authentication token", err.Error())
+	_, err = anonClient.ListTemplate()
+	require.YesError(t, err)
+	require.Matches(t, "no authentication token", err.Error())
+}
+
 // TestListRepoNoAuthInfoIfDeactivated tests that if auth isn't activated, then
 // ListRepo returns RepoInfos where AuthInfo isn't set (i.e. is nil)
 func TestListRepoNoAuthInfoIfDeactivated(t *testing.T) {
@@ -3088,6 +3118,64 @@ func TestS3GatewayAuthRequests(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestS3GatewayBucketAuth tests that creating and deleting buckets through
+// the s3 gateway is gated by the caller's scope on the underlying repo
+func TestS3GatewayBucketAuth(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	deleteAll(t)
+	defer deleteAll(t)
+	alice, bob := tu.UniqueString("alice"), tu.UniqueString("bob")
+	aliceClient, bobClient := getPachClient(t, alice), getPachClient(t, bob)
+
+	minioClient := func(c *client.APIClient) *minio.Client {
+		authResp, err := c.GetAuthToken(c.Ctx(), &auth.GetAuthTokenRequest{})
+		require.NoError(t, err)
+		minioClient, err := minio.NewV4("127.0.0.1:30600", authResp.Token, authResp.Token, false)
+		require.NoError(t, err)
+		return minioClient
+	}
+	aliceMinioClient, bobMinioClient := minioClient(aliceClient), minioClient(bobClient)
+
+	// alice creates a bucket, which creates the repo and makes her its owner
+	repo := tu.UniqueString(t.Name())
+	require.NoError(t, aliceMinioClient.MakeBucket(fmt.Sprintf("master.%s", repo), ""))
+	require.ElementsEqual(t, entries(alice, "owner"), getACL(t, aliceClient, repo))
+
+	// bob can't create a bucket in alice's repo
+	err := bobMinioClient.MakeBucket(fmt.Sprintf("branch.%s", repo), "")
+	require.YesError(t, err)
+	require.Equal(t, "Access Denied", err.Error())
+	_, err = aliceClient.InspectBranch(repo, "branch")
+	require.YesError(t, err)
+
+	// once bob is a writer, he can create and delete buckets in the repo
+	_, err = aliceClient.SetScope(aliceClient.Ctx(), &auth.SetScopeRequest{
+		Repo:     repo,
+		Username: bob,
+		Scope:    auth.Scope_WRITER,
+	})
+	require.NoError(t, err)
+	require.NoError(t, bobMinioClient.MakeBucket(fmt.Sprintf("branch.%s", repo), ""))
+	require.NoError(t, bobMinioClient.RemoveBucket(fmt.Sprintf("branch.%s", repo)))
+	_, err = aliceClient.InspectBranch(repo, "branch")
+	require.YesError(t, err)
+
+	// bob isn't an owner, so deleting the last bucket deletes the branch but
+	// not the repo
+	require.NoError(t, bobMinioClient.RemoveBucket(fmt.Sprintf("master.%s", repo)))
+	repoInfo, err := aliceClient.InspectRepo(repo)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(repoInfo.Branches))
+
+	// alice owns the repo, so deleting the last bucket deletes the repo too
+	require.NoError(t, aliceMinioClient.MakeBucket(fmt.Sprintf("master.%s", repo), ""))
+	require.NoError(t, aliceMinioClient.RemoveBucket(fmt.Sprintf("master.%s", repo)))
+	_, err = aliceClient.InspectRepo(repo)
+	require.YesError(t, err)
+}
+
 // TestDeleteFailedPipeline creates a pipeline with an invalid image and then
 // tries to delete it (which shouldn't be blocked by the auth system)
 func TestDeleteFailedPipeline(t *testing.T) {
@@ -3417,6 +3505,44 @@ func TestDebug(t *testing.T) {
 	require.Equal(t, 0, len(expectedFiles))
 }
 
+// TestTemplateOwner tests that only the user that created a pipeline template
+// and admins can update or delete it
+func TestTemplateOwner(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	deleteAll(t)
+	defer deleteAll(t)
+	alice, bob := tu.UniqueString("alice"), tu.UniqueString("bob")
+	aliceClient, bobClient := getPachClient(t, alice), getPachClient(t, bob)
+	adminClient := getPachClient(t, admin)
+
+	template := tu.UniqueString(t.Name())
+	spec := `{"pipeline": {"name": {{ .name }}}, "transform": {"cmd": ["true"]}}`
+	params := []*pps.TemplateParameter{{Name: "name"}}
+	require.NoError(t, aliceClient.CreateTemplate(template, spec, params, false, false))
+	templateInfo, err := bobClient.InspectTemplate(template)
+	require.NoError(t, err)
+	require.Equal(t, gh(alice), templateInfo.Owner)
+
+	// bob can't update or delete alice's template
+	err = bobClient.CreateTemplate(template, spec, params, true, false)
+	require.YesError(t, err)
+	require.Matches(t, "not authorized", err.Error())
+	err = bobClient.DeleteTemplate(template)
+	require.YesError(t, err)
+	require.Matches(t, "not authorized", err.Error())
+
+	// alice and admins can, and updates don't change the owner
+	require.NoError(t, aliceClient.CreateTemplate(template, spec, params, true, false))
+	require.NoError(t, adminClient.CreateTemplate(template, spec, params, true, false))
+	templateInfo, err = bobClient.InspectTemplate(template)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), templateInfo.Version)
+	require.Equal(t, gh(alice), templateInfo.Owner)
+	require.NoError(t, adminClient.DeleteTemplate(template))
+}
+
 func collectCommitInfos(t testing.TB, commitInfoIter client.CommitInfoIterator) []*pfs.CommitInfo {
 	var commitInfos []*pfs.CommitInfo
 	for {
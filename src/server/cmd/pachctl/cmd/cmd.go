@@ -826,6 +826,30 @@ This resets the cluster to its initial state.`,
 	}
 	subcommands = append(subcommands, cmdutil.CreateAlias(editDocs, "edit"))
 
+	rollbackDocs := &cobra.Command{
+		Short: "Return a Pachyderm resource to an earlier version.",
+		Long:  "Return a Pachyderm resource to an earlier version.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(rollbackDocs, "rollback"))
+
+	drawDocs := &cobra.Command{
+		Short: "Draw a graph of Pachyderm resources.",
+		Long:  "Draw a graph of Pachyderm resources.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(drawDocs, "draw"))
+
+	promoteDocs := &cobra.Command{
+		Short: "Promote a trial Pachyderm resource to replace the one it's a trial of.",
+		Long:  "Promote a trial Pachyderm resource to replace the one it's a trial of.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(promoteDocs, "promote"))
+
+	discardDocs := &cobra.Command{
+		Short: "Discard a trial Pachyderm resource.",
+		Long:  "Discard a trial Pachyderm resource.",
+	}
+	subcommands = append(subcommands, cmdutil.CreateAlias(discardDocs, "discard"))
+
 	subcommands = append(subcommands, pfscmds.Cmds()...)
 	subcommands = append(subcommands, ppscmds.Cmds()...)
 	subcommands = append(subcommands, deploycmds.Cmds()...)
@@ -876,6 +900,8 @@ func applyRootUsageFunc(rootCmd *cobra.Command) {
 			"create",
 			"delete",
 			"diff",
+			"discard",
+			"draw",
 			"edit",
 			"finish",
 			"flush",
@@ -883,8 +909,10 @@ func applyRootUsageFunc(rootCmd *cobra.Command) {
 			"glob",
 			"inspect",
 			"list",
+			"promote",
 			"put",
 			"restart",
+			"rollback",
 			"start",
 			"stop",
 			"subscribe",
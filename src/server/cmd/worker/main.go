@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/pachyderm/pachyderm/src/client"
@@ -21,10 +22,12 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/serviceenv"
 	"github.com/pachyderm/pachyderm/src/server/worker"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
 	workerserver "github.com/pachyderm/pachyderm/src/server/worker/server"
 
 	etcd "github.com/coreos/etcd/clientv3"
 	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func main() {
@@ -73,10 +76,42 @@ func getPipelineInfo(pachClient *client.APIClient, env *serviceenv.ServiceEnv) (
 	return ppsutil.GetPipelineInfo(pachClient, env.PPSPipelineName, &pipelinePtr)
 }
 
+// pinImage pins the pipeline's image to the digest of the image that this
+// worker's user container is actually running, which is read from the status
+// of the worker's pod
+func pinImage(env *serviceenv.ServiceEnv, pipelineInfo *pps.PipelineInfo) error {
+	pod, err := env.GetKubeClient().CoreV1().Pods(env.Namespace).Get(env.PodName, metav1.GetOptions{})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != client.PPSWorkerUserContainerName {
+			continue
+		}
+		// Depending on the container runtime, the image ID is either a
+		// reference pinned to a digest, e.g. "docker-pullable://ubuntu@sha256:...",
+		// or the digest itself
+		digest := common.ImageDigest(status.ImageID)
+		if digest == "" && strings.HasPrefix(status.ImageID, "sha256:") {
+			digest = status.ImageID
+		}
+		if digest == "" {
+			return errors.Errorf("unrecognized image ID %q", status.ImageID)
+		}
+		image := pipelineInfo.Transform.Image
+		if i := strings.LastIndex(image, "@"); i >= 0 {
+			image = image[:i]
+		}
+		pipelineInfo.Transform.Image = image + "@" + digest
+		return nil
+	}
+	return errors.Errorf("container %q not found in pod %q", client.PPSWorkerUserContainerName, env.PodName)
+}
+
 func do(config interface{}) error {
 	// must run InstallJaegerTracer before InitWithKube/pach client initialization
 	tracing.InstallJaegerTracerFromEnv()
-	env := serviceenv.InitServiceEnv(serviceenv.NewConfiguration(config))
+	env := serviceenv.InitWithKube(serviceenv.NewConfiguration(config))
 
 	// Construct a client that connects to the sidecar.
 	pachClient := env.GetPachClient(context.Background())
@@ -85,6 +120,15 @@ func do(config interface{}) error {
 		return errors.Wrapf(err, "error getting pipelineInfo")
 	}
 
+	// The global datum cache identifies the pipeline's image by its digest,
+	// as its tag can point at different code over time
+	if pipelineInfo.GlobalDatumCache {
+		if err := pinImage(env, pipelineInfo); err != nil {
+			log.Errorf("not using the global datum cache, as the digest of image %q could not be determined: %v", pipelineInfo.Transform.Image, err)
+			pipelineInfo.GlobalDatumCache = false
+		}
+	}
+
 	// Construct worker API server.
 	workerRcName := ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version)
 	workerInstance, err := worker.NewWorker(pachClient, env.GetEtcdClient(), env.PPSEtcdPrefix, pipelineInfo, env.PodName, env.Namespace, env.StorageRoot, "/")
@@ -1,8 +1,15 @@
 package cmds
 
 import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/src/client"
@@ -10,6 +17,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pps"
 	"github.com/pachyderm/pachyderm/src/server/pkg/cmdutil"
+	"github.com/pachyderm/pachyderm/src/server/worker/local"
 	"github.com/spf13/cobra"
 )
 
@@ -102,6 +110,86 @@ func Cmds() []*cobra.Command {
 	dump.Flags().StringVarP(&worker, "worker", "w", "", "Only collect the dump from the given worker pod.")
 	commands = append(commands, cmdutil.CreateAlias(dump, "debug dump"))
 
+	var dir string
+	var shell bool
+	datum := &cobra.Command{
+		Use:   "{{alias}} <job> <datum>",
+		Short: "Recreate a datum's input directory locally.",
+		Long:  "Recreate the /pfs directory that a job's worker presented to a datum, from the job's input commits, and write the environment variables that the worker ran the datum's user code with. Prints the commands that run the job's transform on the datum, either locally or in the pipeline's image. The datum ID may be either the one shown by 'inspect datum' or by 'list datum'.",
+		Example: `
+		# Recreate datum "d5ef87" of job "3e1f27" in ./d5ef87, and print how to run it
+		$ {{alias}} 3e1f27 d5ef87
+
+		# Recreate the datum in /tmp/datum, and open a shell in the pipeline's image with it mounted at /pfs
+		$ {{alias}} 3e1f27 d5ef87 --dir /tmp/datum --shell`,
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			client, err := client.NewOnUserMachine("debug-datum")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			jobInfo, err := client.InspectJob(args[0], false, true)
+			if err != nil {
+				return err
+			}
+			inputs, err := local.FindDatum(client, jobInfo, args[1])
+			if err != nil {
+				return err
+			}
+			if dir == "" {
+				dir = args[1]
+			}
+			if dir, err = filepath.Abs(dir); err != nil {
+				return errors.EnsureStack(err)
+			}
+			if err := local.DownloadDatum(client, jobInfo, inputs, dir); err != nil {
+				return err
+			}
+			pfsDir := filepath.Join(dir, "pfs")
+			localEnv := filepath.Join(dir, "env")
+			if err := writeShellEnv(localEnv, local.DatumEnv(jobInfo, inputs, pfsDir)); err != nil {
+				return err
+			}
+			dockerEnv := filepath.Join(dir, "docker.env")
+			if err := writeLines(dockerEnv, local.DatumEnv(jobInfo, inputs, "/pfs")); err != nil {
+				return err
+			}
+			transform := jobInfo.Transform
+			var stdin string
+			if transform.Stdin != nil {
+				stdin = filepath.Join(dir, "stdin")
+				if err := writeLines(stdin, transform.Stdin); err != nil {
+					return err
+				}
+			}
+			dockerArgs := []string{"run", "--rm", "-i", "-v", pfsDir + ":/pfs", "--env-file", dockerEnv}
+			if shell {
+				dockerArgs = append(dockerArgs, "-t", "--entrypoint", "/bin/sh", transform.Image)
+				fmt.Fprintf(os.Stderr, "Running: docker %s\n", shellJoin(dockerArgs))
+				cmd := exec.Command("docker", dockerArgs...)
+				cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+				return errors.EnsureStack(cmd.Run())
+			}
+			if len(transform.Cmd) == 0 {
+				return errors.Errorf("job %s's transform has no cmd to run", jobInfo.Job.ID)
+			}
+			redirect := ""
+			if stdin != "" {
+				redirect = " < " + shellJoin([]string{stdin})
+			}
+			fmt.Printf("Recreated the input of datum %s in %s\n", args[1], pfsDir)
+			fmt.Printf("Variables set from secrets are not included in %s or %s\n\n", localEnv, dockerEnv)
+			fmt.Printf("To run the transform locally:\n  (. %s; %s%s)\n\n",
+				shellJoin([]string{localEnv}), shellJoin(transform.Cmd), redirect)
+			fmt.Printf("To run the transform in the pipeline's image:\n  docker %s%s\n",
+				shellJoin(append(dockerArgs, append([]string{"--entrypoint", transform.Cmd[0], transform.Image}, transform.Cmd[1:]...)...)), redirect)
+			return nil
+		}),
+	}
+	datum.Flags().StringVar(&dir, "dir", "", "The directory in which to recreate the datum. Defaults to a directory named for the datum in the current directory.")
+	datum.Flags().BoolVar(&shell, "shell", false, "Open a shell in the pipeline's image (using docker), with the datum's input mounted at /pfs and its environment variables set.")
+	commands = append(commands, cmdutil.CreateAlias(datum, "debug datum"))
+
 	debug := &cobra.Command{
 		Short: "Debug commands for analyzing a running cluster.",
 		Long:  "Debug commands for analyzing a running cluster.",
@@ -143,3 +231,51 @@ func withFile(file string, cb func(*os.File) error) (retErr error) {
 	}()
 	return cb(f)
 }
+
+// writeLines writes each of 'lines' to 'file', followed by a newline
+func writeLines(file string, lines []string) error {
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteString("\n")
+	}
+	return errors.EnsureStack(ioutil.WriteFile(file, buf.Bytes(), 0644))
+}
+
+// writeShellEnv writes each of the KEY=value pairs in 'env' to 'file' as an
+// export statement, quoting the values so that sourcing 'file' with a POSIX
+// shell sets them verbatim
+func writeShellEnv(file string, env []string) error {
+	lines := make([]string, len(env))
+	for i, kv := range env {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("malformed environment variable %q", kv)
+		}
+		lines[i] = fmt.Sprintf("export %s=%s", parts[0], shellQuote(parts[1]))
+	}
+	return writeLines(file, lines)
+}
+
+// shellQuote single-quotes 's', so that a POSIX shell reads it back without
+// expanding anything in it
+func shellQuote(s string) string {
+	return "'" + strings.Replace(s, "'", `'"'"'`, -1) + "'"
+}
+
+// shellJoin joins 'args' into a string that a POSIX shell splits back into
+// 'args'
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg != "" && strings.IndexFunc(arg, func(r rune) bool {
+			return !(r == '/' || r == '.' || r == '-' || r == '_' || r == ':' || r == '=' ||
+				unicode.IsLetter(r) || unicode.IsDigit(r))
+		}) < 0 {
+			quoted[i] = arg
+			continue
+		}
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
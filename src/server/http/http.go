@@ -1,21 +1,27 @@
 package http
 
 import (
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"html/template"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"path"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/auth"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/julienschmidt/httprouter"
 	"golang.org/x/net/context"
+	"golang.org/x/net/webdav"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -28,6 +34,7 @@ func versionPath(p string) string {
 
 var (
 	getFilePath = versionPath("pfs/repos/:repoName/commits/:commitID/files/*filePath")
+	webdavPath  = versionPath("pfs/webdav/*path")
 	servicePath = versionPath("pps/services/:serviceName/*path")
 	loginPath   = versionPath("auth/login")
 	logoutPath  = versionPath("auth/logout")
@@ -41,6 +48,7 @@ type server struct {
 	pachClient     *client.APIClient
 	pachClientOnce sync.Once
 	httpClient     *http.Client
+	webdavHandler  *webdav.Handler
 }
 
 // NewHTTPServer returns a Pachyderm HTTP server.
@@ -52,9 +60,23 @@ func NewHTTPServer(address string) (http.Handler, error) {
 		httpClient: &http.Client{},
 	}
 
+	s.webdavHandler = &webdav.Handler{
+		Prefix:     path.Dir(webdavPath),
+		FileSystem: &pfsFS{s: s},
+		LockSystem: webdav.NewMemLS(),
+	}
+
 	router.GET(getFilePath, s.getFileHandler)
+	router.HEAD(getFilePath, s.getFileHandler)
 	router.GET(servicePath, s.serviceHandler)
 
+	// WebDAV is read-only, so only the methods needed to browse and mount
+	// repos are routed. LOCK and UNLOCK are included because some clients
+	// (e.g. macOS Finder) refuse to mount shares that don't support them.
+	for _, method := range []string{"GET", "HEAD", "OPTIONS", "PROPFIND", "LOCK", "UNLOCK"} {
+		router.Handle(method, webdavPath, s.webdavHandlerFunc)
+	}
+
 	router.POST(loginPath, s.authLoginHandler)
 	router.POST(logoutPath, s.authLogoutHandler)
 	router.POST(servicePath, s.serviceHandler)
@@ -66,36 +88,136 @@ func NewHTTPServer(address string) (http.Handler, error) {
 func (s *server) getFileHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	filePaths := strings.Split(ps.ByName("filePath"), "/")
 	fileName := filePaths[len(filePaths)-1]
-	ctx := context.Background()
-	for _, cookie := range r.Cookies() {
-		if cookie.Name == auth.ContextTokenKey {
-			ctx = metadata.NewIncomingContext(
-				ctx,
-				metadata.Pairs(auth.ContextTokenKey, cookie.Value),
-			)
-		}
+	c := s.getPachClient().WithCtx(requestContext(r))
+	repoName, commitID, filePath := ps.ByName("repoName"), ps.ByName("commitID"), ps.ByName("filePath")
+	commitInfo, err := c.InspectCommit(repoName, commitID)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	fileInfo, err := c.InspectFile(repoName, commitID, filePath)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	if fileInfo.FileType == pfs.FileType_DIR {
+		s.listDirHandler(w, r, c, fileInfo)
+		return
 	}
 	downloadValues := r.URL.Query()["download"]
 	if len(downloadValues) == 1 && downloadValues[0] == "true" {
 		w.Header().Add("Content-Disposition", fmt.Sprintf("attachment; filename=\"%v\"", fileName))
 	}
-	c := s.getPachClient().WithCtx(ctx)
-	commitInfo, err := c.InspectCommit(ps.ByName("repoName"), ps.ByName("commitID"))
+	content, err := c.GetFileReadSeeker(repoName, commitID, filePath)
 	if err != nil {
 		httpError(w, err)
 		return
 	}
-	content, err := c.GetFileReadSeeker(ps.ByName("repoName"), ps.ByName("commitID"), ps.ByName("filePath"))
+	modtime, err := types.TimestampFromProto(commitInfo.Finished)
 	if err != nil {
 		httpError(w, err)
 		return
 	}
-	modtime, err := types.TimestampFromProto(commitInfo.Finished)
-	if err != nil {
+	// ServeContent handles HEAD and ranged GETs, as well as conditional
+	// requests against the ETag
+	w.Header().Set("ETag", fmt.Sprintf("%q", hex.EncodeToString(fileInfo.Hash)))
+	http.ServeContent(w, r, fileName, modtime, content)
+}
+
+// dirEntry is a single entry of a directory listing
+type dirEntry struct {
+	Name      string    `json:"name"`
+	Path      string    `json:"path"`
+	Type      string    `json:"type"`
+	SizeBytes uint64    `json:"size_bytes"`
+	Committed time.Time `json:"committed"`
+	Hash      string    `json:"hash"`
+
+	// URL is the link to the entry in the HTML listing
+	URL string `json:"-"`
+}
+
+var dirListingTemplate = template.Must(template.New("dir").Parse(`<!DOCTYPE html>
+<html>
+<head><title>{{.Repo}}@{{.Commit}}:{{.Path}}</title></head>
+<body>
+<h1>{{.Repo}}@{{.Commit}}:{{.Path}}</h1>
+<table>
+<tr><th>Name</th><th>Size</th><th>Committed</th></tr>
+{{range .Entries}}<tr><td><a href="{{.URL}}">{{.Name}}</a></td><td>{{.SizeBytes}}</td><td>{{.Committed.Format "2006-01-02 15:04:05 MST"}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// listDirHandler writes the listing of a directory, as JSON if the client
+// asks for it (with `?format=json` or an `Accept: application/json` header),
+// and as HTML otherwise.
+func (s *server) listDirHandler(w http.ResponseWriter, r *http.Request, c *client.APIClient, dirInfo *pfs.FileInfo) {
+	file := dirInfo.File
+	entries := []dirEntry{}
+	if err := c.ListFileF(file.Commit.Repo.Name, file.Commit.ID, file.Path, 0, func(fi *pfs.FileInfo) error {
+		entry := dirEntry{
+			Name:      path.Base(fi.File.Path),
+			Path:      fi.File.Path,
+			Type:      "file",
+			SizeBytes: fi.SizeBytes,
+			Committed: timestamp(fi.Committed),
+			Hash:      hex.EncodeToString(fi.Hash),
+			URL:       path.Join(r.URL.Path, path.Base(fi.File.Path)),
+		}
+		if fi.FileType == pfs.FileType_DIR {
+			entry.Type = "dir"
+			entry.URL += "/"
+		}
+		entries = append(entries, entry)
+		return nil
+	}); err != nil {
 		httpError(w, err)
 		return
 	}
-	http.ServeContent(w, r, fileName, modtime, content)
+
+	if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodHead {
+			return
+		}
+		if err := json.NewEncoder(w).Encode(entries); err != nil {
+			httpError(w, err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if r.Method == http.MethodHead {
+		return
+	}
+	if err := dirListingTemplate.Execute(w, struct {
+		Repo, Commit, Path string
+		Entries            []dirEntry
+	}{file.Commit.Repo.Name, file.Commit.ID, path.Join("/", file.Path), entries}); err != nil {
+		httpError(w, err)
+	}
+}
+
+// webdavHandlerFunc serves the read-only WebDAV view of PFS, authenticating
+// the same way as the rest of the HTTP API. Requests without a valid token are
+// challenged for basic auth credentials (with the token as the password), as
+// WebDAV clients only prompt for credentials on a 401, not on the 403 that the
+// webdav handler returns for os.ErrPermission.
+func (s *server) webdavHandlerFunc(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ctx := requestContext(r)
+	c := s.getPachClient().WithCtx(ctx)
+	if _, err := c.WhoAmI(c.Ctx(), &auth.WhoAmIRequest{}); err != nil && !auth.IsErrNotActivated(err) {
+		if auth.IsErrNotSignedIn(err) || auth.IsErrBadToken(err) {
+			w.Header().Set("WWW-Authenticate", `Basic realm="Pachyderm"`)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		httpError(w, err)
+		return
+	}
+	s.webdavHandler.ServeHTTP(w, r.WithContext(ctx))
 }
 
 func (s *server) serviceHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -138,6 +260,30 @@ func (s *server) authLogoutHandler(w http.ResponseWriter, r *http.Request, ps ht
 	w.WriteHeader(http.StatusOK)
 }
 
+// requestContext returns the request's context, carrying the pachyderm auth
+// token sent by the client. The token is read from the auth cookie set by the
+// login handler or, for clients such as WebDAV file managers that can't set
+// cookies, from the password of HTTP basic auth.
+func requestContext(r *http.Request) context.Context {
+	ctx := r.Context()
+	token := ""
+	if _, password, ok := r.BasicAuth(); ok {
+		token = password
+	}
+	for _, cookie := range r.Cookies() {
+		if cookie.Name == auth.ContextTokenKey {
+			token = cookie.Value
+		}
+	}
+	if token != "" {
+		ctx = metadata.NewIncomingContext(
+			ctx,
+			metadata.Pairs(auth.ContextTokenKey, token),
+		)
+	}
+	return ctx
+}
+
 func notFound(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "route not found", http.StatusNotFound)
 }
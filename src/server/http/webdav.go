@@ -0,0 +1,317 @@
+package http
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+
+	"github.com/gogo/protobuf/types"
+	"golang.org/x/net/context"
+	"golang.org/x/net/webdav"
+)
+
+// pfsFS is a read-only webdav.FileSystem backed by PFS. Paths are of the form
+// /<repo>/<branch or commit>/<path>: the root lists repos, and each repo lists
+// its branches. Requests are made with the auth token attached to the context
+// passed in by the webdav handler.
+type pfsFS struct {
+	s *server
+}
+
+// pfsPath is a path in pfsFS, split into its components. `ref` and `file` are
+// empty for the root and for repos.
+type pfsPath struct {
+	repo string
+	ref  string
+	file string
+}
+
+func parsePFSPath(name string) pfsPath {
+	parts := strings.SplitN(strings.Trim(path.Clean("/"+name), "/"), "/", 3)
+	var p pfsPath
+	switch len(parts) {
+	case 3:
+		p.file = parts[2]
+		fallthrough
+	case 2:
+		p.ref = parts[1]
+		fallthrough
+	case 1:
+		p.repo = parts[0]
+	}
+	return p
+}
+
+func (fs *pfsFS) client(ctx context.Context) *client.APIClient {
+	return fs.s.getPachClient().WithCtx(ctx)
+}
+
+func (fs *pfsFS) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	return os.ErrPermission
+}
+
+func (fs *pfsFS) RemoveAll(ctx context.Context, name string) error {
+	return os.ErrPermission
+}
+
+func (fs *pfsFS) Rename(ctx context.Context, oldName, newName string) error {
+	return os.ErrPermission
+}
+
+func (fs *pfsFS) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	p := parsePFSPath(name)
+	c := fs.client(ctx)
+	switch {
+	case p.repo == "":
+		return &pfsFileInfo{name: "/", dir: true}, nil
+	case p.ref == "":
+		repoInfo, err := c.InspectRepo(p.repo)
+		if err != nil {
+			return nil, osError(err)
+		}
+		return &pfsFileInfo{name: p.repo, dir: true, modTime: timestamp(repoInfo.Created)}, nil
+	case p.file == "":
+		commitInfo, err := c.InspectCommit(p.repo, p.ref)
+		if err != nil {
+			return nil, osError(err)
+		}
+		modTime := commitInfo.Finished
+		if modTime == nil {
+			modTime = commitInfo.Started
+		}
+		return &pfsFileInfo{name: p.ref, dir: true, modTime: timestamp(modTime)}, nil
+	default:
+		fileInfo, err := c.InspectFile(p.repo, p.ref, p.file)
+		if err != nil {
+			return nil, osError(err)
+		}
+		return newPFSFileInfo(fileInfo), nil
+	}
+}
+
+func (fs *pfsFS) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_APPEND|os.O_CREATE|os.O_TRUNC) != 0 {
+		return nil, os.ErrPermission
+	}
+	info, err := fs.Stat(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &pfsFile{
+		fs:   fs,
+		ctx:  ctx,
+		path: parsePFSPath(name),
+		info: info.(*pfsFileInfo),
+	}, nil
+}
+
+// pfsFile is a read-only webdav.File backed by PFS. File contents and
+// directory entries are only fetched once they're read.
+type pfsFile struct {
+	fs      *pfsFS
+	ctx     context.Context
+	path    pfsPath
+	info    *pfsFileInfo
+	content io.ReadSeeker
+	entries []os.FileInfo
+	listed  bool
+}
+
+func (f *pfsFile) Close() error {
+	return nil
+}
+
+func (f *pfsFile) Read(p []byte) (int, error) {
+	if f.info.dir {
+		return 0, os.ErrInvalid
+	}
+	if err := f.open(); err != nil {
+		return 0, err
+	}
+	return f.content.Read(p)
+}
+
+func (f *pfsFile) Seek(offset int64, whence int) (int64, error) {
+	if f.info.dir {
+		return 0, os.ErrInvalid
+	}
+	if err := f.open(); err != nil {
+		return 0, err
+	}
+	return f.content.Seek(offset, whence)
+}
+
+func (f *pfsFile) open() error {
+	if f.content != nil {
+		return nil
+	}
+	content, err := f.fs.client(f.ctx).GetFileReadSeeker(f.path.repo, f.path.ref, f.path.file)
+	if err != nil {
+		return osError(err)
+	}
+	f.content = content
+	return nil
+}
+
+func (f *pfsFile) Write(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+func (f *pfsFile) Stat() (os.FileInfo, error) {
+	return f.info, nil
+}
+
+// Readdir follows the semantics of os.File.Readdir: if count > 0, at most
+// count entries are returned per call, and io.EOF once there are none left.
+func (f *pfsFile) Readdir(count int) ([]os.FileInfo, error) {
+	if !f.info.dir {
+		return nil, os.ErrInvalid
+	}
+	if !f.listed {
+		entries, err := f.list()
+		if err != nil {
+			return nil, osError(err)
+		}
+		f.entries, f.listed = entries, true
+	}
+	if count <= 0 {
+		entries := f.entries
+		f.entries = nil
+		return entries, nil
+	}
+	if len(f.entries) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(f.entries) {
+		count = len(f.entries)
+	}
+	entries := f.entries[:count]
+	f.entries = f.entries[count:]
+	return entries, nil
+}
+
+func (f *pfsFile) list() ([]os.FileInfo, error) {
+	c := f.fs.client(f.ctx)
+	var entries []os.FileInfo
+	switch {
+	case f.path.repo == "":
+		repoInfos, err := c.ListRepo()
+		if err != nil {
+			return nil, err
+		}
+		for _, repoInfo := range repoInfos {
+			entries = append(entries, &pfsFileInfo{
+				name:    repoInfo.Repo.Name,
+				dir:     true,
+				modTime: timestamp(repoInfo.Created),
+			})
+		}
+	case f.path.ref == "":
+		branchInfos, err := c.ListBranch(f.path.repo)
+		if err != nil {
+			return nil, err
+		}
+		for _, branchInfo := range branchInfos {
+			entries = append(entries, &pfsFileInfo{
+				name:    branchInfo.Branch.Name,
+				dir:     true,
+				modTime: f.info.modTime,
+			})
+		}
+	default:
+		if err := c.ListFileF(f.path.repo, f.path.ref, f.path.file, 0, func(fi *pfs.FileInfo) error {
+			entries = append(entries, newPFSFileInfo(fi))
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return entries, nil
+}
+
+// pfsFileInfo implements os.FileInfo for repos, branches and PFS files
+type pfsFileInfo struct {
+	name    string
+	size    int64
+	dir     bool
+	modTime time.Time
+	hash    string
+}
+
+func newPFSFileInfo(fileInfo *pfs.FileInfo) *pfsFileInfo {
+	return &pfsFileInfo{
+		name:    path.Base(fileInfo.File.Path),
+		size:    int64(fileInfo.SizeBytes),
+		dir:     fileInfo.FileType == pfs.FileType_DIR,
+		modTime: timestamp(fileInfo.Committed),
+		hash:    hex.EncodeToString(fileInfo.Hash),
+	}
+}
+
+func (i *pfsFileInfo) Name() string       { return i.name }
+func (i *pfsFileInfo) Size() int64        { return i.size }
+func (i *pfsFileInfo) ModTime() time.Time { return i.modTime }
+func (i *pfsFileInfo) IsDir() bool        { return i.dir }
+func (i *pfsFileInfo) Sys() interface{}   { return nil }
+
+func (i *pfsFileInfo) Mode() os.FileMode {
+	if i.dir {
+		return os.ModeDir | 0555
+	}
+	return 0444
+}
+
+// ETag implements webdav.ETager, so that the webdav handler doesn't need to
+// read the file to compute one
+func (i *pfsFileInfo) ETag(ctx context.Context) (string, error) {
+	if i.hash == "" {
+		return "", webdav.ErrNotImplemented
+	}
+	return fmt.Sprintf("%q", i.hash), nil
+}
+
+// ContentType implements webdav.ContentTyper, so that the webdav handler
+// doesn't need to read the file to sniff its content type
+func (i *pfsFileInfo) ContentType(ctx context.Context) (string, error) {
+	if ctype := mime.TypeByExtension(path.Ext(i.name)); ctype != "" {
+		return ctype, nil
+	}
+	return "application/octet-stream", nil
+}
+
+// timestamp converts a proto timestamp, returning the zero time if it's unset
+func timestamp(t *types.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	result, err := types.TimestampFromProto(t)
+	if err != nil {
+		return time.Time{}
+	}
+	return result
+}
+
+// osError converts PFS errors into the os errors that the webdav handler
+// translates into HTTP statuses. Requests without a valid token are already
+// challenged by webdavHandlerFunc, so auth errors here (e.g. a token that
+// expired mid-request) are refused with a 403.
+func osError(err error) error {
+	switch {
+	case errutil.IsNotFoundError(err):
+		return os.ErrNotExist
+	case auth.IsErrNotAuthorized(err), auth.IsErrNotSignedIn(err), auth.IsErrBadToken(err):
+		return os.ErrPermission
+	default:
+		return err
+	}
+}
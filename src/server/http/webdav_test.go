@@ -0,0 +1,133 @@
+package http
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/auth"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParsePFSPath(t *testing.T) {
+	require.Equal(t, pfsPath{}, parsePFSPath("/"))
+	require.Equal(t, pfsPath{}, parsePFSPath(""))
+	require.Equal(t, pfsPath{repo: "repo"}, parsePFSPath("/repo/"))
+	require.Equal(t, pfsPath{repo: "repo", ref: "master"}, parsePFSPath("/repo/master"))
+	require.Equal(t, pfsPath{repo: "repo", ref: "master", file: "dir/file"}, parsePFSPath("/repo/master/dir/file"))
+	require.Equal(t, pfsPath{repo: "repo", ref: "master", file: "file"}, parsePFSPath("/repo/master/dir/../file"))
+}
+
+func TestRequestContext(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	_, ok := metadata.FromIncomingContext(requestContext(r))
+	require.False(t, ok)
+
+	r.SetBasicAuth("", "basic-token")
+	md, ok := metadata.FromIncomingContext(requestContext(r))
+	require.True(t, ok)
+	require.Equal(t, []string{"basic-token"}, md.Get(auth.ContextTokenKey))
+
+	// the login cookie takes precedence over basic auth
+	r.Header.Set("Cookie", auth.ContextTokenKey+"=cookie-token")
+	md, ok = metadata.FromIncomingContext(requestContext(r))
+	require.True(t, ok)
+	require.Equal(t, []string{"cookie-token"}, md.Get(auth.ContextTokenKey))
+}
+
+// serveWebDAV sends a request to the WebDAV view of PFS served by 'handler'
+// and returns the response
+func serveWebDAV(handler http.Handler, method, path string, header http.Header) *http.Response {
+	r := httptest.NewRequest(method, webdavPath[:strings.Index(webdavPath, "*")]+path, nil)
+	for key, values := range header {
+		r.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	return w.Result()
+}
+
+func TestWebDAV(t *testing.T) {
+	t.Parallel()
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		require.NoError(t, c.CreateRepo("repo"))
+		_, err := c.PutFile("repo", "master", "dir/file", strings.NewReader("content"))
+		require.NoError(t, err)
+		_, err = c.PutFile("repo", "master", "other", strings.NewReader("other"))
+		require.NoError(t, err)
+
+		handler, err := NewHTTPServer(env.MockPachd.Addr.String())
+		require.NoError(t, err)
+
+		t.Run("PROPFIND", func(t *testing.T) {
+			resp := serveWebDAV(handler, "PROPFIND", "repo/master/", http.Header{"Depth": {"1"}})
+			require.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.True(t, strings.Contains(string(body), "/repo/master/dir/"), string(body))
+			require.True(t, strings.Contains(string(body), "/repo/master/other"), string(body))
+			// Depth 1 doesn't list the contents of subdirectories
+			require.False(t, strings.Contains(string(body), "/repo/master/dir/file"), string(body))
+
+			resp = serveWebDAV(handler, "PROPFIND", "", http.Header{"Depth": {"1"}})
+			require.Equal(t, http.StatusMultiStatus, resp.StatusCode)
+			body, err = ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.True(t, strings.Contains(string(body), "/repo/"), string(body))
+
+			resp = serveWebDAV(handler, "PROPFIND", "repo/master/missing", http.Header{"Depth": {"0"}})
+			require.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+
+		t.Run("HEAD", func(t *testing.T) {
+			resp := serveWebDAV(handler, "HEAD", "repo/master/dir/file", nil)
+			require.Equal(t, http.StatusOK, resp.StatusCode)
+			require.Equal(t, "7", resp.Header.Get("Content-Length"))
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, 0, len(body))
+		})
+
+		t.Run("RangedGET", func(t *testing.T) {
+			resp := serveWebDAV(handler, "GET", "repo/master/dir/file", http.Header{"Range": {"bytes=1-3"}})
+			require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+			require.Equal(t, "bytes 1-3/7", resp.Header.Get("Content-Range"))
+			body, err := ioutil.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, "ont", string(body))
+		})
+
+		t.Run("Unauthenticated", func(t *testing.T) {
+			env.MockPachd.Auth.WhoAmI.Use(func(ctx context.Context, req *auth.WhoAmIRequest) (*auth.WhoAmIResponse, error) {
+				md, _ := metadata.FromIncomingContext(ctx)
+				switch tokens := md.Get(auth.ContextTokenKey); {
+				case len(tokens) == 0:
+					return nil, auth.ErrNotSignedIn
+				case tokens[0] != "token":
+					return nil, auth.ErrBadToken
+				}
+				return &auth.WhoAmIResponse{Username: "alice"}, nil
+			})
+			// no token, then a bad one
+			for _, header := range []http.Header{{}, {"Authorization": {"Basic OmJhZA=="}}} {
+				header.Set("Depth", "1")
+				resp := serveWebDAV(handler, "PROPFIND", "repo/master/", header)
+				require.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+				require.Equal(t, `Basic realm="Pachyderm"`, resp.Header.Get("WWW-Authenticate"))
+			}
+			// a valid token isn't challenged (PFS itself still sees auth as
+			// inactive, so the request isn't otherwise expected to succeed)
+			resp := serveWebDAV(handler, "PROPFIND", "repo/master/", http.Header{"Depth": {"1"}, "Authorization": {"Basic OnRva2Vu"}})
+			require.NotEqual(t, http.StatusUnauthorized, resp.StatusCode)
+			require.Equal(t, "", resp.Header.Get("WWW-Authenticate"))
+		})
+		return nil
+	}))
+}
@@ -401,6 +401,67 @@ func TestDatumDedup(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPipelineWindow(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := tu.GetPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestPipelineWindow_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+
+	var commits []*pfs.Commit
+	for i := 0; i < 2; i++ {
+		commit, err := c.StartCommit(dataRepo, "master")
+		require.NoError(t, err)
+		_, err = c.PutFile(dataRepo, commit.ID, "file", strings.NewReader(fmt.Sprintf("%d", i)))
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+		commits = append(commits, commit)
+	}
+
+	pipeline := tu.UniqueString("pipeline")
+	input := client.NewPFSInput(dataRepo, "/*")
+	input.Pfs.Window = &pps.PFSWindow{Commits: 2}
+	require.NoError(t, c.CreatePipeline(
+		pipeline,
+		"",
+		[]string{"bash"},
+		[]string{
+			fmt.Sprintf("ls /pfs/%s > /pfs/out/dirs", dataRepo),
+		},
+		nil,
+		input,
+		"",
+		false,
+	))
+
+	commitIter, err := c.FlushCommit([]*pfs.Commit{commits[1]}, nil)
+	require.NoError(t, err)
+	commitInfos := collectCommitInfos(t, commitIter)
+	require.Equal(t, 1, len(commitInfos))
+
+	// Each commit's directory is prefixed with its position in the window
+	var buf bytes.Buffer
+	require.NoError(t, c.GetFile(pipeline, commitInfos[0].Commit.ID, "dirs", 0, 0, &buf))
+	require.Equal(t, fmt.Sprintf("0-%s\n1-%s\n", commits[0].ID, commits[1].ID), buf.String())
+
+	// Both commits in the window are in the output commit's provenance, the
+	// older one under a branch named for its ID
+	provenance := make(map[string]string)
+	for _, prov := range commitInfos[0].Provenance {
+		if prov.Commit.Repo.Name == dataRepo {
+			provenance[prov.Commit.ID] = prov.Branch.Name
+		}
+	}
+	require.Equal(t, map[string]string{
+		commits[0].ID: commits[0].ID,
+		commits[1].ID: "master",
+	}, provenance)
+}
+
 func TestPipelineInputDataModification(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -4711,6 +4772,79 @@ func testGetLogs(t *testing.T, enableStats bool) {
 	}, backoff.NewTestingBackOff()))
 }
 
+func TestGetPersistedLogs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+
+	c := tu.GetPachClient(t)
+	require.NoError(t, c.DeleteAll())
+	dataRepo := tu.UniqueString("TestGetPersistedLogs_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	pipelineName := tu.UniqueString("TestGetPersistedLogs")
+	_, err := c.PpsAPIClient.CreatePipeline(context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline(pipelineName),
+			Transform: &pps.Transform{
+				Cmd: []string{"sh"},
+				Stdin: []string{
+					fmt.Sprintf("echo processing $(ls /pfs/%s)", dataRepo),
+					fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo),
+				},
+			},
+			Input:       client.NewPFSInput(dataRepo, "/*"),
+			PersistLogs: true,
+		})
+	require.NoError(t, err)
+
+	// The second job skips the datum "a", so its stats commit also holds the
+	// logs that the first job wrote for it
+	var jobIDs []string
+	for _, file := range []string{"a", "b"} {
+		commit, err := c.StartCommit(dataRepo, "master")
+		require.NoError(t, err)
+		_, err = c.PutFile(dataRepo, commit.ID, file, strings.NewReader(file))
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+		jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(jobInfos))
+		require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfos[0].State)
+		jobIDs = append(jobIDs, jobInfos[0].Job.ID)
+	}
+
+	// Each datum's message is returned once, tagged with the job that
+	// processed it
+	getLogs := func(jobID string, grep string) map[string]string {
+		jobByMessage := make(map[string]string)
+		iter := c.GetPersistedLogs(pipelineName, jobID, nil, "", time.Time{}, time.Time{}, grep)
+		for iter.Next() {
+			msg := iter.Message()
+			message := strings.TrimSuffix(msg.Message, "\n")
+			_, ok := jobByMessage[message]
+			require.False(t, ok, "duplicate log message %q", message)
+			jobByMessage[message] = msg.JobID
+		}
+		require.NoError(t, iter.Err())
+		return jobByMessage
+	}
+	require.Equal(t, map[string]string{
+		"processing a": jobIDs[0],
+		"processing b": jobIDs[1],
+	}, getLogs("", "^processing"))
+	require.Equal(t, map[string]string{
+		"processing a": jobIDs[0],
+	}, getLogs("", "processing a"))
+	require.Equal(t, map[string]string{
+		"processing b": jobIDs[1],
+	}, getLogs(jobIDs[1], "^processing"))
+
+	// Messages logged before 'since' are filtered out
+	iter := c.GetPersistedLogs(pipelineName, "", nil, "", time.Now(), time.Time{}, "")
+	require.False(t, iter.Next())
+	require.NoError(t, iter.Err())
+}
+
 func TestManyLogs(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -13042,6 +13176,362 @@ func TestListDatum(t *testing.T) {
 	require.Equal(t, 25, len(resp.DatumInfos))
 }
 
+func TestDryRunPipeline(t *testing.T) {
+	c := tu.GetPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	repo1 := tu.UniqueString("TestDryRunPipeline1")
+	repo2 := tu.UniqueString("TestDryRunPipeline2")
+	require.NoError(t, c.CreateRepo(repo1))
+	require.NoError(t, c.CreateRepo(repo2))
+	for i := 0; i < 5; i++ {
+		_, err := c.PutFile(repo1, "master", fmt.Sprintf("file-%d", i), strings.NewReader("foo"))
+		require.NoError(t, err)
+	}
+	for i := 0; i < 3; i++ {
+		_, err := c.PutFile(repo2, "master", fmt.Sprintf("file-%d", i), strings.NewReader("foobar"))
+		require.NoError(t, err)
+	}
+
+	pipeline := tu.UniqueString("TestDryRunPipeline")
+	resp, err := c.DryRunPipeline(&pps.CreatePipelineRequest{
+		Pipeline:  client.NewPipeline(pipeline),
+		Transform: &pps.Transform{Cmd: []string{"true"}},
+		Input: client.NewJoinInput(
+			&pps.Input{Pfs: &pps.PFSInput{Repo: repo1, Glob: "/(*)", JoinOn: "$1"}},
+			&pps.Input{Pfs: &pps.PFSInput{Repo: repo2, Glob: "/(*)", JoinOn: "$1"}},
+		),
+	}, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resp.DatumCount)
+	require.Equal(t, 2, len(resp.Datums))
+	require.Equal(t, 2, len(resp.Datums[0].Data))
+	require.Equal(t, int64(3*(3+6)), resp.EstimatedBytes)
+	require.Equal(t, 2, len(resp.InputCommits))
+
+	// The dry run doesn't create the pipeline or its output repo
+	_, err = c.InspectPipeline(pipeline)
+	require.YesError(t, err)
+	_, err = c.InspectRepo(pipeline)
+	require.YesError(t, err)
+}
+
+func TestPipelineTemplate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c := tu.GetPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestPipelineTemplate_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	_, err := c.PutFile(dataRepo, "master", "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+
+	template := tu.UniqueString("TestPipelineTemplate")
+	spec := func(suffix string) string {
+		return fmt.Sprintf(`
+pipeline:
+  name: {{ printf "%%s-%s" .customer }}
+transform:
+  cmd: [ "bash" ]
+  stdin: [ {{ printf "echo %%s%s > /pfs/out/file" .greeting }} ]
+input:
+  pfs:
+    repo: %s
+    glob: /*
+`, template, suffix, dataRepo)
+	}
+	params := []*pps.TemplateParameter{
+		{Name: "customer"},
+		{Name: "greeting", Default: "hello"},
+	}
+	require.NoError(t, c.CreateTemplate(template, spec(""), params, false, false))
+	require.YesError(t, c.CreateTemplate(template, spec(""), params, false, false))
+
+	// Instantiating the template requires its required arguments
+	require.YesError(t, c.CreatePipelineFromTemplate(template, nil, false))
+	require.NoError(t, c.CreatePipelineFromTemplate(template, map[string]string{"customer": "acme"}, false))
+	pipeline := "acme-" + template
+	pipelineInfo, err := c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	require.Equal(t, template, pipelineInfo.Template.Template.Name)
+	require.Equal(t, int64(1), pipelineInfo.Template.Version)
+	require.Equal(t, map[string]string{"customer": "acme"}, pipelineInfo.Template.Args)
+
+	commitIter, err := c.FlushCommit([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(collectCommitInfos(t, commitIter)))
+	var buf bytes.Buffer
+	require.NoError(t, c.GetFile(pipeline, "master", "file", 0, 0, &buf))
+	require.Equal(t, "hello\n", buf.String())
+
+	// Updating the template with propagate updates the pipeline
+	require.NoError(t, c.CreateTemplate(template, spec(" world"), params, true, true))
+	templateInfo, err := c.InspectTemplate(template)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), templateInfo.Version)
+	pipelineInfo, err = c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), pipelineInfo.Template.Version)
+	require.Equal(t, uint64(2), pipelineInfo.Version)
+
+	// Propagating a version that's invalid for any of the template's
+	// pipelines fails without updating the template or the pipelines
+	invalid := strings.Replace(spec(""), "glob: /*", `glob: ""`, 1)
+	require.YesError(t, c.CreateTemplate(template, invalid, params, true, true))
+	templateInfo, err = c.InspectTemplate(template)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), templateInfo.Version)
+	pipelineInfo, err = c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), pipelineInfo.Version)
+
+	templateInfos, err := c.ListTemplate()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(templateInfos))
+	require.NoError(t, c.DeleteTemplate(template))
+	_, err = c.InspectTemplate(template)
+	require.YesError(t, err)
+	_, err = c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+}
+
+func TestPipelineNotifications(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c := tu.GetPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestPipelineNotifications_data")
+	eventsRepo := tu.UniqueString("TestPipelineNotifications_events")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	require.NoError(t, c.CreateRepo(eventsRepo))
+	commit, err := c.StartCommit(dataRepo, "master")
+	require.NoError(t, err)
+	_, err = c.PutFile(dataRepo, commit.ID, "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+	require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+	pipeline := tu.UniqueString("TestPipelineNotifications")
+	_, err = c.PpsAPIClient.CreatePipeline(c.Ctx(), &pps.CreatePipelineRequest{
+		Pipeline: client.NewPipeline(pipeline),
+		Transform: &pps.Transform{
+			Cmd: []string{"cp", path.Join("/pfs", dataRepo, "file"), "/pfs/out/file"},
+		},
+		Input: client.NewPFSInput(dataRepo, "/*"),
+		Notifications: []*pps.Notification{{
+			Repo: eventsRepo,
+			Events: []pps.NotificationEventType{
+				pps.NotificationEventType_NOTIFY_JOB_STARTED,
+				pps.NotificationEventType_NOTIFY_JOB_SUCCESS,
+			},
+		}},
+	})
+	require.NoError(t, err)
+	jobInfos, err := c.FlushJobAll([]*pfs.Commit{commit}, []string{pipeline})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(jobInfos))
+	require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfos[0].State)
+
+	// The PPS master writes each event into the events repo
+	require.NoErrorWithinTRetry(t, 60*time.Second, func() error {
+		fileInfos, err := c.ListFile(eventsRepo, "master", pipeline)
+		if err != nil {
+			return err
+		}
+		events := make(map[pps.NotificationEventType]*pps.NotificationEvent)
+		for _, fileInfo := range fileInfos {
+			var buf bytes.Buffer
+			if err := c.GetFile(eventsRepo, "master", fileInfo.File.Path, 0, 0, &buf); err != nil {
+				return err
+			}
+			event := &pps.NotificationEvent{}
+			if err := jsonpb.Unmarshal(&buf, event); err != nil {
+				return err
+			}
+			events[event.Type] = event
+		}
+		if len(events) != 2 {
+			return errors.Errorf("expected 2 events, but got %d", len(events))
+		}
+		for _, event := range events {
+			if event.Job.ID != jobInfos[0].Job.ID {
+				return errors.Errorf("expected event for job %s, but got %s", jobInfos[0].Job.ID, event.Job.ID)
+			}
+		}
+		return nil
+	})
+
+	pipelineInfo, err := c.InspectPipeline(pipeline)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(pipelineInfo.Notifications))
+	require.Equal(t, eventsRepo, pipelineInfo.Notifications[0].Repo)
+}
+
+func TestGlobalDatumCache(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c := tu.GetPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestGlobalDatumCache_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	for i := 0; i < 3; i++ {
+		_, err := c.PutFile(dataRepo, "master", fmt.Sprintf("file-%d", i), strings.NewReader(fmt.Sprintf("foo-%d", i)))
+		require.NoError(t, err)
+	}
+
+	createPipeline := func(pipeline string) *pps.JobInfo {
+		_, err := c.PpsAPIClient.CreatePipeline(
+			context.Background(),
+			&pps.CreatePipelineRequest{
+				Pipeline: client.NewPipeline(pipeline),
+				Transform: &pps.Transform{
+					Cmd:   []string{"bash"},
+					Stdin: []string{fmt.Sprintf("cp /pfs/%s/* /pfs/out/", dataRepo)},
+				},
+				Input:            client.NewPFSInput(dataRepo, "/*"),
+				GlobalDatumCache: true,
+			})
+		require.NoError(t, err)
+		jobInfos, err := c.FlushJobAll([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, []string{pipeline})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(jobInfos))
+		require.Equal(t, pps.JobState_JOB_SUCCESS, jobInfos[0].State)
+		return jobInfos[0]
+	}
+
+	// The first pipeline processes every datum, and the second pipeline, which
+	// runs the same transform over the same files, reuses their outputs
+	pipeline1 := tu.UniqueString("TestGlobalDatumCache1")
+	jobInfo := createPipeline(pipeline1)
+	require.Equal(t, int64(3), jobInfo.DataProcessed)
+	require.Equal(t, int64(0), jobInfo.DataSkipped)
+	pipeline2 := tu.UniqueString("TestGlobalDatumCache2")
+	jobInfo = createPipeline(pipeline2)
+	require.Equal(t, int64(0), jobInfo.DataProcessed)
+	require.Equal(t, int64(3), jobInfo.DataSkipped)
+	for i := 0; i < 3; i++ {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(pipeline2, "master", fmt.Sprintf("file-%d", i), 0, 0, &buf))
+		require.Equal(t, fmt.Sprintf("foo-%d", i), buf.String())
+	}
+
+	globalTags := func() int {
+		n := 0
+		for _, tag := range getAllTags(t, c) {
+			if strings.HasPrefix(tag, client.GlobalDatumTagPrefix) {
+				n++
+			}
+		}
+		return n
+	}
+	require.Equal(t, 3, globalTags())
+
+	// The outputs stay in the cache while any pipeline still has them, and are
+	// evicted once every pipeline that had them is deleted
+	require.NoError(t, c.DeletePipeline(pipeline1, false))
+	require.NoError(t, c.GarbageCollect(0))
+	require.Equal(t, 3, globalTags())
+	require.NoError(t, c.DeletePipeline(pipeline2, false))
+	require.NoError(t, c.GarbageCollect(0))
+	require.Equal(t, 0, globalTags())
+}
+
+func TestNamedOutputs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	c := tu.GetPachClient(t)
+	require.NoError(t, c.DeleteAll())
+
+	dataRepo := tu.UniqueString("TestNamedOutputs_data")
+	require.NoError(t, c.CreateRepo(dataRepo))
+	_, err := c.PutFile(dataRepo, "master", "file", strings.NewReader("foo"))
+	require.NoError(t, err)
+
+	pipeline := tu.UniqueString("TestNamedOutputs")
+	goodRepo, badRepo := client.OutputRepo(pipeline, "good"), client.OutputRepo(pipeline, "bad")
+	createPipeline := func(update bool, outputs ...string) error {
+		_, err := c.PpsAPIClient.CreatePipeline(
+			context.Background(),
+			&pps.CreatePipelineRequest{
+				Pipeline: client.NewPipeline(pipeline),
+				Transform: &pps.Transform{
+					Cmd: []string{"bash"},
+					Stdin: []string{
+						"mkdir -p /pfs/out/good /pfs/out/bad",
+						fmt.Sprintf("cp /pfs/%s/* /pfs/out/good/", dataRepo),
+						fmt.Sprintf("cp /pfs/%s/* /pfs/out/bad/", dataRepo),
+					},
+				},
+				Input:   client.NewPFSInput(dataRepo, "/"),
+				Outputs: outputs,
+				Update:  update,
+			})
+		return err
+	}
+	flush := func() {
+		_, err := c.FlushCommitAll([]*pfs.Commit{client.NewCommit(dataRepo, "master")}, []*pfs.Repo{client.NewRepo(goodRepo)})
+		require.NoError(t, err)
+	}
+
+	// A pipeline doesn't take over an existing repo as a named output repo, or
+	// share one with a cron input
+	require.NoError(t, c.CreateRepo(badRepo))
+	require.YesError(t, createPipeline(false, "good", "bad"))
+	require.NoError(t, c.DeleteRepo(badRepo, false))
+	_, err = c.PpsAPIClient.CreatePipeline(
+		context.Background(),
+		&pps.CreatePipelineRequest{
+			Pipeline:  client.NewPipeline(pipeline),
+			Transform: &pps.Transform{Cmd: []string{"true"}},
+			Input:     client.NewCronInput("good", "@every 1h"),
+			Outputs:   []string{"good"},
+		})
+	require.YesError(t, err)
+
+	require.NoError(t, createPipeline(false, "good", "bad"))
+	flush()
+	for _, repo := range []string{goodRepo, badRepo} {
+		var buf bytes.Buffer
+		require.NoError(t, c.GetFile(repo, "master", "file", 0, 0, &buf))
+		require.Equal(t, "foo", buf.String())
+	}
+
+	// An output that's removed by an update keeps its repo, but the repo no
+	// longer gets new commits
+	require.NoError(t, createPipeline(true, "good"))
+	branchInfo, err := c.InspectBranch(badRepo, "master")
+	require.NoError(t, err)
+	require.Equal(t, 0, len(branchInfo.Provenance))
+	badCommits, err := c.ListCommitByRepo(badRepo)
+	require.NoError(t, err)
+	_, err = c.PutFile(dataRepo, "master", "file2", strings.NewReader("bar"))
+	require.NoError(t, err)
+	flush()
+	var buf bytes.Buffer
+	require.NoError(t, c.GetFile(goodRepo, "master", "file2", 0, 0, &buf))
+	require.Equal(t, "bar", buf.String())
+	commits, err := c.ListCommitByRepo(badRepo)
+	require.NoError(t, err)
+	require.Equal(t, len(badCommits), len(commits))
+
+	// The removed output can't be added back while its repo exists
+	require.YesError(t, createPipeline(true, "good", "bad"))
+
+	// Deleting the pipeline deletes its named output repos, but not the repo
+	// of the removed output
+	require.NoError(t, c.DeletePipeline(pipeline, false))
+	_, err = c.InspectRepo(goodRepo)
+	require.YesError(t, err)
+	_, err = c.InspectRepo(badRepo)
+	require.NoError(t, err)
+}
+
 func TestDebug(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping integration tests in short mode")
@@ -13,9 +13,11 @@ import (
 	"strconv"
 	"strings"
 	gosync "sync"
+	"time"
 
 	prompt "github.com/c-bata/go-prompt"
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/limit"
 	pfsclient "github.com/pachyderm/pachyderm/src/client/pfs"
@@ -606,6 +608,8 @@ Any pachctl command that can take a Commit ID, can take a branch name instead.`,
 	var branchProvenance cmdutil.RepeatedStringArg
 	var head string
 	trigger := &pfsclient.Trigger{}
+	var triggerCronJitter time.Duration
+	var triggerCronSkipMissed bool
 	createBranch := &cobra.Command{
 		Use:   "{{alias}} <repo>@<branch-or-commit>",
 		Short: "Create a new branch, or update an existing branch, on a repo.",
@@ -626,6 +630,12 @@ Any pachctl command that can take a Commit ID, can take a branch name instead.`,
 				trigger.Branch == "" {
 				return errors.Errorf("trigger condition specified without a branch to trigger on, specify a branch with --trigger")
 			}
+			if triggerCronJitter != 0 {
+				trigger.CronJitter = types.DurationProto(triggerCronJitter)
+			}
+			if triggerCronSkipMissed {
+				trigger.CronCatchUp = pfsclient.CronCatchUp_CRON_SKIP_MISSED
+			}
 			c, err := client.NewOnUserMachine("user")
 			if err != nil {
 				return err
@@ -646,6 +656,9 @@ Any pachctl command that can take a Commit ID, can take a branch name instead.`,
 	createBranch.MarkFlagCustom("head", "__pachctl_get_commit $(__parse_repo ${nouns[0]})")
 	createBranch.Flags().StringVarP(&trigger.Branch, "trigger", "t", "", "The branch to trigger this branch on.")
 	createBranch.Flags().StringVar(&trigger.CronSpec, "trigger-cron", "", "The cron spec to use in triggering.")
+	createBranch.Flags().StringVar(&trigger.CronTimezone, "trigger-cron-timezone", "", "The IANA time zone (e.g. America/New_York) in which to evaluate the trigger's cron spec, UTC by default.")
+	createBranch.Flags().DurationVar(&triggerCronJitter, "trigger-cron-jitter", 0, "Delay each tick of the trigger's cron spec by a fixed offset of up to this duration.")
+	createBranch.Flags().BoolVar(&triggerCronSkipMissed, "trigger-cron-skip-missed", false, "Only trigger on the first commit after each tick of the trigger's cron spec, rather than on any commit until the branch is triggered.")
 	createBranch.Flags().StringVar(&trigger.Size_, "trigger-size", "", "The data size to use in triggering.")
 	createBranch.Flags().Int64Var(&trigger.Commits, "trigger-commits", 0, "The number of commits to use in triggering.")
 	createBranch.Flags().BoolVar(&trigger.All, "trigger-all", false, "Only trigger when all conditions are met, rather than when any are met.")
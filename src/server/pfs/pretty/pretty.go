@@ -9,6 +9,7 @@ import (
 
 	units "github.com/docker/go-units"
 	"github.com/fatih/color"
+	"github.com/gogo/protobuf/types"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/server/pkg/pretty"
 )
@@ -83,7 +84,17 @@ Access level: {{ .AuthInfo.AccessLevel.String }}{{end}}
 func printTrigger(trigger *pfs.Trigger) string {
 	var conds []string
 	if trigger.CronSpec != "" {
-		conds = append(conds, fmt.Sprintf("Cron(%s)", trigger.CronSpec))
+		spec := trigger.CronSpec
+		if trigger.CronTimezone != "" {
+			spec += " " + trigger.CronTimezone
+		}
+		if jitter, err := types.DurationFromProto(trigger.CronJitter); err == nil && jitter > 0 {
+			spec += fmt.Sprintf(", jitter %v", jitter)
+		}
+		if trigger.CronCatchUp == pfs.CronCatchUp_CRON_SKIP_MISSED {
+			spec += ", skip missed"
+		}
+		conds = append(conds, fmt.Sprintf("Cron(%s)", spec))
 	}
 	if trigger.Size_ != "" {
 		conds = append(conds, fmt.Sprintf("Size(%s)", trigger.Size_))
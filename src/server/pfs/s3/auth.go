@@ -1,11 +1,31 @@
 package s3
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/pachyderm/pachyderm/src/client/auth"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/s2"
+)
+
+// authV4HeaderValidator is a regex for validating the authorization header
+// when using AWS' auth V4. This is the same one s2 uses.
+var authV4HeaderValidator = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]*)/([^/]*)/([^/]*)/s3/aws4_request, ?SignedHeaders=([^,]+), ?Signature=(.+)$`)
+
+const (
+	// The only signing algorithm supported for AWS' signature v4
+	signatureV4Algorithm = "AWS4-HMAC-SHA256"
+	// The format of signature v4 timestamps, e.g. the `x-amz-date` header
+	signatureV4DateFormat = "20060102T150405Z"
 )
 
 func (c *controller) SecretKey(r *http.Request, accessKey string, region *string) (*string, error) {
@@ -41,6 +61,14 @@ func (c *controller) SecretKey(r *http.Request, accessKey string, region *string
 func (c *controller) CustomAuth(r *http.Request) (bool, error) {
 	c.logger.Debug("CustomAuth")
 
+	// Presigned URLs carry their signature in the query string rather than
+	// the authorization header, so s2 delegates them to us. They're refused,
+	// since their access key is the auth token that signed them, so anyone
+	// with the URL could use the token for anything.
+	if r.URL.Query().Get("X-Amz-Algorithm") != "" {
+		return false, presignedURLsUnsupportedError(r)
+	}
+
 	pc, err := c.clientFactory()
 	if err != nil {
 		return false, errors.Wrapf(err, "could not create a pach client for auth")
@@ -55,3 +83,196 @@ func (c *controller) CustomAuth(r *http.Request) (bool, error) {
 	// pachyderm auth is disabled
 	return !active, nil
 }
+
+// headerAuth validates a request authenticated using AWS' signature v4
+// authorization header. s2 does this for the endpoints it serves; this is
+// used for the endpoints the s3 gateway serves itself.
+func (c *controller) headerAuth(r *http.Request, authHeader string) error {
+	match := authV4HeaderValidator.FindStringSubmatch(authHeader)
+	if len(match) == 0 {
+		return s2.AuthorizationHeaderMalformedError(r)
+	}
+
+	timestamp := r.Header.Get("x-amz-date")
+	if timestamp == "" {
+		t, err := http.ParseTime(r.Header.Get("date"))
+		if err != nil {
+			return s2.AuthorizationHeaderMalformedError(r)
+		}
+		timestamp = t.UTC().Format(signatureV4DateFormat)
+	}
+
+	if err := c.verifySignatureV4(r, signatureV4{
+		accessKey:        match[1],
+		date:             match[2],
+		region:           match[3],
+		timestamp:        timestamp,
+		signedHeaderKeys: strings.Split(match[4], ";"),
+		signedQuery:      r.URL.Query(),
+		payloadHash:      r.Header.Get("x-amz-content-sha256"),
+		signature:        match[5],
+	}); err != nil {
+		return err
+	}
+
+	mux.Vars(r)["authMethod"] = "v4"
+	return nil
+}
+
+// signatureV4 holds the parts of a request signed with AWS' signature v4
+type signatureV4 struct {
+	accessKey        string
+	date             string
+	region           string
+	timestamp        string
+	signedHeaderKeys []string
+	signedQuery      url.Values
+	payloadHash      string
+	signature        string
+}
+
+// verifySignatureV4 checks that a request was signed by the holder of the
+// secret key associated with the signature's access key. On success, the
+// access key is stored in the request vars, so that it's used for the
+// request-scoped pach client.
+func (c *controller) verifySignatureV4(r *http.Request, sig signatureV4) error {
+	secretKey, err := c.SecretKey(r, sig.accessKey, &sig.region)
+	if err != nil {
+		return s2.InternalError(r, err)
+	}
+	if secretKey == nil {
+		return s2.InvalidAccessKeyIDError(r)
+	}
+
+	signedHeaderKeys := append([]string{}, sig.signedHeaderKeys...)
+	sort.Strings(signedHeaderKeys)
+
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		canonicalURI(r.URL.Path),
+		canonicalQuery(sig.signedQuery),
+		canonicalHeaders(r, signedHeaderKeys),
+		strings.Join(signedHeaderKeys, ";"),
+		sig.payloadHash,
+	}, "\n")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := fmt.Sprintf(
+		"%s\n%s\n%s/%s/s3/aws4_request\n%x",
+		signatureV4Algorithm,
+		sig.timestamp,
+		sig.date,
+		sig.region,
+		canonicalRequestHash,
+	)
+
+	signingKey := hmacSHA256([]byte("AWS4"+*secretKey), sig.date)
+	signingKey = hmacSHA256(signingKey, sig.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	if !hmac.Equal([]byte(signature), []byte(sig.signature)) {
+		return s2.SignatureDoesNotMatchError(r)
+	}
+
+	vars := mux.Vars(r)
+	vars["authAccessKey"] = sig.accessKey
+	vars["authRegion"] = sig.region
+	return nil
+}
+
+// authMiddleware authenticates requests to the endpoints that the s3 gateway
+// serves itself, rather than through s2. It mirrors s2's own auth middleware,
+// except that the deprecated signature v2 is not supported.
+func (c *controller) authMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("authorization")
+
+		passed := true
+		var err error
+		if strings.HasPrefix(authHeader, "AWS4-HMAC-SHA256 ") {
+			err = c.headerAuth(r, authHeader)
+		} else if strings.HasPrefix(authHeader, "AWS ") {
+			err = s2.NotImplementedError(r)
+		} else {
+			passed, err = c.CustomAuth(r)
+		}
+		if err != nil {
+			s2.WriteError(c.logger, w, r, err)
+			return
+		}
+		if !passed {
+			s2.WriteError(c.logger, w, r, s2.AccessDeniedError(r))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// canonicalURI encodes a URI path as per AWS' signature v4 rules
+func canonicalURI(path string) string {
+	parts := strings.Split(path, "/")
+	for i, part := range parts {
+		parts[i] = awsURIEncode(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// canonicalQuery encodes query parameters as per AWS' signature v4 rules,
+// sorted by key
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var params []string
+	for _, k := range keys {
+		values := append([]string{}, query[k]...)
+		sort.Strings(values)
+		for _, v := range values {
+			params = append(params, fmt.Sprintf("%s=%s", awsURIEncode(k), awsURIEncode(v)))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// canonicalHeaders serializes the signed headers of a request as per AWS'
+// signature v4 rules. `keys` must be sorted and lowercase.
+func canonicalHeaders(r *http.Request, keys []string) string {
+	var headers strings.Builder
+	for _, key := range keys {
+		headers.WriteString(key)
+		headers.WriteString(":")
+		if key == "host" {
+			headers.WriteString(r.Host)
+		} else {
+			headers.WriteString(strings.TrimSpace(r.Header.Get(key)))
+		}
+		headers.WriteString("\n")
+	}
+	return headers.String()
+}
+
+// awsURIEncode percent-encodes every byte of `s` except for the unreserved
+// characters defined in RFC 3986
+func awsURIEncode(s string) string {
+	var encoded strings.Builder
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		if 'a' <= b && b <= 'z' || 'A' <= b && b <= 'Z' || '0' <= b && b <= '9' || b == '-' || b == '_' || b == '.' || b == '~' {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, content string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(content))
+	return mac.Sum(nil)
+}
@@ -1,12 +1,20 @@
 package s3
 
 import (
+	"bytes"
+	"encoding/xml"
 	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gogo/protobuf/types"
+	"github.com/gorilla/mux"
 	glob "github.com/pachyderm/ohmyglob"
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/auth"
 	pfsClient "github.com/pachyderm/pachyderm/src/client/pfs"
 	pfsServer "github.com/pachyderm/pachyderm/src/server/pfs"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
@@ -157,33 +165,45 @@ func (c *controller) CreateBucket(r *http.Request, bucketName string) error {
 		return err
 	}
 
+	createdRepo := true
 	err = pc.CreateRepo(bucket.Repo)
 	if err != nil {
 		if errutil.IsAlreadyExistError(err) {
 			// Bucket already exists - this is not an error so long as the
 			// branch being created is new. Verify if that is the case now,
 			// since PFS' `CreateBranch` won't error out.
+			createdRepo = false
 			_, err := pc.InspectBranch(bucket.Repo, bucket.Commit)
 			if err != nil {
 				if !pfsServer.IsBranchNotFoundErr(err) {
-					return s2.InternalError(r, err)
+					return maybeNotFoundError(r, err)
 				}
 			} else {
 				return s2.BucketAlreadyOwnedByYouError(r)
 			}
 		} else if ancestry.IsInvalidNameError(err) {
 			return s2.InvalidBucketNameError(r)
+		} else if auth.IsErrNotSignedIn(err) || auth.IsErrBadToken(err) {
+			return s2.AccessDeniedError(r)
 		} else {
 			return s2.InternalError(r, err)
 		}
 	}
 
+	// Creating a branch in an existing repo requires WRITER access to the
+	// repo, which PFS checks for us
 	err = pc.CreateBranch(bucket.Repo, bucket.Commit, "", nil)
 	if err != nil {
+		if createdRepo {
+			// don't leave behind a repo without the requested branch
+			if err := pc.DeleteRepo(bucket.Repo, false); err != nil {
+				c.logger.Errorf("could not clean up repo %q after failing to create branch: %v", bucket.Repo, err)
+			}
+		}
 		if ancestry.IsInvalidNameError(err) {
 			return s2.InvalidBucketNameError(r)
 		}
-		return s2.InternalError(r, err)
+		return maybeNotFoundError(r, err)
 	}
 
 	return nil
@@ -224,7 +244,7 @@ func (c *controller) DeleteBucket(r *http.Request, bucketName string) error {
 			return nil
 		})
 		if err != nil {
-			return s2.InternalError(r, err)
+			return maybeNotFoundError(r, err)
 		}
 
 		if hasFiles {
@@ -232,34 +252,420 @@ func (c *controller) DeleteBucket(r *http.Request, bucketName string) error {
 		}
 	}
 
-	err = pc.DeleteBranch(bucket.Repo, bucket.Commit, false)
-	if err != nil {
-		return s2.InternalError(r, err)
-	}
-
 	repoInfo, err := pc.InspectRepo(bucket.Repo)
 	if err != nil {
-		return s2.InternalError(r, err)
+		return maybeNotFoundError(r, err)
 	}
 
-	// delete the repo if this was the last branch
-	if len(repoInfo.Branches) == 0 {
+	// If this is the last branch, delete the repo along with it. Deleting a
+	// repo requires OWNER access, whereas deleting a branch only requires
+	// WRITER access, so callers who aren't owners just delete the branch.
+	if len(repoInfo.Branches) == 1 && repoInfo.Branches[0].Name == bucket.Commit {
 		err = pc.DeleteRepo(bucket.Repo, false)
-		if err != nil {
-			return s2.InternalError(r, err)
+		if err == nil {
+			return nil
+		} else if !auth.IsErrNotAuthorized(err) {
+			return maybeNotFoundError(r, err)
 		}
 	}
 
+	if err = pc.DeleteBranch(bucket.Repo, bucket.Commit, false); err != nil {
+		return maybeNotFoundError(r, err)
+	}
+
 	return nil
 }
 
+// ListObjectVersions implements s2's BucketController. s2's result can't hold
+// common prefixes, and s2 derives the next markers from the highest key and
+// version it returns rather than the last ones, so listing versions is served
+// by listObjectVersionsHandler instead, and this only exists to satisfy the
+// interface.
 func (c *controller) ListObjectVersions(r *http.Request, bucketName, prefix, keyMarker, versionIDMarker string, delimiter string, maxKeys int) (*s2.ListObjectVersionsResult, error) {
-	// NOTE: because this endpoint isn't implemented, conformance tests will
-	// fail on teardown. It's nevertheless unimplemented because it's too
-	// expensive to pull off with PFS until this is implemented:
-	// https://github.com/pachyderm/pachyderm/issues/3896
+	result, err := c.listObjectVersions(r, bucketName, prefix, keyMarker, versionIDMarker, delimiter, maxKeys)
+	if err != nil {
+		return nil, err
+	}
+	return &s2.ListObjectVersionsResult{
+		Versions:      result.Versions,
+		DeleteMarkers: result.DeleteMarkers,
+		IsTruncated:   result.IsTruncated,
+	}, nil
+}
+
+// listObjectVersionsResult is the result of a ListObjectVersions call
+type listObjectVersionsResult struct {
+	Versions       []*s2.Version
+	DeleteMarkers  []*s2.DeleteMarker
+	CommonPrefixes []*s2.CommonPrefixes
+	IsTruncated    bool
+	// NextKeyMarker and NextVersionIDMarker identify the last entry returned,
+	// if the result is truncated
+	NextKeyMarker       string
+	NextVersionIDMarker string
+}
+
+func (c *controller) listObjectVersions(r *http.Request, bucketName, prefix, keyMarker, versionIDMarker string, delimiter string, maxKeys int) (*listObjectVersionsResult, error) {
 	c.logger.Debugf("ListObjectVersions: bucketName=%+v, prefix=%+v, keyMarker=%+v, versionIDMarker=%+v, delimiter=%+v, maxKeys=%+v", bucketName, prefix, keyMarker, versionIDMarker, delimiter, maxKeys)
-	return nil, s2.NotImplementedError(r)
+
+	pc, err := c.requestClient(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if delimiter != "" && delimiter != "/" {
+		return nil, invalidDelimiterError(r)
+	}
+
+	bucket, err := c.driver.bucket(pc, r, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	bucketCaps, err := c.driver.bucketCapabilities(pc, r, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	result := listObjectVersionsResult{
+		Versions:       []*s2.Version{},
+		DeleteMarkers:  []*s2.DeleteMarker{},
+		CommonPrefixes: []*s2.CommonPrefixes{},
+	}
+
+	if !bucketCaps.readable {
+		// serve empty results if we can't read the bucket; this helps with s3
+		// conformance
+		return &result, nil
+	}
+
+	history, err := c.objectHistory(pc, r, bucket, bucketCaps, prefix, delimiter == "")
+	if err != nil {
+		return nil, err
+	}
+
+	page, truncated := pageObjectVersions(history, keyMarker, versionIDMarker, maxKeys)
+	for _, v := range page {
+		switch {
+		case v.deleteMarker != nil:
+			result.DeleteMarkers = append(result.DeleteMarkers, v.deleteMarker)
+		case v.commonPrefix != nil:
+			result.CommonPrefixes = append(result.CommonPrefixes, v.commonPrefix)
+		default:
+			result.Versions = append(result.Versions, v.objectVersion)
+		}
+	}
+	if truncated {
+		last := page[len(page)-1]
+		result.IsTruncated = true
+		result.NextKeyMarker = last.key()
+		result.NextVersionIDMarker = last.version()
+	}
+
+	return &result, nil
+}
+
+// pageObjectVersions returns up to maxKeys entries of 'history' following
+// the entry identified by 'keyMarker' and 'versionIDMarker', and whether
+// there are more entries after them. If 'versionIDMarker' is empty, every
+// entry for 'keyMarker' is skipped, as in S3.
+func pageObjectVersions(history []*objectVersion, keyMarker, versionIDMarker string, maxKeys int) ([]*objectVersion, bool) {
+	start := sort.Search(len(history), func(i int) bool {
+		return history[i].key() >= keyMarker
+	})
+	if keyMarker != "" {
+		end := sort.Search(len(history), func(i int) bool {
+			return history[i].key() > keyMarker
+		})
+		if versionIDMarker == "" {
+			start = end
+		} else {
+			// skip past the version marker, which is the last version
+			// returned in the previous page
+			for i := start; i < end; i++ {
+				if history[i].version() == versionIDMarker {
+					start = i + 1
+					break
+				}
+			}
+		}
+	}
+	history = history[start:]
+	if len(history) > maxKeys {
+		return history[:maxKeys], maxKeys > 0
+	}
+	return history, false
+}
+
+// objectVersion is an entry in a listing of object versions: a version of an
+// object, a delete marker representing the deletion of the object in a given
+// commit, or a common prefix
+type objectVersion struct {
+	objectVersion *s2.Version
+	deleteMarker  *s2.DeleteMarker
+	commonPrefix  *s2.CommonPrefixes
+}
+
+func (v *objectVersion) key() string {
+	switch {
+	case v.deleteMarker != nil:
+		return v.deleteMarker.Key
+	case v.commonPrefix != nil:
+		return v.commonPrefix.Prefix
+	}
+	return v.objectVersion.Key
+}
+
+func (v *objectVersion) version() string {
+	switch {
+	case v.deleteMarker != nil:
+		return v.deleteMarker.Version
+	case v.commonPrefix != nil:
+		return ""
+	}
+	return v.objectVersion.Version
+}
+
+// objectHistory returns the versions of every object in 'bucket' matching
+// 'prefix', ordered by key, and newest first for each key, as S3 does. If
+// 'recursive' isn't set, the directories under 'prefix' are returned as
+// common prefixes instead of the objects in them. Histories are cached by the
+// commit that the bucket points to, so that paging through a listing doesn't
+// recompute it for every page.
+func (c *controller) objectHistory(pc *client.APIClient, r *http.Request, bucket *Bucket, bucketCaps bucketCapabilities, prefix string, recursive bool) ([]*objectVersion, error) {
+	headInfo, err := pc.InspectCommit(bucket.Repo, bucket.Commit)
+	if err != nil {
+		return nil, maybeNotFoundError(r, err)
+	}
+	cacheKey := fmt.Sprintf("%s@%s:%t:%t:%t:%s", bucket.Repo, headInfo.Commit.ID, headInfo.Finished != nil, bucketCaps.historicVersions, recursive, prefix)
+	if history, ok := c.versionsCache.Get(cacheKey); ok {
+		return history.([]*objectVersion), nil
+	}
+
+	// Every finished commit on the branch is an S3 version. If the bucket
+	// doesn't support historic versions, only the commit the bucket points
+	// to is considered.
+	var commitInfos []*pfsClient.CommitInfo
+	if bucketCaps.historicVersions {
+		err = pc.ListCommitF(bucket.Repo, headInfo.Commit.ID, "", 0, true, func(commitInfo *pfsClient.CommitInfo) error {
+			if commitInfo.Finished != nil {
+				commitInfos = append(commitInfos, commitInfo)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, maybeNotFoundError(r, err)
+		}
+	} else {
+		commitInfos = append(commitInfos, headInfo)
+	}
+
+	history, err := computeObjectHistory(pc, bucket, commitInfos, prefix, recursive)
+	if err != nil {
+		return nil, err
+	}
+	if !bucketCaps.historicVersions {
+		for _, v := range history {
+			if v.deleteMarker != nil {
+				v.deleteMarker.Version = ""
+			} else if v.objectVersion != nil {
+				v.objectVersion.Version = ""
+			}
+		}
+	}
+	c.versionsCache.Add(cacheKey, history)
+	return history, nil
+}
+
+// computeObjectHistory computes the versions of every object matching
+// `prefix` over the given commits, which must be ordered from oldest to
+// newest. A new version is created whenever an object is added or its
+// content changes, and a delete marker is created whenever an object is
+// removed.
+func computeObjectHistory(pc *client.APIClient, bucket *Bucket, commitInfos []*pfsClient.CommitInfo, prefix string, recursive bool) ([]*objectVersion, error) {
+	var pattern string
+	if recursive {
+		pattern = fmt.Sprintf("%s**", glob.QuoteMeta(prefix))
+	} else {
+		pattern = fmt.Sprintf("%s*", glob.QuoteMeta(prefix))
+	}
+
+	history := map[string][]*objectVersion{}
+	prefixes := map[string]bool{}
+	prevFiles := map[string]*pfsClient.FileInfo{}
+	for _, commitInfo := range commitInfos {
+		files := map[string]*pfsClient.FileInfo{}
+		err := pc.GlobFileF(bucket.Repo, commitInfo.Commit.ID, pattern, func(fileInfo *pfsClient.FileInfo) error {
+			key := fileInfo.File.Path[1:] // strip leading slash
+			if !strings.HasPrefix(key, prefix) {
+				return nil
+			}
+			if fileInfo.FileType == pfsClient.FileType_DIR {
+				if !recursive && key != "" {
+					prefixes[key+"/"] = true
+				}
+				return nil
+			}
+			if fileInfo.FileType == pfsClient.FileType_FILE {
+				files[key] = fileInfo
+			}
+			return nil
+		})
+		if err != nil {
+			if pfsServer.IsOutputCommitNotFinishedErr(err) {
+				// failed output commits have no content
+				continue
+			}
+			return nil, err
+		}
+
+		for key, fileInfo := range files {
+			if prevFileInfo, ok := prevFiles[key]; ok && bytes.Equal(prevFileInfo.Hash, fileInfo.Hash) {
+				continue
+			}
+			contents, err := newContents(fileInfo)
+			if err != nil {
+				return nil, err
+			}
+			history[key] = append([]*objectVersion{{
+				objectVersion: &s2.Version{
+					Key:          key,
+					Version:      commitInfo.Commit.ID,
+					LastModified: contents.LastModified,
+					ETag:         contents.ETag,
+					Size:         contents.Size,
+					StorageClass: contents.StorageClass,
+					Owner:        contents.Owner,
+				},
+			}}, history[key]...)
+		}
+
+		for key := range prevFiles {
+			if _, ok := files[key]; ok {
+				continue
+			}
+			t, err := types.TimestampFromProto(commitInfo.Finished)
+			if err != nil {
+				return nil, err
+			}
+			history[key] = append([]*objectVersion{{
+				deleteMarker: &s2.DeleteMarker{
+					Key:          key,
+					Version:      commitInfo.Commit.ID,
+					LastModified: t,
+					Owner:        defaultUser,
+				},
+			}}, history[key]...)
+		}
+
+		prevFiles = files
+	}
+
+	for _, versions := range history {
+		if versions[0].deleteMarker != nil {
+			versions[0].deleteMarker.IsLatest = true
+		} else {
+			versions[0].objectVersion.IsLatest = true
+		}
+	}
+
+	keys := make([]string, 0, len(history)+len(prefixes))
+	for key := range history {
+		keys = append(keys, key)
+	}
+	for key := range prefixes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var result []*objectVersion
+	for _, key := range keys {
+		if prefixes[key] {
+			result = append(result, &objectVersion{
+				commonPrefix: &s2.CommonPrefixes{
+					Prefix: key,
+					Owner:  defaultUser,
+				},
+			})
+			continue
+		}
+		result = append(result, history[key]...)
+	}
+	return result, nil
+}
+
+// listObjectVersionsHandler serves ListObjectVersions requests
+func (c *controller) listObjectVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	maxKeys := defaultMaxKeys
+	if s := r.FormValue("max-keys"); s != "" {
+		i, err := strconv.Atoi(s)
+		if err != nil || i < 0 || i > defaultMaxKeys {
+			s2.WriteError(c.logger, w, r, s2.InvalidArgumentError(r))
+			return
+		}
+		maxKeys = i
+	}
+
+	prefix := r.FormValue("prefix")
+	keyMarker := r.FormValue("key-marker")
+	versionIDMarker := r.FormValue("version-id-marker")
+	delimiter := r.FormValue("delimiter")
+
+	result, err := c.listObjectVersions(r, bucket, prefix, keyMarker, versionIDMarker, delimiter, maxKeys)
+	if err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+
+	// results may be cached, so they're copied before being modified.
+	// Some clients (e.g. minio-python) can't handle sub-seconds in datetime
+	// output.
+	versions := make([]*s2.Version, len(result.Versions))
+	for i, v := range result.Versions {
+		version := *v
+		version.LastModified = version.LastModified.UTC().Round(time.Second)
+		if !strings.HasPrefix(version.ETag, "\"") {
+			version.ETag = fmt.Sprintf("\"%s\"", version.ETag)
+		}
+		versions[i] = &version
+	}
+	deleteMarkers := make([]*s2.DeleteMarker, len(result.DeleteMarkers))
+	for i, d := range result.DeleteMarkers {
+		deleteMarker := *d
+		deleteMarker.LastModified = deleteMarker.LastModified.UTC().Round(time.Second)
+		deleteMarkers[i] = &deleteMarker
+	}
+
+	writeXML(c.logger, w, http.StatusOK, struct {
+		XMLName             xml.Name             `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult"`
+		Delimiter           string               `xml:"Delimiter,omitempty"`
+		IsTruncated         bool                 `xml:"IsTruncated"`
+		KeyMarker           string               `xml:"KeyMarker"`
+		NextKeyMarker       string               `xml:"NextKeyMarker,omitempty"`
+		MaxKeys             int                  `xml:"MaxKeys"`
+		Name                string               `xml:"Name"`
+		VersionIDMarker     string               `xml:"VersionIdMarker"`
+		NextVersionIDMarker string               `xml:"NextVersionIdMarker,omitempty"`
+		Prefix              string               `xml:"Prefix"`
+		Versions            []*s2.Version        `xml:"Version"`
+		DeleteMarkers       []*s2.DeleteMarker   `xml:"DeleteMarker"`
+		CommonPrefixes      []*s2.CommonPrefixes `xml:"CommonPrefixes"`
+	}{
+		Delimiter:           delimiter,
+		IsTruncated:         result.IsTruncated,
+		KeyMarker:           keyMarker,
+		NextKeyMarker:       result.NextKeyMarker,
+		MaxKeys:             maxKeys,
+		Name:                bucket,
+		VersionIDMarker:     versionIDMarker,
+		NextVersionIDMarker: result.NextVersionIDMarker,
+		Prefix:              prefix,
+		Versions:            versions,
+		DeleteMarkers:       deleteMarkers,
+		CommonPrefixes:      result.CommonPrefixes,
+	})
 }
 
 func (c *controller) GetBucketVersioning(r *http.Request, bucketName string) (string, error) {
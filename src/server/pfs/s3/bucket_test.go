@@ -0,0 +1,47 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/s2"
+)
+
+func TestPageObjectVersions(t *testing.T) {
+	history := []*objectVersion{
+		{objectVersion: &s2.Version{Key: "a", Version: "3"}},
+		{deleteMarker: &s2.DeleteMarker{Key: "a", Version: "2"}},
+		{objectVersion: &s2.Version{Key: "a", Version: "1"}},
+		{objectVersion: &s2.Version{Key: "b", Version: "2"}},
+		{commonPrefix: &s2.CommonPrefixes{Prefix: "dir/"}},
+	}
+	entries := func(page []*objectVersion) []string {
+		var result []string
+		for _, v := range page {
+			result = append(result, v.key()+"@"+v.version())
+		}
+		return result
+	}
+
+	page, truncated := pageObjectVersions(history, "", "", 2)
+	require.Equal(t, []string{"a@3", "a@2"}, entries(page))
+	require.True(t, truncated)
+
+	// resuming from a version skips only the versions up to and including it
+	page, truncated = pageObjectVersions(history, "a", "2", 2)
+	require.Equal(t, []string{"a@1", "b@2"}, entries(page))
+	require.True(t, truncated)
+
+	// resuming from a key without a version skips all of its versions
+	page, truncated = pageObjectVersions(history, "a", "", 10)
+	require.Equal(t, []string{"b@2", "dir/@"}, entries(page))
+	require.False(t, truncated)
+
+	page, truncated = pageObjectVersions(history, "b", "2", 1)
+	require.Equal(t, []string{"dir/@"}, entries(page))
+	require.False(t, truncated)
+
+	page, truncated = pageObjectVersions(history, "dir/", "", 1)
+	require.Equal(t, 0, len(page))
+	require.False(t, truncated)
+}
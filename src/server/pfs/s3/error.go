@@ -3,6 +3,7 @@ package s3
 import (
 	"net/http"
 
+	"github.com/pachyderm/pachyderm/src/client/auth"
 	"github.com/pachyderm/pachyderm/src/server/pfs"
 	"github.com/pachyderm/s2"
 )
@@ -23,8 +24,14 @@ func writeToOutputBranchError(r *http.Request) *s2.Error {
 	return s2.NewError(r, http.StatusBadRequest, "WriteToOutputBranch", "You cannot write to an output branch")
 }
 
+func presignedURLsUnsupportedError(r *http.Request) *s2.Error {
+	return s2.NewError(r, http.StatusNotImplemented, "NotImplemented", "Presigned URLs are not supported, as they would expose the auth token used to sign them")
+}
+
 func maybeNotFoundError(r *http.Request, err error) *s2.Error {
-	if pfs.IsRepoNotFoundErr(err) || pfs.IsBranchNotFoundErr(err) {
+	if auth.IsErrNotAuthorized(err) {
+		return s2.AccessDeniedError(r)
+	} else if pfs.IsRepoNotFoundErr(err) || pfs.IsBranchNotFoundErr(err) {
 		return s2.NoSuchBucketError(r)
 	} else if pfs.IsFileNotFoundErr(err) {
 		return s2.NoSuchKeyError(r)
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
 	minio "github.com/minio/minio-go"
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
@@ -416,6 +419,292 @@ func masterListObjectsRecursive(t *testing.T, pachClient *client.APIClient, mini
 	checkListObjects(t, ch, &startTime, &endTime, expectedFiles, []string{})
 }
 
+func masterListObjectVersions(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testlistobjectversions")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	_, err := pachClient.PutFile(repo, "master", "file", strings.NewReader("content1"))
+	require.NoError(t, err)
+	commit1, err := pachClient.InspectCommit(repo, "master")
+	require.NoError(t, err)
+	_, err = pachClient.PutFileOverwrite(repo, "master", "file", strings.NewReader("content2"), 0)
+	require.NoError(t, err)
+	commit2, err := pachClient.InspectCommit(repo, "master")
+	require.NoError(t, err)
+	// this commit doesn't change `file`, so it shouldn't create a new version
+	_, err = pachClient.PutFile(repo, "master", "other", strings.NewReader("other"))
+	require.NoError(t, err)
+	require.NoError(t, pachClient.DeleteFile(repo, "master", "file"))
+	commit4, err := pachClient.InspectCommit(repo, "master")
+	require.NoError(t, err)
+
+	s3Client := awsClient(t)
+	bucket := fmt.Sprintf("master.%s", repo)
+	out, err := s3Client.ListObjectVersions(&s3.ListObjectVersionsInput{
+		Bucket: aws.String(bucket),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, 1, len(out.DeleteMarkers))
+	require.Equal(t, "file", *out.DeleteMarkers[0].Key)
+	require.Equal(t, commit4.Commit.ID, *out.DeleteMarkers[0].VersionId)
+	require.True(t, *out.DeleteMarkers[0].IsLatest)
+
+	require.Equal(t, 3, len(out.Versions))
+	require.Equal(t, "file", *out.Versions[0].Key)
+	require.Equal(t, commit2.Commit.ID, *out.Versions[0].VersionId)
+	require.False(t, *out.Versions[0].IsLatest)
+	require.Equal(t, "file", *out.Versions[1].Key)
+	require.Equal(t, commit1.Commit.ID, *out.Versions[1].VersionId)
+	require.False(t, *out.Versions[1].IsLatest)
+	require.Equal(t, "other", *out.Versions[2].Key)
+	require.True(t, *out.Versions[2].IsLatest)
+
+	// historical versions remain readable, even after the file is deleted
+	obj, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:    aws.String(bucket),
+		Key:       aws.String("file"),
+		VersionId: aws.String(commit1.Commit.ID),
+	})
+	require.NoError(t, err)
+	defer obj.Body.Close()
+	content, err := ioutil.ReadAll(obj.Body)
+	require.NoError(t, err)
+	require.Equal(t, "content1", string(content))
+	require.Equal(t, commit1.Commit.ID, *obj.VersionId)
+}
+
+func masterListObjectVersionsPaginated(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testlistobjectversionspaginated")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	var expected []string
+	for _, file := range []string{"a", "b"} {
+		var versions []string
+		for i := 0; i < 3; i++ {
+			_, err := pachClient.PutFileOverwrite(repo, "master", file, strings.NewReader(fmt.Sprintf("%d", i)), 0)
+			require.NoError(t, err)
+			commitInfo, err := pachClient.InspectCommit(repo, "master")
+			require.NoError(t, err)
+			versions = append([]string{file + "@" + commitInfo.Commit.ID}, versions...)
+		}
+		expected = append(expected, versions...)
+	}
+	_, err := pachClient.PutFile(repo, "master", "dir/c", strings.NewReader("c"))
+	require.NoError(t, err)
+
+	// every version is returned exactly once when paging through the
+	// versions of keys with several versions, one at a time
+	s3Client := awsClient(t)
+	bucket := fmt.Sprintf("master.%s", repo)
+	var versions []string
+	var pages int
+	err = s3Client.ListObjectVersionsPages(&s3.ListObjectVersionsInput{
+		Bucket:    aws.String(bucket),
+		Delimiter: aws.String("/"),
+		MaxKeys:   aws.Int64(1),
+	}, func(out *s3.ListObjectVersionsOutput, lastPage bool) bool {
+		pages++
+		for _, version := range out.Versions {
+			versions = append(versions, *version.Key+"@"+*version.VersionId)
+		}
+		for _, prefix := range out.CommonPrefixes {
+			versions = append(versions, *prefix.Prefix)
+		}
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, append(expected, "dir/"), versions)
+	require.Equal(t, 7, pages)
+}
+
+func masterPresignedURL(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testpresignedurl")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	_, err := pachClient.PutFile(repo, "master", "file", strings.NewReader("content"))
+	require.NoError(t, err)
+
+	// presigned URLs are refused, as their access key would expose the auth
+	// token that signed them
+	presignClient, err := minio.NewV4("127.0.0.1:30600", "presign", "presign", false)
+	require.NoError(t, err)
+	u, err := presignClient.PresignedGetObject(fmt.Sprintf("master.%s", repo), "file", time.Minute, nil)
+	require.NoError(t, err)
+	resp, err := http.Get(u.String())
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+
+	u, err = presignClient.PresignedPutObject(fmt.Sprintf("master.%s", repo), "file", time.Minute)
+	require.NoError(t, err)
+	req, err := http.NewRequest("PUT", u.String(), strings.NewReader("changed"))
+	require.NoError(t, err)
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotImplemented, resp.StatusCode)
+
+	fetchedContent, err := getObject(t, minioClient, fmt.Sprintf("master.%s", repo), "file")
+	require.NoError(t, err)
+	require.Equal(t, "content", fetchedContent)
+}
+
+func masterGetObjectTagging(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testgetobjecttagging")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	_, err := pachClient.PutFile(repo, "master", "file", strings.NewReader("content"))
+	require.NoError(t, err)
+	commit1, err := pachClient.InspectCommit(repo, "master")
+	require.NoError(t, err)
+	_, err = pachClient.PutFile(repo, "master", "other", strings.NewReader("content"))
+	require.NoError(t, err)
+
+	s3Client := awsClient(t)
+	out, err := s3Client.GetObjectTagging(&s3.GetObjectTaggingInput{
+		Bucket: aws.String(fmt.Sprintf("master.%s", repo)),
+		Key:    aws.String("file"),
+	})
+	require.NoError(t, err)
+
+	tags := map[string]string{}
+	for _, tag := range out.TagSet {
+		tags[*tag.Key] = *tag.Value
+	}
+	// the tags describe the commit in which the file was last modified
+	require.Equal(t, commit1.Commit.ID, tags["pachyderm-commit"])
+	require.Equal(t, "master", tags["pachyderm-branch"])
+	require.Equal(t, "USER", tags["pachyderm-origin"])
+	require.Equal(t, commit1.Commit.ID, *out.VersionId)
+
+	// tags are read-only
+	_, err = s3Client.PutObjectTagging(&s3.PutObjectTaggingInput{
+		Bucket:  aws.String(fmt.Sprintf("master.%s", repo)),
+		Key:     aws.String("file"),
+		Tagging: &s3.Tagging{TagSet: []*s3.Tag{{Key: aws.String("k"), Value: aws.String("v")}}},
+	})
+	require.YesError(t, err)
+
+	_, err = s3Client.GetObjectTagging(&s3.GetObjectTaggingInput{
+		Bucket: aws.String(fmt.Sprintf("master.%s", repo)),
+		Key:    aws.String("missing"),
+	})
+	require.YesError(t, err)
+}
+
+func masterConditionalGetObject(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testconditionalgetobject")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	_, err := pachClient.PutFile(repo, "master", "file", strings.NewReader("content"))
+	require.NoError(t, err)
+
+	bucket := fmt.Sprintf("master.%s", repo)
+	info, err := minioClient.StatObject(bucket, "file", minio.StatObjectOptions{})
+	require.NoError(t, err)
+
+	// commits that don't modify the file don't change its ETag or
+	// modification time
+	time.Sleep(time.Second)
+	_, err = pachClient.PutFile(repo, "master", "other", strings.NewReader("other"))
+	require.NoError(t, err)
+	newInfo, err := minioClient.StatObject(bucket, "file", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, info.ETag, newInfo.ETag)
+	require.Equal(t, info.LastModified, newInfo.LastModified)
+
+	s3Client := awsClient(t)
+	_, err = s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:      aws.String(bucket),
+		Key:         aws.String("file"),
+		IfNoneMatch: aws.String(info.ETag),
+	})
+	require.YesError(t, err)
+	require.Matches(t, "NotModified", err.Error())
+
+	_, err = s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:          aws.String(bucket),
+		Key:             aws.String("file"),
+		IfModifiedSince: aws.Time(info.LastModified),
+	})
+	require.YesError(t, err)
+	require.Matches(t, "NotModified", err.Error())
+
+	_, err = s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:  aws.String(bucket),
+		Key:     aws.String("file"),
+		IfMatch: aws.String("\"not-the-etag\""),
+	})
+	require.YesError(t, err)
+	require.Matches(t, "PreconditionFailed", err.Error())
+
+	obj, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket:  aws.String(bucket),
+		Key:     aws.String("file"),
+		IfMatch: aws.String(info.ETag),
+	})
+	require.NoError(t, err)
+	defer obj.Body.Close()
+	content, err := ioutil.ReadAll(obj.Body)
+	require.NoError(t, err)
+	require.Equal(t, "content", string(content))
+
+	// modifying the file changes its ETag
+	_, err = pachClient.PutFileOverwrite(repo, "master", "file", strings.NewReader("new-content"), 0)
+	require.NoError(t, err)
+	newInfo, err = minioClient.StatObject(bucket, "file", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	require.NotEqual(t, info.ETag, newInfo.ETag)
+
+	// files that only exist in an open commit are served too
+	commit, err := pachClient.StartCommit(repo, "master")
+	require.NoError(t, err)
+	_, err = pachClient.PutFile(repo, commit.ID, "open", strings.NewReader("open"))
+	require.NoError(t, err)
+	_, err = minioClient.StatObject(bucket, "open", minio.StatObjectOptions{})
+	require.NoError(t, err)
+	require.NoError(t, pachClient.FinishCommit(repo, commit.ID))
+}
+
+func masterRangeGetObject(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
+	repo := tu.UniqueString("testrangegetobject")
+	require.NoError(t, pachClient.CreateRepo(repo))
+	_, err := pachClient.PutFile(repo, "master", "file", strings.NewReader("0123456789"))
+	require.NoError(t, err)
+
+	bucket := fmt.Sprintf("master.%s", repo)
+	opts := minio.GetObjectOptions{}
+	require.NoError(t, opts.SetRange(2, 5))
+	obj, err := minioClient.GetObject(bucket, "file", opts)
+	require.NoError(t, err)
+	content, err := ioutil.ReadAll(obj)
+	require.NoError(t, err)
+	require.Equal(t, "2345", string(content))
+
+	// suffix range
+	s3Client := awsClient(t)
+	out, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String("file"),
+		Range:  aws.String("bytes=-3"),
+	})
+	require.NoError(t, err)
+	defer out.Body.Close()
+	content, err = ioutil.ReadAll(out.Body)
+	require.NoError(t, err)
+	require.Equal(t, "789", string(content))
+
+	// multiple ranges are served as a multipart response
+	out, err = s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String("file"),
+		Range:  aws.String("bytes=0-1,8-9"),
+	})
+	require.NoError(t, err)
+	defer out.Body.Close()
+	require.True(t, strings.HasPrefix(*out.ContentType, "multipart/byteranges"))
+	content, err = ioutil.ReadAll(out.Body)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(string(content), "01"))
+	require.True(t, strings.Contains(string(content), "89"))
+}
+
 func masterAuthV2(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client) {
 	// The other tests use auth V4, versus this which checks auth V2
 	minioClientV2, err := minio.NewV2("127.0.0.1:30600", "", "", false)
@@ -496,6 +785,24 @@ func TestMasterDriver(t *testing.T) {
 		t.Run("ListObjectsRecursive", func(t *testing.T) {
 			masterListObjectsRecursive(t, pachClient, minioClient)
 		})
+		t.Run("ListObjectVersions", func(t *testing.T) {
+			masterListObjectVersions(t, pachClient, minioClient)
+		})
+		t.Run("ListObjectVersionsPaginated", func(t *testing.T) {
+			masterListObjectVersionsPaginated(t, pachClient, minioClient)
+		})
+		t.Run("PresignedURL", func(t *testing.T) {
+			masterPresignedURL(t, pachClient, minioClient)
+		})
+		t.Run("GetObjectTagging", func(t *testing.T) {
+			masterGetObjectTagging(t, pachClient, minioClient)
+		})
+		t.Run("ConditionalGetObject", func(t *testing.T) {
+			masterConditionalGetObject(t, pachClient, minioClient)
+		})
+		t.Run("RangeGetObject", func(t *testing.T) {
+			masterRangeGetObject(t, pachClient, minioClient)
+		})
 		t.Run("AuthV2", func(t *testing.T) {
 			masterAuthV2(t, pachClient, minioClient)
 		})
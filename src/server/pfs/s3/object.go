@@ -1,12 +1,16 @@
 package s3
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gogo/protobuf/types"
+	"github.com/pachyderm/pachyderm/src/client"
+	pfsClient "github.com/pachyderm/pachyderm/src/client/pfs"
 	pfsServer "github.com/pachyderm/pachyderm/src/server/pfs"
 	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/s2"
@@ -37,14 +41,9 @@ func (c *controller) GetObject(r *http.Request, bucketName, file, version string
 	}
 
 	if bucketCaps.historicVersions && version != "" {
-		commitInfo, err := pc.InspectCommit(bucket.Repo, version)
-		if err != nil {
-			return nil, maybeNotFoundError(r, err)
-		}
-		if commitInfo.Branch.Name != bucket.Commit {
-			return nil, s2.NoSuchVersionError(r)
+		if err := resolveVersion(pc, r, bucket, version); err != nil {
+			return nil, err
 		}
-		bucket.Commit = commitInfo.Commit.ID
 	}
 
 	fileInfo, err := pc.InspectFile(bucket.Repo, bucket.Commit, file)
@@ -52,7 +51,15 @@ func (c *controller) GetObject(r *http.Request, bucketName, file, version string
 		return nil, maybeNotFoundError(r, err)
 	}
 
-	modTime, err := types.TimestampFromProto(fileInfo.Committed)
+	// The file hash is used as a strong ETag, and the finish time of the
+	// commit that wrote the file's content as the modification time, so that
+	// s2 can serve conditional and range requests without the object
+	// changing under a client between unrelated commits
+	modCommitInfo, err := c.lastModified(pc, fileInfo)
+	if err != nil {
+		return nil, maybeNotFoundError(r, err)
+	}
+	modTime, err := commitModTime(modCommitInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -69,10 +76,117 @@ func (c *controller) GetObject(r *http.Request, bucketName, file, version string
 		Version:      bucket.Commit,
 		DeleteMarker: false,
 	}
+	if bucketCaps.historicVersions {
+		result.Version = modCommitInfo.Commit.ID
+	}
 
 	return &result, nil
 }
 
+// lastModified finds the commit in which the current content of a file was
+// written, by walking back through the ancestors of the commit it was read
+// from until its content differs. This is the commit that
+// `ListObjectVersions` reports as the object's version. The result for each
+// finished commit on the way is cached, so that later walks, e.g. from a new
+// head of the branch, stop at the first commit that has already been walked
+// through. If the file's content was written in an open commit, that commit is
+// returned. The walk reads at most lastModifiedWalkLimit commits; if the
+// file's content is the same in all of them, the commit it was read from is
+// returned instead, so that the cost of a request doesn't grow with the
+// branch's history.
+func (c *controller) lastModified(pc *client.APIClient, fileInfo *pfsClient.FileInfo) (*pfsClient.CommitInfo, error) {
+	repo := fileInfo.File.Commit.Repo.Name
+	var first, result *pfsClient.CommitInfo
+	var walked []string
+	var done bool
+	err := pc.ListCommitF(repo, fileInfo.File.Commit.ID, "", lastModifiedWalkLimit, false, func(commitInfo *pfsClient.CommitInfo) error {
+		if first == nil {
+			first = commitInfo
+		}
+		if commitInfo.ParentCommit == nil {
+			// the file can't have been written any earlier
+			done = true
+		}
+		if commitInfo.Finished == nil {
+			// open commits don't have content yet
+			if result == nil {
+				result = commitInfo
+			}
+			return nil
+		}
+		cacheKey := lastModifiedCacheKey(repo, commitInfo.Commit.ID, fileInfo)
+		if cached, ok := c.lastModifiedCache.Get(cacheKey); ok {
+			result = cached.(*pfsClient.CommitInfo)
+			done = true
+			return errutil.ErrBreak
+		}
+		prevFileInfo, err := pc.InspectFile(repo, commitInfo.Commit.ID, fileInfo.File.Path)
+		if err != nil {
+			if pfsServer.IsFileNotFoundErr(err) || pfsServer.IsOutputCommitNotFinishedErr(err) {
+				done = true
+				return errutil.ErrBreak
+			}
+			return err
+		}
+		if !bytes.Equal(prevFileInfo.Hash, fileInfo.Hash) {
+			done = true
+			return errutil.ErrBreak
+		}
+		walked = append(walked, cacheKey)
+		result = commitInfo
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, pfsServer.ErrFileNotFound{File: fileInfo.File}
+	}
+	if !done {
+		// The walk hit its limit before finding where the content was
+		// written, so fall back to the commit that the file was read from.
+		// Only that commit's result is cached, as the older commits that
+		// were walked through weren't written after it.
+		if first.Finished != nil {
+			c.lastModifiedCache.Add(lastModifiedCacheKey(repo, first.Commit.ID, fileInfo), first)
+		}
+		return first, nil
+	}
+	if result.Finished != nil {
+		for _, cacheKey := range walked {
+			c.lastModifiedCache.Add(cacheKey, result)
+		}
+	}
+	return result, nil
+}
+
+func lastModifiedCacheKey(repo, commitID string, fileInfo *pfsClient.FileInfo) string {
+	return fmt.Sprintf("%s@%s:%s:%x", repo, commitID, fileInfo.File.Path, fileInfo.Hash)
+}
+
+// commitModTime returns the time at which the content of a commit was written,
+// which is when it was finished, or when it was started if it's still open
+func commitModTime(commitInfo *pfsClient.CommitInfo) (time.Time, error) {
+	if commitInfo.Finished != nil {
+		return types.TimestampFromProto(commitInfo.Finished)
+	}
+	return types.TimestampFromProto(commitInfo.Started)
+}
+
+// resolveVersion points `bucket` at the commit referenced by `version`, which
+// must be on the bucket's branch
+func resolveVersion(pc *client.APIClient, r *http.Request, bucket *Bucket, version string) error {
+	commitInfo, err := pc.InspectCommit(bucket.Repo, version)
+	if err != nil {
+		return maybeNotFoundError(r, err)
+	}
+	if commitInfo.Branch.Name != bucket.Commit {
+		return s2.NoSuchVersionError(r)
+	}
+	bucket.Commit = commitInfo.Commit.ID
+	return nil
+}
+
 func (c *controller) CopyObject(r *http.Request, srcBucketName, srcFile string, srcObj *s2.GetObjectResult, destBucketName, destFile string) (string, error) {
 	c.logger.Tracef("CopyObject: srcBucketName=%+v, srcFile=%+v, srcObj=%+v, destBucketName=%+v, destFile=%+v", srcBucketName, srcFile, srcObj, destBucketName, destFile)
 
@@ -1,12 +1,14 @@
 package s3
 
 import (
+	"encoding/xml"
 	"fmt"
 	stdlog "log"
 	"net/http"
 	"time"
 
 	"github.com/gorilla/mux"
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/pachyderm/pachyderm/src/client"
 
 	"github.com/pachyderm/s2"
@@ -29,6 +31,19 @@ const (
 
 	// The S3 location served back
 	globalLocation = "PACHYDERM"
+
+	// The maximum number of keys returned in listings, as in s2
+	defaultMaxKeys = 1000
+
+	// The number of object histories cached for listing object versions
+	versionsCacheSize = 64
+
+	// The number of files whose last modification is cached
+	lastModifiedCacheSize = 10000
+
+	// The number of ancestor commits that are read to find the commit in
+	// which a file was last modified
+	lastModifiedWalkLimit = 100
 )
 
 // The S3 user associated with all PFS content
@@ -47,6 +62,13 @@ type controller struct {
 	driver Driver
 
 	clientFactory ClientFactory
+
+	// versionsCache holds the object histories computed to list object
+	// versions, keyed by the commit that they were computed from
+	versionsCache *lru.Cache
+	// lastModifiedCache holds the commits in which files' content was
+	// written, keyed by a later commit with the same content
+	lastModifiedCache *lru.Cache
 }
 
 // requestPachClient uses the clientFactory to construct a request-scoped
@@ -95,12 +117,23 @@ func Server(port uint16, driver Driver, clientFactory ClientFactory) (*http.Serv
 		"source": "s3gateway",
 	})
 
+	versionsCache, err := lru.New(versionsCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	lastModifiedCache, err := lru.New(lastModifiedCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
 	c := &controller{
-		logger:          logger,
-		repo:            multipartRepo,
-		maxAllowedParts: maxAllowedParts,
-		driver:          driver,
-		clientFactory:   clientFactory,
+		logger:            logger,
+		repo:              multipartRepo,
+		maxAllowedParts:   maxAllowedParts,
+		driver:            driver,
+		clientFactory:     clientFactory,
+		versionsCache:     versionsCache,
+		lastModifiedCache: lastModifiedCache,
 	}
 
 	s3Server := s2.NewS2(logger, maxRequestBodyLength, readBodyTimeout)
@@ -109,7 +142,16 @@ func Server(port uint16, driver Driver, clientFactory ClientFactory) (*http.Serv
 	s3Server.Bucket = c
 	s3Server.Object = c
 	s3Server.Multipart = c
-	router := s3Server.Router()
+
+	// s2 doesn't support object tagging, and its version listings can't be
+	// paged through, so those endpoints are served directly; everything else
+	// is delegated to s2
+	router := mux.NewRouter()
+	router.Path(`/{bucket:[a-zA-Z0-9\-_\.]{1,255}}/{key:.+}`).Methods("GET").Queries("tagging", "").Handler(c.authMiddleware(http.HandlerFunc(c.getObjectTagging)))
+	for _, path := range []string{`/{bucket:[a-zA-Z0-9\-_\.]{1,255}}`, `/{bucket:[a-zA-Z0-9\-_\.]{1,255}}/`} {
+		router.Path(path).Methods("GET").Queries("versions", "").Handler(c.authMiddleware(http.HandlerFunc(c.listObjectVersionsHandler)))
+	}
+	router.PathPrefix("/").Handler(s3Server.Router())
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", port),
@@ -126,3 +168,15 @@ func Server(port uint16, driver Driver, clientFactory ClientFactory) (*http.Serv
 
 	return server, nil
 }
+
+// writeXML writes an XML response body, for endpoints that s2 doesn't serve
+func writeXML(logger *logrus.Entry, w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(code)
+	fmt.Fprint(w, xml.Header)
+	if err := xml.NewEncoder(w).Encode(v); err != nil {
+		// just log a message since a response has already been partially
+		// written
+		logger.Errorf("could not encode xml response: %v", err)
+	}
+}
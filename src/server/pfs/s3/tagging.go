@@ -0,0 +1,104 @@
+package s3
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pachyderm/s2"
+)
+
+// tagging is the response body of a GetObjectTagging call
+type tagging struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ Tagging"`
+	TagSet  []tag    `xml:"TagSet>Tag"`
+}
+
+// tag is a single S3 object tag
+type tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// getObjectTaggingResult is the result of a GetObjectTagging call
+type getObjectTaggingResult struct {
+	// Tags are the object's tags
+	Tags []tag
+	// Version is the version of the object that the tags describe
+	Version string
+}
+
+// GetObjectTagging exposes PFS metadata about the commit an object was read
+// from as read-only S3 tags. s2 doesn't support object tagging, so this
+// isn't part of any s2 controller interface.
+func (c *controller) GetObjectTagging(r *http.Request, bucketName, file, version string) (*getObjectTaggingResult, error) {
+	c.logger.Debugf("GetObjectTagging: bucketName=%+v, file=%+v, version=%+v", bucketName, file, version)
+
+	pc, err := c.requestClient(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.HasSuffix(file, "/") {
+		return nil, invalidFilePathError(r)
+	}
+
+	bucket, err := c.driver.bucket(pc, r, bucketName)
+	if err != nil {
+		return nil, err
+	}
+	bucketCaps, err := c.driver.bucketCapabilities(pc, r, bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !bucketCaps.readable {
+		return nil, s2.NoSuchKeyError(r)
+	}
+
+	if bucketCaps.historicVersions && version != "" {
+		if err := resolveVersion(pc, r, bucket, version); err != nil {
+			return nil, err
+		}
+	}
+
+	fileInfo, err := pc.InspectFile(bucket.Repo, bucket.Commit, file)
+	if err != nil {
+		return nil, maybeNotFoundError(r, err)
+	}
+	// the tags describe the commit that wrote the file's current content,
+	// which is the object's version
+	commitInfo, err := c.lastModified(pc, fileInfo)
+	if err != nil {
+		return nil, maybeNotFoundError(r, err)
+	}
+
+	tags := []tag{
+		{Key: "pachyderm-commit", Value: commitInfo.Commit.ID},
+	}
+	if commitInfo.Branch != nil {
+		tags = append(tags, tag{Key: "pachyderm-branch", Value: commitInfo.Branch.Name})
+	}
+	if commitInfo.Origin != nil {
+		tags = append(tags, tag{Key: "pachyderm-origin", Value: commitInfo.Origin.Kind.String()})
+	}
+
+	return &getObjectTaggingResult{
+		Tags:    tags,
+		Version: commitInfo.Commit.ID,
+	}, nil
+}
+
+func (c *controller) getObjectTagging(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	result, err := c.GetObjectTagging(r, vars["bucket"], vars["key"], r.FormValue("versionId"))
+	if err != nil {
+		s2.WriteError(c.logger, w, r, err)
+		return
+	}
+
+	if result.Version != "" {
+		w.Header().Set("x-amz-version-id", result.Version)
+	}
+	writeXML(c.logger, w, http.StatusOK, tagging{TagSet: result.Tags})
+}
@@ -14,6 +14,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
 	minio "github.com/minio/minio-go"
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
@@ -131,6 +135,21 @@ func fileHash(t *testing.T, name string) (int64, []byte) {
 	return fi.Size(), hashSum
 }
 
+// awsClient creates an AWS SDK S3 client that talks to the s3gateway
+// instance running on pachd. This is used for S3 features that minio-go
+// doesn't support, such as listing object versions.
+func awsClient(t *testing.T) *s3.S3 {
+	t.Helper()
+	sess, err := session.NewSession(&aws.Config{
+		Credentials:      credentials.AnonymousCredentials,
+		Endpoint:         aws.String("http://127.0.0.1:30600"),
+		Region:           aws.String("us-east-1"),
+		S3ForcePathStyle: aws.Bool(true),
+	})
+	require.NoError(t, err)
+	return s3.New(sess)
+}
+
 func testRunner(t *testing.T, group string, driver Driver, runner func(t *testing.T, pachClient *client.APIClient, minioClient *minio.Client)) {
 	server, err := Server(0, driver, client.NewForTest)
 	require.NoError(t, err)
@@ -237,9 +237,9 @@ func (a *apiServer) FinishCommitInTransaction(
 	request *pfs.FinishCommitRequest,
 ) error {
 	if request.Trees != nil {
-		return a.driver.finishOutputCommit(txnCtx, request.Commit, request.Trees, request.Datums, request.SizeBytes)
+		return a.driver.finishOutputCommit(txnCtx, request.Commit, request.Trees, request.Datums, request.SizeBytes, request.Provenance)
 	}
-	return a.driver.finishCommit(txnCtx, request.Commit, request.Tree, request.Empty, request.Description)
+	return a.driver.finishCommit(txnCtx, request.Commit, request.Tree, request.Empty, request.Description, request.Provenance)
 }
 
 // FinishCommit implements the protobuf pfs.FinishCommit RPC
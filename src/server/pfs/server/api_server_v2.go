@@ -59,7 +59,7 @@ func (a *apiServerV2) FinishCommitInTransaction(txnCtx *txnenv.TransactionContex
 		if request.Empty {
 			request.Description += pfs.EmptyStr
 		}
-		return a.driver.finishCommitV2(txnCtx, request.Commit, request.Description)
+		return a.driver.finishCommitV2(txnCtx, request.Commit, request.Description, request.Provenance)
 	})
 }
 
@@ -1547,7 +1547,7 @@ func (d *driver) makeCommit(
 	return newCommit, nil
 }
 
-func (d *driver) finishCommit(txnCtx *txnenv.TransactionContext, commit *pfs.Commit, tree *pfs.Object, empty bool, description string) (retErr error) {
+func (d *driver) finishCommit(txnCtx *txnenv.TransactionContext, commit *pfs.Commit, tree *pfs.Object, empty bool, description string, provenance []*pfs.CommitProvenance) (retErr error) {
 	// Validate arguments
 	if commit == nil {
 		return errors.New("commit cannot be nil")
@@ -1621,6 +1621,9 @@ func (d *driver) finishCommit(txnCtx *txnenv.TransactionContext, commit *pfs.Com
 		commitInfo.SizeBytes = uint64(finishedTree.FSSize())
 	}
 	commitInfo.Finished = types.TimestampNow()
+	if err := d.addCommitProvenance(txnCtx, commitInfo, provenance); err != nil {
+		return err
+	}
 	if err := d.updateProvenanceProgress(txnCtx, !empty, commitInfo); err != nil {
 		return err
 	}
@@ -1639,7 +1642,7 @@ func (d *driver) finishCommit(txnCtx *txnenv.TransactionContext, commit *pfs.Com
 	return nil
 }
 
-func (d *driver) finishOutputCommit(txnCtx *txnenv.TransactionContext, commit *pfs.Commit, trees []*pfs.Object, datums *pfs.Object, size uint64) (retErr error) {
+func (d *driver) finishOutputCommit(txnCtx *txnenv.TransactionContext, commit *pfs.Commit, trees []*pfs.Object, datums *pfs.Object, size uint64, provenance []*pfs.CommitProvenance) (retErr error) {
 	if err := authserver.CheckIsAuthorizedInTransaction(txnCtx, commit.Repo, auth.Scope_WRITER); err != nil {
 		return err
 	}
@@ -1654,6 +1657,9 @@ func (d *driver) finishOutputCommit(txnCtx *txnenv.TransactionContext, commit *p
 	commitInfo.Datums = datums
 	commitInfo.SizeBytes = size
 	commitInfo.Finished = types.TimestampNow()
+	if err := d.addCommitProvenance(txnCtx, commitInfo, provenance); err != nil {
+		return err
+	}
 	if err := d.updateProvenanceProgress(txnCtx, true, commitInfo); err != nil {
 		return err
 	}
@@ -1672,6 +1678,51 @@ func (d *driver) finishOutputCommit(txnCtx *txnenv.TransactionContext, commit *p
 	return nil
 }
 
+// addCommitProvenance adds each commit in 'provenance', and the provenance of
+// each of those commits, to the provenance of 'commitInfo', which is being
+// finished, and adds 'commitInfo' to their subvenance. The added commits
+// aren't the heads of their branches (PPS adds the older commits in its
+// input windows), so each is keyed by its own ID as branch name, as
+// resolveCommitProvenance does for commits that aren't branch heads, and the
+// head of each branch in 'commitInfo's provenance is unchanged.
+func (d *driver) addCommitProvenance(txnCtx *txnenv.TransactionContext, commitInfo *pfs.CommitInfo, provenance []*pfs.CommitProvenance) error {
+	if len(provenance) == 0 {
+		return nil
+	}
+	key := path.Join
+	inProvenance := make(map[string]bool)
+	for _, prov := range commitInfo.Provenance {
+		inProvenance[key(prov.Commit.Repo.Name, prov.Commit.ID)] = true
+	}
+	add := func(commit *pfs.Commit) error {
+		if inProvenance[key(commit.Repo.Name, commit.ID)] {
+			return nil
+		}
+		inProvenance[key(commit.Repo.Name, commit.ID)] = true
+		commitInfo.Provenance = append(commitInfo.Provenance, client.NewCommitProvenance(commit.Repo.Name, commit.ID, commit.ID))
+		provCommitInfo := &pfs.CommitInfo{}
+		return d.commits(commit.Repo.Name).ReadWrite(txnCtx.Stm).Update(commit.ID, provCommitInfo, func() error {
+			d.appendSubvenance(provCommitInfo, commitInfo)
+			return nil
+		})
+	}
+	for _, prov := range provenance {
+		provCommitInfo, err := d.resolveCommit(txnCtx.Stm, prov.Commit)
+		if err != nil {
+			return err
+		}
+		if err := add(provCommitInfo.Commit); err != nil {
+			return err
+		}
+		for _, provProv := range provCommitInfo.Provenance {
+			if err := add(provProv.Commit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (d *driver) updateProvenanceProgress(txnCtx *txnenv.TransactionContext, success bool, ci *pfs.CommitInfo) error {
 	if d.env.DisableCommitProgressCounter {
 		return nil
@@ -118,7 +118,7 @@ func newDB() (db *sqlx.DB, retErr error) {
 	})
 }
 
-func (d *driverV2) finishCommitV2(txnCtx *txnenv.TransactionContext, commit *pfs.Commit, description string) error {
+func (d *driverV2) finishCommitV2(txnCtx *txnenv.TransactionContext, commit *pfs.Commit, description string, provenance []*pfs.CommitProvenance) error {
 	commitInfo, err := d.resolveCommit(txnCtx.Stm, commit)
 	if err != nil {
 		return err
@@ -162,6 +162,9 @@ func (d *driverV2) finishCommitV2(txnCtx *txnenv.TransactionContext, commit *pfs
 		}
 		commitInfo.SizeBytes = uint64(compactRes.OutputSize)
 		commitInfo.Finished = types.TimestampNow()
+		if err := d.addCommitProvenance(txnCtx, commitInfo, provenance); err != nil {
+			return err
+		}
 		return d.writeFinishedCommit(txnCtx.Stm, commit, commitInfo)
 	})
 }
@@ -203,7 +206,7 @@ func (d *driverV2) oneOffFileOperation(ctx context.Context, repo, branch string,
 		}
 		defer func() {
 			if retErr == nil {
-				retErr = d.finishCommitV2(txnCtx, commit, "")
+				retErr = d.finishCommitV2(txnCtx, commit, "", nil)
 			}
 		}()
 		return d.withCommitWriter(txnCtx.ClientContext, commit, cb)
@@ -6793,6 +6793,39 @@ func TestTrigger(t *testing.T) {
 			require.NoError(t, err)
 			require.NotEqual(t, head, bi.Head.ID)
 		})
+		t.Run("SkipMissed", func(t *testing.T) {
+			require.NoError(t, c.CreateRepo("skip"))
+			require.NoError(t, c.CreateBranchTrigger("skip", "trigger", "", &pfs.Trigger{
+				Branch:      "master",
+				All:         true,
+				CronSpec:    "* * * * *",
+				Commits:     2,
+				CronCatchUp: pfs.CronCatchUp_CRON_SKIP_MISSED,
+			}))
+			// The first commit follows a tick (since epoch), but doesn't
+			// trigger because there's only one commit
+			_, err := c.PutFile("skip", "master", "file1", strings.NewReader("foo"))
+			require.NoError(t, err)
+			bi, err := c.InspectBranch("skip", "trigger")
+			require.NoError(t, err)
+			require.Nil(t, bi.Head)
+
+			// The second commit has enough commits, but the tick was skipped,
+			// as the first commit followed it
+			_, err = c.PutFile("skip", "master", "file2", strings.NewReader("bar"))
+			require.NoError(t, err)
+			bi, err = c.InspectBranch("skip", "trigger")
+			require.NoError(t, err)
+			require.Nil(t, bi.Head)
+
+			time.Sleep(time.Minute)
+			// The first commit after the next tick triggers
+			_, err = c.PutFile("skip", "master", "file3", strings.NewReader("fizz"))
+			require.NoError(t, err)
+			bi, err = c.InspectBranch("skip", "trigger")
+			require.NoError(t, err)
+			require.NotNil(t, bi.Head)
+		})
 		t.Run("Chain", func(t *testing.T) {
 			// a triggers b which triggers c
 			require.NoError(t, c.CreateRepo("chain"))
@@ -6939,6 +6972,37 @@ func TestTriggerValidation(t *testing.T) {
 			Branch:   "master",
 			CronSpec: "this is not a cron spec",
 		}))
+		// CronTimezone isn't a time zone
+		require.YesError(t, c.CreateBranchTrigger("repo", "trigger", "", &pfs.Trigger{
+			Branch:       "master",
+			CronSpec:     "0 9 * * *",
+			CronTimezone: "Mars/Olympus_Mons",
+		}))
+		// Can't have negative cron jitter
+		require.YesError(t, c.CreateBranchTrigger("repo", "trigger", "", &pfs.Trigger{
+			Branch:     "master",
+			CronSpec:   "0 9 * * *",
+			CronJitter: types.DurationProto(-time.Minute),
+		}))
+		// Can't have a cron time zone without a cron spec
+		require.YesError(t, c.CreateBranchTrigger("repo", "trigger", "", &pfs.Trigger{
+			Branch:       "master",
+			Size_:        "1K",
+			CronTimezone: "America/New_York",
+		}))
+		// Can't have a cron catch-up policy without a cron spec
+		require.YesError(t, c.CreateBranchTrigger("repo", "trigger", "", &pfs.Trigger{
+			Branch:      "master",
+			Size_:       "1K",
+			CronCatchUp: pfs.CronCatchUp_CRON_SKIP_MISSED,
+		}))
+		require.NoError(t, c.CreateBranchTrigger("repo", "trigger", "", &pfs.Trigger{
+			Branch:       "master",
+			CronSpec:     "0 9 * * *",
+			CronTimezone: "America/New_York",
+			CronJitter:   types.DurationProto(time.Minute),
+			CronCatchUp:  pfs.CronCatchUp_CRON_SKIP_MISSED,
+		}))
 		// Can't use a trigger and provenance together
 		require.NoError(t, c.CreateRepo("in"))
 		_, err := c.PfsAPIClient.CreateBranch(c.Ctx(),
@@ -5,12 +5,12 @@ import (
 
 	units "github.com/docker/go-units"
 	"github.com/gogo/protobuf/types"
-	"github.com/robfig/cron"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/cronutil"
 	txnenv "github.com/pachyderm/pachyderm/src/server/pkg/transactionenv"
 )
 
@@ -67,7 +67,7 @@ func (d *driver) triggerCommit(
 						return err
 					}
 				}
-				triggered, err := d.isTriggered(txnCtx, bi.Trigger, oldHead, newHead)
+				triggered, err := d.isTriggered(txnCtx, bi.Branch, bi.Trigger, oldHead, newHead)
 				if err != nil {
 					return err
 				}
@@ -95,7 +95,7 @@ func (d *driver) triggerCommit(
 
 // isTriggered checks to see if a branch should be updated from oldHead to
 // newHead based on a trigger.
-func (d *driver) isTriggered(txnCtx *txnenv.TransactionContext, t *pfs.Trigger, oldHead, newHead *pfs.CommitInfo) (bool, error) {
+func (d *driver) isTriggered(txnCtx *txnenv.TransactionContext, branch *pfs.Branch, t *pfs.Trigger, oldHead, newHead *pfs.CommitInfo) (bool, error) {
 	result := t.All
 	merge := func(cond bool) {
 		if t.All {
@@ -117,11 +117,10 @@ func (d *driver) isTriggered(txnCtx *txnenv.TransactionContext, t *pfs.Trigger,
 		merge(int64(newHead.SizeBytes-oldSize) >= size)
 	}
 	if t.CronSpec != "" {
-		// Shouldn't be possible to error here since we validate on ingress
-		schedule, err := cron.ParseStandard(t.CronSpec)
+		schedule, err := cronutil.ParseSchedule(t.CronSpec, t.CronTimezone, t.CronJitter, branchKey(branch))
 		if err != nil {
 			// Shouldn't be possible to error here since we validate on ingress
-			return false, errors.EnsureStack(err)
+			return false, err
 		}
 		var oldTime, newTime time.Time
 		if oldHead != nil && oldHead.Finished != nil {
@@ -130,13 +129,30 @@ func (d *driver) isTriggered(txnCtx *txnenv.TransactionContext, t *pfs.Trigger,
 				return false, errors.EnsureStack(err)
 			}
 		}
+		if t.CronCatchUp == pfs.CronCatchUp_CRON_SKIP_MISSED && newHead.ParentCommit != nil {
+			// Only ticks that were due after the previous commit count, so a
+			// tick that the previous commit didn't trigger on is skipped
+			parent, err := d.inspectCommit(txnCtx.Client, newHead.ParentCommit, pfs.CommitState_STARTED)
+			if err != nil {
+				return false, err
+			}
+			if parent.Finished != nil {
+				parentTime, err := types.TimestampFromProto(parent.Finished)
+				if err != nil {
+					return false, errors.EnsureStack(err)
+				}
+				if parentTime.After(oldTime) {
+					oldTime = parentTime
+				}
+			}
+		}
 		if newHead.Finished != nil {
 			newTime, err = types.TimestampFromProto(newHead.Finished)
 			if err != nil {
 				return false, errors.EnsureStack(err)
 			}
 		}
-		merge(schedule.Next(oldTime).Before(newTime))
+		merge(schedule.Due(schedule.NextDue(oldTime)).Before(newTime))
 	}
 	if t.Commits != 0 {
 		ci := newHead
@@ -166,9 +182,15 @@ func (d *driver) validateTrigger(txnCtx *txnenv.TransactionContext, branch *pfs.
 	if trigger.Branch == "" {
 		return errors.Errorf("triggers must specify a branch to trigger on")
 	}
-	if _, err := cron.ParseStandard(trigger.CronSpec); trigger.CronSpec != "" && err != nil {
+	if _, err := cronutil.ParseSchedule(trigger.CronSpec, trigger.CronTimezone, trigger.CronJitter, branchKey(branch)); trigger.CronSpec != "" && err != nil {
 		return errors.Wrapf(err, "invalid trigger cron spec")
 	}
+	if (trigger.CronTimezone != "" || trigger.CronJitter != nil || trigger.CronCatchUp != pfs.CronCatchUp_CRON_BACKFILL) && trigger.CronSpec == "" {
+		return errors.Errorf("trigger cron timezone, jitter and catch-up require a cron spec")
+	}
+	if _, ok := pfs.CronCatchUp_name[int32(trigger.CronCatchUp)]; !ok {
+		return errors.Errorf("unknown trigger cron catch-up %d", trigger.CronCatchUp)
+	}
 	if _, err := units.FromHumanSize(trigger.Size_); trigger.Size_ != "" && err != nil {
 		return errors.Wrapf(err, "invalid trigger size")
 	}
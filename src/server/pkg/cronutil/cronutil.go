@@ -0,0 +1,90 @@
+// Package cronutil contains helpers for the cron schedules used by PPS cron
+// inputs and PFS branch triggers.
+package cronutil
+
+import (
+	"hash/fnv"
+	"time"
+	// Embed the IANA time zone database, so that time zones can be loaded
+	// even if the container running pachd doesn't have one installed
+	_ "time/tzdata"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/robfig/cron"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+)
+
+// Schedule is a cron schedule that's evaluated in a particular time zone.
+// Each of its ticks takes effect, or is due, a fixed offset after it happens,
+// so that the processes that share a schedule don't all act at once.
+type Schedule struct {
+	schedule cron.Schedule
+	location *time.Location
+	offset   time.Duration
+}
+
+// Next returns the first tick of the schedule after 't', in the schedule's
+// time zone
+func (s *Schedule) Next(t time.Time) time.Time {
+	return s.schedule.Next(t.In(s.location))
+}
+
+// Due returns the time at which 'tick' takes effect
+func (s *Schedule) Due(tick time.Time) time.Time {
+	return tick.Add(s.offset)
+}
+
+// NextDue returns the first tick of the schedule that takes effect after 't'
+func (s *Schedule) NextDue(t time.Time) time.Time {
+	return s.Next(t.Add(-s.offset))
+}
+
+// ParseSchedule parses a standard cron spec, which is evaluated in the IANA
+// time zone 'timezone', e.g. "America/New_York". If 'timezone' is empty, the
+// spec is evaluated in UTC. If 'jitter' is set, each tick of the schedule is
+// due an offset of up to 'jitter' after it happens. The offset is fixed for
+// 'key', so that each cron input or branch sharing a schedule is delayed by a
+// different amount without any coordination.
+func ParseSchedule(spec string, timezone string, jitter *types.Duration, key string) (*Schedule, error) {
+	s, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	location := time.UTC
+	if timezone != "" {
+		if location, err = time.LoadLocation(timezone); err != nil {
+			return nil, errors.Wrapf(err, "invalid time zone %q", timezone)
+		}
+	}
+	d, err := parseJitter(jitter)
+	if err != nil {
+		return nil, err
+	}
+	return &Schedule{schedule: s, location: location, offset: offset(key, d)}, nil
+}
+
+// parseJitter converts 'jitter' to a duration, which is 0 if 'jitter' is nil
+func parseJitter(jitter *types.Duration) (time.Duration, error) {
+	if jitter == nil {
+		return 0, nil
+	}
+	d, err := types.DurationFromProto(jitter)
+	if err != nil {
+		return 0, errors.EnsureStack(err)
+	}
+	if d < 0 {
+		return 0, errors.Errorf("jitter cannot be negative")
+	}
+	return d, nil
+}
+
+// offset returns a duration in [0, jitter) that's fixed for 'key'
+func offset(key string, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	return time.Duration(h.Sum64() % uint64(jitter))
+}
@@ -0,0 +1,67 @@
+package cronutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+)
+
+func TestParseScheduleTimezone(t *testing.T) {
+	s, err := ParseSchedule("0 9 * * *", "", nil, "")
+	require.NoError(t, err)
+	start := time.Date(2020, 3, 7, 20, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2020, 3, 8, 9, 0, 0, 0, time.UTC), s.Next(start).UTC())
+
+	// 9am in New York is 14:00 UTC before daylight saving time starts on March
+	// 8th 2020, and 13:00 UTC after
+	s, err = ParseSchedule("0 9 * * *", "America/New_York", nil, "")
+	require.NoError(t, err)
+	next := s.Next(start)
+	require.Equal(t, time.Date(2020, 3, 8, 13, 0, 0, 0, time.UTC), next.UTC())
+	require.Equal(t, time.Date(2020, 3, 9, 13, 0, 0, 0, time.UTC), s.Next(next).UTC())
+	start = time.Date(2020, 3, 6, 20, 0, 0, 0, time.UTC)
+	require.Equal(t, time.Date(2020, 3, 7, 14, 0, 0, 0, time.UTC), s.Next(start).UTC())
+
+	_, err = ParseSchedule("0 9 * * *", "Mars/Olympus_Mons", nil, "")
+	require.YesError(t, err)
+	_, err = ParseSchedule("not a cron spec", "", nil, "")
+	require.YesError(t, err)
+}
+
+func TestParseJitter(t *testing.T) {
+	d, err := parseJitter(nil)
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), d)
+	d, err = parseJitter(types.DurationProto(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, d)
+	_, err = parseJitter(types.DurationProto(-time.Minute))
+	require.YesError(t, err)
+}
+
+func TestOffset(t *testing.T) {
+	require.Equal(t, time.Duration(0), offset("repo@branch", 0))
+	d := offset("repo@branch", time.Hour)
+	require.True(t, d >= 0 && d < time.Hour)
+	require.Equal(t, d, offset("repo@branch", time.Hour))
+	require.NotEqual(t, d, offset("repo@other", time.Hour))
+}
+
+func TestScheduleJitter(t *testing.T) {
+	s, err := ParseSchedule("0 9 * * *", "", types.DurationProto(time.Hour), "repo@branch")
+	require.NoError(t, err)
+	delay := offset("repo@branch", time.Hour)
+	require.True(t, delay > 0)
+	tick := time.Date(2020, 3, 8, 9, 0, 0, 0, time.UTC)
+	require.Equal(t, tick.Add(delay), s.Due(tick))
+
+	// A tick that has happened, but isn't due yet, is still the next one due
+	require.Equal(t, tick, s.NextDue(tick.Add(delay-time.Second)).UTC())
+	require.Equal(t, tick.AddDate(0, 0, 1), s.NextDue(tick.Add(delay)).UTC())
+
+	_, err = ParseSchedule("0 9 * * *", "", types.DurationProto(-time.Minute), "")
+	require.YesError(t, err)
+}
@@ -441,7 +441,8 @@ func Role(opts *AssetOpts) *rbacv1.Role {
 }
 
 // workerRole returns a Role bound to the Pachyderm worker service account
-// (used by workers to create an s3 gateway k8s service for each job)
+// (used by workers to create an s3 gateway k8s service for each job, and to
+// read the image digest of their own pod for the global datum cache)
 func workerRole(opts *AssetOpts) *rbacv1.Role {
 	return &rbacv1.Role{
 		TypeMeta: metav1.TypeMeta{
@@ -453,6 +454,10 @@ func workerRole(opts *AssetOpts) *rbacv1.Role {
 			APIGroups: []string{""},
 			Verbs:     []string{"get", "list", "update", "create", "delete"},
 			Resources: []string{"services"},
+		}, {
+			APIGroups: []string{""},
+			Verbs:     []string{"get"},
+			Resources: []string{"pods"},
 		}},
 	}
 }
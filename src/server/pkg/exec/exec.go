@@ -32,6 +32,7 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 )
@@ -461,6 +462,7 @@ func (c *Cmd) WaitIO(state *os.ProcessState, err error) (retErr error) {
 	if err != nil {
 		return err
 	} else if !state.Success() {
+		c.drainIO(failedIOTimeout)
 		return &ExitError{ProcessState: state}
 	}
 	for range c.goroutine {
@@ -472,6 +474,26 @@ func (c *Cmd) WaitIO(state *os.ProcessState, err error) (retErr error) {
 	return retErr
 }
 
+// failedIOTimeout is how long WaitIO waits for a failed process's output to be
+// copied out
+const failedIOTimeout = time.Second
+
+// drainIO waits up to timeout for the goroutines copying the process's output
+// to finish, so that what a failed process wrote just before exiting isn't
+// lost. Unlike WaitIO, it doesn't wait indefinitely, as the process's children
+// may still be holding its output open.
+func (c *Cmd) drainIO(timeout time.Duration) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for range c.goroutine {
+		select {
+		case <-c.errch:
+		case <-timer.C:
+			return
+		}
+	}
+}
+
 // Output runs the command and returns its standard output.
 // Any returned error will usually be of type *ExitError.
 // If c.Stderr was nil, Output populates ExitError.Stderr.
@@ -13,6 +13,8 @@ import (
 const (
 	pipelinesPrefix = "/pipelines"
 	jobsPrefix      = "/jobs"
+	templatesPrefix = "/templates"
+	queuesPrefix    = "/queues"
 )
 
 var (
@@ -50,3 +52,27 @@ func Jobs(etcdClient *etcd.Client, etcdPrefix string) col.Collection {
 		nil,
 	)
 }
+
+// Templates returns a Collection of pipeline templates
+func Templates(etcdClient *etcd.Client, etcdPrefix string) col.Collection {
+	return col.NewCollection(
+		etcdClient,
+		path.Join(etcdPrefix, templatesPrefix),
+		nil,
+		&pps.TemplateInfo{},
+		nil,
+		nil,
+	)
+}
+
+// JobQueues returns a Collection of job queues, keyed by queue name
+func JobQueues(etcdClient *etcd.Client, etcdPrefix string) col.Collection {
+	return col.NewCollection(
+		etcdClient,
+		path.Join(etcdPrefix, queuesPrefix),
+		nil,
+		&pps.JobQueue{},
+		nil,
+		nil,
+	)
+}
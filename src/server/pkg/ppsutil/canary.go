@@ -0,0 +1,67 @@
+package ppsutil
+
+import (
+	"sort"
+
+	pfsclient "github.com/pachyderm/pachyderm/src/client/pfs"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// DefaultCanaryMaxPaths is the number of paths that InspectCanary returns in
+// each list if the request doesn't set max_paths
+const DefaultCanaryMaxPaths = 100
+
+// DiffCanaryOutputs fills in the file counts and path lists of 'response'
+// from the result of a DiffFile of a canary's output (newFiles) against the
+// output of the pipeline that it's a canary of (oldFiles). 'total' is the
+// number of files in the canary's output. If the canary only samples some
+// datums, files that are only in the old output aren't counted as removed,
+// as they may be the outputs of datums that the canary didn't process.
+func DiffCanaryOutputs(response *ppsclient.InspectCanaryResponse, newFiles, oldFiles []*pfsclient.FileInfo, total int64, sampled bool, maxPaths int64) {
+	if maxPaths <= 0 {
+		maxPaths = DefaultCanaryMaxPaths
+	}
+	files := func(fileInfos []*pfsclient.FileInfo) map[string]bool {
+		result := make(map[string]bool)
+		for _, fileInfo := range fileInfos {
+			if fileInfo.FileType == pfsclient.FileType_FILE {
+				result[fileInfo.File.Path] = true
+			}
+		}
+		return result
+	}
+	newPaths, oldPaths := files(newFiles), files(oldFiles)
+	var added, removed, changed []string
+	for p := range newPaths {
+		if oldPaths[p] {
+			changed = append(changed, p)
+		} else {
+			added = append(added, p)
+		}
+	}
+	if !sampled {
+		for p := range oldPaths {
+			if !newPaths[p] {
+				removed = append(removed, p)
+			}
+		}
+	}
+	response.FilesAdded = int64(len(added))
+	response.FilesRemoved = int64(len(removed))
+	response.FilesChanged = int64(len(changed))
+	response.FilesUnchanged = total - response.FilesAdded - response.FilesChanged
+	if response.FilesUnchanged < 0 {
+		response.FilesUnchanged = 0
+	}
+	truncate := func(paths []string) []string {
+		sort.Strings(paths)
+		if int64(len(paths)) > maxPaths {
+			response.Truncated = true
+			return paths[:maxPaths]
+		}
+		return paths
+	}
+	response.Added = truncate(added)
+	response.Removed = truncate(removed)
+	response.Changed = truncate(changed)
+}
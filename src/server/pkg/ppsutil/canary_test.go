@@ -0,0 +1,48 @@
+package ppsutil
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	pfsclient "github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestDiffCanaryOutputs(t *testing.T) {
+	fileInfo := func(repo, path string, fileType pfsclient.FileType) *pfsclient.FileInfo {
+		return &pfsclient.FileInfo{File: client.NewFile(repo, "master", path), FileType: fileType}
+	}
+	newFiles := []*pfsclient.FileInfo{
+		fileInfo("edges-canary", "/a", pfsclient.FileType_FILE),
+		fileInfo("edges-canary", "/dir", pfsclient.FileType_DIR),
+		fileInfo("edges-canary", "/dir/c", pfsclient.FileType_FILE),
+		fileInfo("edges-canary", "/dir/b", pfsclient.FileType_FILE),
+	}
+	oldFiles := []*pfsclient.FileInfo{
+		fileInfo("edges", "/a", pfsclient.FileType_FILE),
+		fileInfo("edges", "/dir", pfsclient.FileType_DIR),
+		fileInfo("edges", "/d", pfsclient.FileType_FILE),
+	}
+
+	response := &ppsclient.InspectCanaryResponse{}
+	DiffCanaryOutputs(response, newFiles, oldFiles, 5, false, 0)
+	require.Equal(t, int64(2), response.FilesAdded)
+	require.Equal(t, int64(1), response.FilesRemoved)
+	require.Equal(t, int64(1), response.FilesChanged)
+	require.Equal(t, int64(2), response.FilesUnchanged)
+	require.Equal(t, []string{"/dir/b", "/dir/c"}, response.Added)
+	require.Equal(t, []string{"/d"}, response.Removed)
+	require.Equal(t, []string{"/a"}, response.Changed)
+	require.False(t, response.Truncated)
+
+	// Sampled canaries don't report removed files, and lists are cut to
+	// maxPaths
+	response = &ppsclient.InspectCanaryResponse{}
+	DiffCanaryOutputs(response, newFiles, oldFiles, 5, true, 1)
+	require.Equal(t, int64(0), response.FilesRemoved)
+	require.Equal(t, 0, len(response.Removed))
+	require.Equal(t, int64(2), response.FilesAdded)
+	require.Equal(t, []string{"/dir/b"}, response.Added)
+	require.True(t, response.Truncated)
+}
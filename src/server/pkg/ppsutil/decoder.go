@@ -22,8 +22,17 @@ type PipelineManifestReader struct {
 }
 
 // NewPipelineManifestReader creates a new manifest reader from a path.
-func NewPipelineManifestReader(path string) (result *PipelineManifestReader, retErr error) {
-	var pipelineBytes []byte
+func NewPipelineManifestReader(path string) (*PipelineManifestReader, error) {
+	pipelineBytes, err := ReadPipelineSpec(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewPipelineManifestReaderFromBytes(pipelineBytes), nil
+}
+
+// ReadPipelineSpec reads the contents of a pipeline spec from a path, which
+// may be a local file, a URL, or "-" for stdin.
+func ReadPipelineSpec(path string) (pipelineBytes []byte, retErr error) {
 	if path == "-" {
 		fmt.Print("Reading from stdin.\n")
 		var err error
@@ -52,6 +61,12 @@ func NewPipelineManifestReader(path string) (result *PipelineManifestReader, ret
 			return nil, err
 		}
 	}
+	return pipelineBytes, nil
+}
+
+// NewPipelineManifestReaderFromBytes creates a new manifest reader from the
+// contents of a pipeline spec, which may be JSON or YAML.
+func NewPipelineManifestReaderFromBytes(pipelineBytes []byte) *PipelineManifestReader {
 	// TODO(msteffen): if we can get the yaml decoder to handle leading tabs, as
 	// in pps/cmds/cmds_test.go, then we can get rid of this
 	idx := bytes.IndexFunc(pipelineBytes, func(r rune) bool {
@@ -60,11 +75,11 @@ func NewPipelineManifestReader(path string) (result *PipelineManifestReader, ret
 	if idx >= 0 && pipelineBytes[idx] == '{' {
 		return &PipelineManifestReader{
 			decoder: serde.NewJSONDecoder(bytes.NewReader(pipelineBytes)),
-		}, nil
+		}
 	}
 	return &PipelineManifestReader{
 		decoder: serde.NewYAMLDecoder(bytes.NewReader(pipelineBytes)),
-	}, nil
+	}
 }
 
 // NextCreatePipelineRequest gets the next request from the manifest reader.
@@ -0,0 +1,106 @@
+package ppsutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/gogo/protobuf/jsonpb"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// SpecDiff is a difference in one field of two pipeline specs. From is nil if
+// the field was added, and To is nil if it was removed.
+type SpecDiff struct {
+	Path string
+	From interface{}
+	To   interface{}
+}
+
+func (d *SpecDiff) String() string {
+	switch {
+	case d.From == nil:
+		return fmt.Sprintf("+ %s: %s", d.Path, diffValue(d.To))
+	case d.To == nil:
+		return fmt.Sprintf("- %s: %s", d.Path, diffValue(d.From))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", d.Path, diffValue(d.From), diffValue(d.To))
+	}
+}
+
+func diffValue(v interface{}) string {
+	text, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(text)
+}
+
+// DiffPipelineSpecs returns the fields that differ between two pipeline specs,
+// sorted by path. Paths use the field names of the JSON pipeline spec, such
+// as "transform.cmd[1]".
+func DiffPipelineSpecs(from, to *ppsclient.CreatePipelineRequest) ([]*SpecDiff, error) {
+	fromFields, err := specFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := specFields(to)
+	if err != nil {
+		return nil, err
+	}
+	var result []*SpecDiff
+	for path, fromValue := range fromFields {
+		toValue, ok := toFields[path]
+		if !ok {
+			result = append(result, &SpecDiff{Path: path, From: fromValue})
+		} else if diffValue(fromValue) != diffValue(toValue) {
+			result = append(result, &SpecDiff{Path: path, From: fromValue, To: toValue})
+		}
+	}
+	for path, toValue := range toFields {
+		if _, ok := fromFields[path]; !ok {
+			result = append(result, &SpecDiff{Path: path, To: toValue})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result, nil
+}
+
+// specFields flattens a pipeline spec into a map from the path of each of its
+// leaf fields to that field's value
+func specFields(spec *ppsclient.CreatePipelineRequest) (map[string]interface{}, error) {
+	text, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(spec)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var holder interface{}
+	if err := json.Unmarshal([]byte(text), &holder); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	result := make(map[string]interface{})
+	flattenSpec("", holder, result)
+	return result, nil
+}
+
+func flattenSpec(path string, value interface{}, result map[string]interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			flattenSpec(childPath, child, result)
+		}
+	case []interface{}:
+		for i, child := range v {
+			flattenSpec(fmt.Sprintf("%s[%d]", path, i), child, result)
+		}
+	default:
+		result[path] = value
+	}
+}
@@ -0,0 +1,45 @@
+package ppsutil
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestDiffPipelineSpecs(t *testing.T) {
+	from := &ppsclient.CreatePipelineRequest{
+		Pipeline: client.NewPipeline("edges"),
+		Transform: &ppsclient.Transform{
+			Image: "edges:1.0",
+			Cmd:   []string{"python3", "/edges.py"},
+		},
+		Egress: &ppsclient.Egress{URL: "s3://bucket/dir"},
+		Input:  client.NewPFSInput("images", "/*"),
+	}
+	to := &ppsclient.CreatePipelineRequest{
+		Pipeline: client.NewPipeline("edges"),
+		Transform: &ppsclient.Transform{
+			Image: "edges:1.1",
+			Cmd:   []string{"python3", "/edges.py"},
+		},
+		ParallelismSpec: &ppsclient.ParallelismSpec{Constant: 4},
+		Input:           client.NewPFSInput("images", "/*"),
+	}
+	diffs, err := DiffPipelineSpecs(from, to)
+	require.NoError(t, err)
+	var lines []string
+	for _, diff := range diffs {
+		lines = append(lines, diff.String())
+	}
+	require.Equal(t, []string{
+		`- egress.URL: "s3://bucket/dir"`,
+		`+ parallelism_spec.constant: "4"`,
+		`~ transform.image: "edges:1.0" -> "edges:1.1"`,
+	}, lines)
+
+	diffs, err = DiffPipelineSpecs(from, from)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(diffs))
+}
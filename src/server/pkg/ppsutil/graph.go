@@ -0,0 +1,297 @@
+package ppsutil
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/jsonpb"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/dag"
+)
+
+// graphBuilder accumulates the nodes and edges of a PipelineGraph, along with
+// each node's parents, which are used to sort the graph
+type graphBuilder struct {
+	graph     *ppsclient.PipelineGraph
+	nodes     map[string]*ppsclient.GraphNode
+	parents   map[string][]string
+	pipelines map[string]*ppsclient.PipelineInfo
+	// outputs maps the repos of the pipelines' named outputs to the pipeline and
+	// output that they belong to
+	outputs map[string][2]string
+}
+
+func (b *graphBuilder) addNode(node *ppsclient.GraphNode) {
+	if _, ok := b.nodes[node.Id]; ok {
+		return
+	}
+	b.nodes[node.Id] = node
+	b.graph.Nodes = append(b.graph.Nodes, node)
+	b.parents[node.Id] = nil
+}
+
+func (b *graphBuilder) addEdge(from, to, label string) {
+	b.graph.Edges = append(b.graph.Edges, &ppsclient.GraphEdge{From: from, To: to, Label: label})
+	b.parents[to] = append(b.parents[to], from)
+}
+
+// repoNode returns the ID of the node that produces the repo 'repo', which is
+// a pipeline node if 'repo' is the output repo of one of the graph's
+// pipelines, and a repo node otherwise. The repo node of a pipeline's named
+// output is connected to the pipeline.
+func (b *graphBuilder) repoNode(repo string) string {
+	if _, ok := b.pipelines[repo]; ok {
+		return pipelineNodeID(repo)
+	}
+	id := "repo:" + repo
+	if _, ok := b.nodes[id]; ok {
+		return id
+	}
+	b.addNode(&ppsclient.GraphNode{Id: id, Type: ppsclient.GraphNodeType_GRAPH_REPO, Label: repo})
+	if output, ok := b.outputs[repo]; ok {
+		b.addEdge(pipelineNodeID(output[0]), id, output[1])
+	}
+	return id
+}
+
+// addInput adds the nodes for 'input', which is at 'path' in the input of
+// 'pipeline', and returns the ID of the node that 'input' flows out of, along
+// with the label of the edge out of it
+func (b *graphBuilder) addInput(pipeline string, input *ppsclient.Input, path string) (string, string) {
+	var children []*ppsclient.Input
+	node := &ppsclient.GraphNode{Id: fmt.Sprintf("%s/input%s", pipelineNodeID(pipeline), path)}
+	switch {
+	case input.Pfs != nil:
+		name := input.Pfs.Name
+		if name == "" {
+			name = input.Pfs.Repo // as in setInputDefaults
+		}
+		label := fmt.Sprintf("%s:%s", name, input.Pfs.Glob)
+		if input.Pfs.Branch != "" && input.Pfs.Branch != "master" {
+			label = fmt.Sprintf("%s@%s:%s", name, input.Pfs.Branch, input.Pfs.Glob)
+		}
+		return b.repoNode(input.Pfs.Repo), label
+	case input.Cron != nil:
+		node.Type = ppsclient.GraphNodeType_GRAPH_CRON
+		node.Label = input.Cron.Spec
+		b.addNode(node)
+		return node.Id, input.Cron.Name
+	case input.Git != nil:
+		node.Type = ppsclient.GraphNodeType_GRAPH_GIT
+		node.Label = input.Git.URL
+		b.addNode(node)
+		return node.Id, input.Git.Name
+	case input.Cross != nil:
+		node.Type, node.Label, children = ppsclient.GraphNodeType_GRAPH_CROSS, "cross", input.Cross
+	case input.Join != nil:
+		node.Type, node.Label, children = ppsclient.GraphNodeType_GRAPH_JOIN, "join", input.Join
+	case input.Union != nil:
+		node.Type, node.Label, children = ppsclient.GraphNodeType_GRAPH_UNION, "union", input.Union
+	case input.Group != nil:
+		node.Type, node.Label, children = ppsclient.GraphNodeType_GRAPH_GROUP, "group", input.Group
+	}
+	b.addNode(node)
+	for i, child := range children {
+		from, label := b.addInput(pipeline, child, fmt.Sprintf("%s.%d", path, i))
+		b.addEdge(from, node.Id, label)
+	}
+	return node.Id, ""
+}
+
+func pipelineNodeID(pipeline string) string {
+	return "pipeline:" + pipeline
+}
+
+// NewPipelineGraph returns the graph of 'pipelineInfos', the repos that they
+// read from and the structure of each of their inputs. If 'pipelines' is set,
+// only those pipelines and the nodes upstream of them are included.
+func NewPipelineGraph(pipelineInfos []*ppsclient.PipelineInfo, pipelines []string) (*ppsclient.PipelineGraph, error) {
+	b := &graphBuilder{
+		graph:     &ppsclient.PipelineGraph{},
+		nodes:     make(map[string]*ppsclient.GraphNode),
+		parents:   make(map[string][]string),
+		pipelines: make(map[string]*ppsclient.PipelineInfo),
+		outputs:   make(map[string][2]string),
+	}
+	for _, pipelineInfo := range pipelineInfos {
+		b.pipelines[pipelineInfo.Pipeline.Name] = pipelineInfo
+		for _, output := range pipelineInfo.Outputs {
+			b.outputs[client.OutputRepo(pipelineInfo.Pipeline.Name, output)] = [2]string{pipelineInfo.Pipeline.Name, output}
+		}
+	}
+	for _, pipelineInfo := range pipelineInfos {
+		name := pipelineInfo.Pipeline.Name
+		b.addNode(&ppsclient.GraphNode{
+			Id:           pipelineNodeID(name),
+			Type:         ppsclient.GraphNodeType_GRAPH_PIPELINE,
+			Label:        name,
+			State:        pipelineInfo.State,
+			LastJobState: pipelineInfo.LastJobState,
+		})
+		if pipelineInfo.Input != nil {
+			from, label := b.addInput(name, pipelineInfo.Input, "")
+			b.addEdge(from, pipelineNodeID(name), label)
+		}
+	}
+
+	// Sort the nodes so that each comes after its parents, keeping the order in
+	// which they were added where possible. If 'pipelines' is set, only their
+	// ancestors are visited.
+	roots := make([]string, 0, len(b.graph.Nodes))
+	if len(pipelines) > 0 {
+		for _, pipeline := range pipelines {
+			if _, ok := b.pipelines[pipeline]; !ok {
+				return nil, errors.Errorf("pipeline %q not found", pipeline)
+			}
+			roots = append(roots, pipelineNodeID(pipeline))
+		}
+	} else {
+		for _, node := range b.graph.Nodes {
+			roots = append(roots, node.Id)
+		}
+	}
+	d := dag.NewDAG(b.parents)
+	var sorted []string
+	for _, root := range roots {
+		sorted = append(sorted, d.Ancestors(root, sorted)...)
+	}
+	result := &ppsclient.PipelineGraph{}
+	included := make(map[string]bool)
+	for _, id := range sorted {
+		included[id] = true
+		result.Nodes = append(result.Nodes, b.nodes[id])
+	}
+	for _, edge := range b.graph.Edges {
+		if included[edge.From] && included[edge.To] {
+			result.Edges = append(result.Edges, edge)
+		}
+	}
+	return result, nil
+}
+
+// DrawPipelineGraph renders 'graph' in 'format'
+func DrawPipelineGraph(graph *ppsclient.PipelineGraph, format ppsclient.GraphFormat) (string, error) {
+	switch format {
+	case ppsclient.GraphFormat_GRAPH_JSON:
+		text, err := (&jsonpb.Marshaler{EmitDefaults: true, Indent: "  ", OrigName: true}).MarshalToString(graph)
+		if err != nil {
+			return "", errors.EnsureStack(err)
+		}
+		return text + "\n", nil
+	case ppsclient.GraphFormat_GRAPH_DOT:
+		return drawDOT(graph), nil
+	case ppsclient.GraphFormat_GRAPH_MERMAID:
+		return drawMermaid(graph), nil
+	default:
+		return "", errors.Errorf("unrecognized graph format: %v", format)
+	}
+}
+
+// nodeStatus returns the status that's displayed under a pipeline node's
+// label, e.g. "running / success"
+func nodeStatus(node *ppsclient.GraphNode) string {
+	return fmt.Sprintf("%s / %s",
+		strings.ToLower(strings.TrimPrefix(node.State.String(), "PIPELINE_")),
+		strings.ToLower(strings.TrimPrefix(node.LastJobState.String(), "JOB_")))
+}
+
+// stateClass groups pipeline states by how they're styled
+func stateClass(state ppsclient.PipelineState) string {
+	switch state {
+	case ppsclient.PipelineState_PIPELINE_RUNNING:
+		return "running"
+	case ppsclient.PipelineState_PIPELINE_FAILURE, ppsclient.PipelineState_PIPELINE_CRASHING:
+		return "failed"
+	case ppsclient.PipelineState_PIPELINE_PAUSED, ppsclient.PipelineState_PIPELINE_STANDBY:
+		return "paused"
+	default:
+		return "starting"
+	}
+}
+
+var stateColors = map[string]string{
+	"running":  "#c8e6c9",
+	"failed":   "#ffcdd2",
+	"paused":   "#e0e0e0",
+	"starting": "#fff9c4",
+}
+
+func drawDOT(graph *ppsclient.PipelineGraph) string {
+	var buf bytes.Buffer
+	buf.WriteString("digraph pipelines {\n  rankdir=LR;\n")
+	for _, node := range graph.Nodes {
+		var attrs string
+		switch node.Type {
+		case ppsclient.GraphNodeType_GRAPH_REPO:
+			attrs = fmt.Sprintf("label=%s, shape=cylinder", strconv.Quote(node.Label))
+		case ppsclient.GraphNodeType_GRAPH_PIPELINE:
+			attrs = fmt.Sprintf("label=%s, shape=box, style=filled, fillcolor=%q",
+				strconv.Quote(node.Label+"\n"+nodeStatus(node)), stateColors[stateClass(node.State)])
+		case ppsclient.GraphNodeType_GRAPH_CRON, ppsclient.GraphNodeType_GRAPH_GIT:
+			attrs = fmt.Sprintf("label=%s, shape=note", strconv.Quote(node.Label))
+		default:
+			attrs = fmt.Sprintf("label=%s, shape=diamond", strconv.Quote(node.Label))
+		}
+		fmt.Fprintf(&buf, "  %s [%s];\n", strconv.Quote(node.Id), attrs)
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(&buf, "  %s -> %s", strconv.Quote(edge.From), strconv.Quote(edge.To))
+		if edge.Label != "" {
+			fmt.Fprintf(&buf, " [label=%s]", strconv.Quote(edge.Label))
+		}
+		buf.WriteString(";\n")
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// mermaidText escapes 's' for use in a quoted Mermaid label
+func mermaidText(s string) string {
+	return strings.NewReplacer(`"`, "#quot;", "\n", "<br/>").Replace(s)
+}
+
+func drawMermaid(graph *ppsclient.PipelineGraph) string {
+	var buf bytes.Buffer
+	buf.WriteString("graph LR\n")
+	// Node IDs are replaced with n0, n1, etc, as Mermaid IDs can't contain most
+	// punctuation
+	ids := make(map[string]string)
+	classes := make(map[string][]string)
+	for i, node := range graph.Nodes {
+		id := fmt.Sprintf("n%d", i)
+		ids[node.Id] = id
+		label := mermaidText(node.Label)
+		switch node.Type {
+		case ppsclient.GraphNodeType_GRAPH_REPO:
+			fmt.Fprintf(&buf, "  %s[(\"%s\")]\n", id, label)
+		case ppsclient.GraphNodeType_GRAPH_PIPELINE:
+			fmt.Fprintf(&buf, "  %s[\"%s<br/>%s\"]\n", id, label, nodeStatus(node))
+			class := stateClass(node.State)
+			classes[class] = append(classes[class], id)
+		case ppsclient.GraphNodeType_GRAPH_CRON, ppsclient.GraphNodeType_GRAPH_GIT:
+			fmt.Fprintf(&buf, "  %s([\"%s\"])\n", id, label)
+		default:
+			fmt.Fprintf(&buf, "  %s{{\"%s\"}}\n", id, label)
+		}
+	}
+	for _, edge := range graph.Edges {
+		if edge.Label != "" {
+			fmt.Fprintf(&buf, "  %s -->|\"%s\"| %s\n", ids[edge.From], mermaidText(edge.Label), ids[edge.To])
+		} else {
+			fmt.Fprintf(&buf, "  %s --> %s\n", ids[edge.From], ids[edge.To])
+		}
+	}
+	for _, class := range []string{"running", "failed", "paused", "starting"} {
+		if len(classes[class]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "  classDef %s fill:%s\n", class, stateColors[class])
+		fmt.Fprintf(&buf, "  class %s %s\n", strings.Join(classes[class], ","), class)
+	}
+	return buf.String()
+}
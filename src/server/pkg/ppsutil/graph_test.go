@@ -0,0 +1,90 @@
+package ppsutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestPipelineGraph(t *testing.T) {
+	// 'montage' is listed first, to check that the graph is sorted
+	pipelineInfos := []*pps.PipelineInfo{
+		{
+			Pipeline: client.NewPipeline("montage"),
+			Input: client.NewCrossInput(
+				client.NewPFSInput("images", "/"),
+				client.NewPFSInput("edges", "/"),
+			),
+			State:        pps.PipelineState_PIPELINE_RUNNING,
+			LastJobState: pps.JobState_JOB_FAILURE,
+		},
+		{
+			Pipeline:     client.NewPipeline("edges"),
+			Input:        client.NewPFSInput("images", "/*"),
+			State:        pps.PipelineState_PIPELINE_RUNNING,
+			LastJobState: pps.JobState_JOB_SUCCESS,
+		},
+		{
+			Pipeline: client.NewPipeline("unrelated"),
+			Input:    client.NewPFSInput("other", "/*"),
+		},
+	}
+
+	graph, err := NewPipelineGraph(pipelineInfos, nil)
+	require.NoError(t, err)
+	var ids []string
+	for _, node := range graph.Nodes {
+		ids = append(ids, node.Id)
+	}
+	require.Equal(t, []string{
+		"repo:images",
+		"pipeline:edges",
+		"pipeline:montage/input",
+		"pipeline:montage",
+		"repo:other",
+		"pipeline:unrelated",
+	}, ids)
+	require.Equal(t, 5, len(graph.Edges))
+	require.Equal(t, &pps.GraphEdge{From: "pipeline:edges", To: "pipeline:montage/input", Label: "edges:/"}, graph.Edges[1])
+
+	// Only the requested pipelines and their ancestors are drawn
+	graph, err = NewPipelineGraph(pipelineInfos, []string{"edges"})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(graph.Nodes))
+	require.Equal(t, 1, len(graph.Edges))
+	_, err = NewPipelineGraph(pipelineInfos, []string{"missing"})
+	require.YesError(t, err)
+
+	// A pipeline that reads a named output is downstream of the output's
+	// pipeline
+	pipelineInfos[1].Outputs = []string{"rejected"}
+	graph, err = NewPipelineGraph(append(pipelineInfos, &pps.PipelineInfo{
+		Pipeline: client.NewPipeline("review"),
+		Input:    client.NewPFSInput("edges_rejected", "/*"),
+	}), []string{"review"})
+	require.NoError(t, err)
+	require.Equal(t, 4, len(graph.Nodes))
+	require.Equal(t, &pps.GraphEdge{From: "pipeline:edges", To: "repo:edges_rejected", Label: "rejected"}, graph.Edges[1])
+	pipelineInfos[1].Outputs = nil
+
+	graph, err = NewPipelineGraph(pipelineInfos, []string{"montage"})
+	require.NoError(t, err)
+	dot, err := DrawPipelineGraph(graph, pps.GraphFormat_GRAPH_DOT)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(dot, "digraph pipelines {"))
+	require.True(t, strings.Contains(dot, `"pipeline:montage" [label="montage\nrunning / failure"`))
+	require.True(t, strings.Contains(dot, `"repo:images" -> "pipeline:edges" [label="images:/*"];`))
+
+	mermaid, err := DrawPipelineGraph(graph, pps.GraphFormat_GRAPH_MERMAID)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(mermaid, "graph LR\n"))
+	require.True(t, strings.Contains(mermaid, `n2{{"cross"}}`))
+	require.True(t, strings.Contains(mermaid, `n0 -->|"images:/*"| n1`))
+
+	json, err := DrawPipelineGraph(graph, pps.GraphFormat_GRAPH_JSON)
+	require.NoError(t, err)
+	require.True(t, strings.Contains(json, `"type": "GRAPH_CROSS"`))
+}
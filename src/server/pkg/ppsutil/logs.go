@@ -0,0 +1,87 @@
+package ppsutil
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// LogFilter matches log messages against the time range and regular
+// expression of a GetLogsRequest. The zero value of each field matches every
+// message.
+type LogFilter struct {
+	Since time.Time
+	Until time.Time
+	Grep  *regexp.Regexp
+}
+
+// NewLogFilter returns the LogFilter of 'request'
+func NewLogFilter(request *ppsclient.GetLogsRequest) (*LogFilter, error) {
+	f := &LogFilter{}
+	var err error
+	if request.Since != nil {
+		if f.Since, err = types.TimestampFromProto(request.Since); err != nil {
+			return nil, errors.Wrapf(err, "invalid since")
+		}
+	}
+	if request.Until != nil {
+		if f.Until, err = types.TimestampFromProto(request.Until); err != nil {
+			return nil, errors.Wrapf(err, "invalid until")
+		}
+	}
+	if !f.Since.IsZero() && !f.Until.IsZero() && !f.Since.Before(f.Until) {
+		return nil, errors.New("since must be before until")
+	}
+	if request.Grep != "" {
+		if f.Grep, err = regexp.Compile(request.Grep); err != nil {
+			return nil, errors.Wrapf(err, "invalid grep pattern")
+		}
+	}
+	return f, nil
+}
+
+// Match returns true if 'msg' was logged in the filter's time range, and its
+// message matches the filter's regular expression. Messages without a
+// timestamp only match filters without a time range.
+func (f *LogFilter) Match(msg *ppsclient.LogMessage) bool {
+	if !f.Since.IsZero() || !f.Until.IsZero() {
+		ts, err := types.TimestampFromProto(msg.Ts)
+		if err != nil {
+			return false
+		}
+		if !f.Since.IsZero() && ts.Before(f.Since) {
+			return false
+		}
+		if !f.Until.IsZero() && !ts.Before(f.Until) {
+			return false
+		}
+	}
+	return f.MatchMessage(msg.Message)
+}
+
+// MatchMessage returns true if 'message' matches the filter's regular
+// expression. It's used for log lines that aren't LogMessages, and so don't
+// have a timestamp.
+func (f *LogFilter) MatchMessage(message string) bool {
+	return f.Grep == nil || f.Grep.MatchString(message)
+}
+
+// Overlaps returns true if the job 'jobInfo', which may still be running, ran
+// during the filter's time range
+func (f *LogFilter) Overlaps(jobInfo *ppsclient.JobInfo) bool {
+	if !f.Until.IsZero() && jobInfo.Started != nil {
+		if started, err := types.TimestampFromProto(jobInfo.Started); err == nil && !started.Before(f.Until) {
+			return false
+		}
+	}
+	if !f.Since.IsZero() && jobInfo.Finished != nil {
+		if finished, err := types.TimestampFromProto(jobInfo.Finished); err == nil && finished.Before(f.Since) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,53 @@
+package ppsutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestLogFilter(t *testing.T) {
+	start := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *types.Timestamp {
+		ts, err := types.TimestampProto(start.Add(d))
+		require.NoError(t, err)
+		return ts
+	}
+	msg := func(d time.Duration, message string) *ppsclient.LogMessage {
+		return &ppsclient.LogMessage{Ts: at(d), Message: message}
+	}
+
+	// The zero filter matches everything, including messages without a
+	// timestamp
+	f, err := NewLogFilter(&ppsclient.GetLogsRequest{})
+	require.NoError(t, err)
+	require.True(t, f.Match(&ppsclient.LogMessage{Message: "no timestamp"}))
+
+	f, err = NewLogFilter(&ppsclient.GetLogsRequest{
+		Since: at(0),
+		Until: at(time.Hour),
+		Grep:  "error|panic",
+	})
+	require.NoError(t, err)
+	require.True(t, f.Match(msg(0, "error: file not found")))
+	require.True(t, f.Match(msg(30*time.Minute, "panic: oops")))
+	require.False(t, f.Match(msg(30*time.Minute, "processed datum")))
+	require.False(t, f.Match(msg(-time.Second, "error: too early")))
+	require.False(t, f.Match(msg(time.Hour, "error: too late")))
+	require.False(t, f.Match(&ppsclient.LogMessage{Message: "error: no timestamp"}))
+
+	// Jobs that overlap the time range are searched, including running jobs
+	require.True(t, f.Overlaps(&ppsclient.JobInfo{Started: at(-time.Hour), Finished: at(time.Minute)}))
+	require.True(t, f.Overlaps(&ppsclient.JobInfo{Started: at(-time.Hour)}))
+	require.False(t, f.Overlaps(&ppsclient.JobInfo{Started: at(-time.Hour), Finished: at(-time.Minute)}))
+	require.False(t, f.Overlaps(&ppsclient.JobInfo{Started: at(2 * time.Hour)}))
+
+	_, err = NewLogFilter(&ppsclient.GetLogsRequest{Since: at(time.Hour), Until: at(0)})
+	require.YesError(t, err)
+	_, err = NewLogFilter(&ppsclient.GetLogsRequest{Grep: "("})
+	require.YesError(t, err)
+}
@@ -0,0 +1,74 @@
+package ppsutil
+
+import (
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
+)
+
+// MaxPendingNotifications caps the number of undelivered events queued on a
+// pipeline, so that a target that can't be reached doesn't grow the
+// pipeline's EtcdPipelineInfo without bound. The oldest events are dropped
+// first.
+const MaxPendingNotifications = 100
+
+// jobNotificationEvents maps job states to the event fired when a job enters
+// them
+var jobNotificationEvents = map[pps.JobState]pps.NotificationEventType{
+	pps.JobState_JOB_STARTING: pps.NotificationEventType_NOTIFY_JOB_STARTED,
+	pps.JobState_JOB_SUCCESS:  pps.NotificationEventType_NOTIFY_JOB_SUCCESS,
+	pps.JobState_JOB_FAILURE:  pps.NotificationEventType_NOTIFY_JOB_FAILURE,
+	pps.JobState_JOB_KILLED:   pps.NotificationEventType_NOTIFY_JOB_KILLED,
+}
+
+// pipelineNotificationEvents maps pipeline states to the event fired when a
+// pipeline enters them
+var pipelineNotificationEvents = map[pps.PipelineState]pps.NotificationEventType{
+	pps.PipelineState_PIPELINE_CRASHING:   pps.NotificationEventType_NOTIFY_PIPELINE_CRASHING,
+	pps.PipelineState_PIPELINE_RESTARTING: pps.NotificationEventType_NOTIFY_PIPELINE_RESTARTING,
+}
+
+// Notifies returns true if 'notification' should be sent events of type 't'
+func Notifies(notification *pps.Notification, t pps.NotificationEventType) bool {
+	if len(notification.Events) == 0 {
+		return true
+	}
+	for _, e := range notification.Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// QueueNotification adds 'event' to the pending notifications of each of the
+// pipeline's notification targets that it should be sent to. It fills in the
+// event's ID and time. The caller is responsible for writing 'pipelinePtr'
+// back to etcd, in the same transaction as the state change that caused the
+// event, after which the PPS master delivers it.
+func QueueNotification(pipelinePtr *pps.EtcdPipelineInfo, event *pps.NotificationEvent) {
+	if len(pipelinePtr.Notifications) == 0 {
+		return
+	}
+	event.ID = uuid.NewWithoutDashes()
+	event.Time, _ = types.TimestampProto(time.Now())
+	for _, notification := range pipelinePtr.Notifications {
+		if !Notifies(notification, event.Type) {
+			continue
+		}
+		pipelinePtr.PendingNotifications = append(pipelinePtr.PendingNotifications,
+			&pps.PendingNotification{
+				ID:           uuid.NewWithoutDashes(),
+				Notification: notification,
+				Event:        event,
+			})
+	}
+	if over := len(pipelinePtr.PendingNotifications) - MaxPendingNotifications; over > 0 {
+		log.Warningf("dropping %d undelivered notifications for pipeline %q", over, event.Pipeline.Name)
+		pipelinePtr.PendingNotifications = pipelinePtr.PendingNotifications[over:]
+	}
+}
@@ -0,0 +1,55 @@
+package ppsutil
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestQueueNotification(t *testing.T) {
+	all := &pps.Notification{Repo: "events"}
+	failures := &pps.Notification{
+		Webhook: &pps.WebhookNotification{URL: "http://hooks.example.com"},
+		Events:  []pps.NotificationEventType{pps.NotificationEventType_NOTIFY_JOB_FAILURE},
+	}
+	pipelinePtr := &pps.EtcdPipelineInfo{Notifications: []*pps.Notification{all, failures}}
+
+	QueueNotification(pipelinePtr, &pps.NotificationEvent{
+		Type:     pps.NotificationEventType_NOTIFY_JOB_SUCCESS,
+		Pipeline: client.NewPipeline("edges"),
+	})
+	require.Equal(t, 1, len(pipelinePtr.PendingNotifications))
+	require.Equal(t, all, pipelinePtr.PendingNotifications[0].Notification)
+	require.NotEqual(t, "", pipelinePtr.PendingNotifications[0].Event.ID)
+	require.NotNil(t, pipelinePtr.PendingNotifications[0].Event.Time)
+
+	QueueNotification(pipelinePtr, &pps.NotificationEvent{
+		Type:     pps.NotificationEventType_NOTIFY_JOB_FAILURE,
+		Pipeline: client.NewPipeline("edges"),
+	})
+	require.Equal(t, 3, len(pipelinePtr.PendingNotifications))
+	require.Equal(t, pipelinePtr.PendingNotifications[1].Event, pipelinePtr.PendingNotifications[2].Event)
+	require.NotEqual(t, pipelinePtr.PendingNotifications[1].ID, pipelinePtr.PendingNotifications[2].ID)
+
+	// The oldest notifications are dropped once too many are pending
+	for i := 0; i < MaxPendingNotifications; i++ {
+		QueueNotification(pipelinePtr, &pps.NotificationEvent{
+			Type:     pps.NotificationEventType_NOTIFY_PIPELINE_CRASHING,
+			Pipeline: client.NewPipeline("edges"),
+		})
+	}
+	require.Equal(t, MaxPendingNotifications, len(pipelinePtr.PendingNotifications))
+	for _, pending := range pipelinePtr.PendingNotifications {
+		require.Equal(t, pps.NotificationEventType_NOTIFY_PIPELINE_CRASHING, pending.Event.Type)
+	}
+
+	// Pipelines without notifications queue nothing
+	pipelinePtr = &pps.EtcdPipelineInfo{}
+	QueueNotification(pipelinePtr, &pps.NotificationEvent{
+		Type:     pps.NotificationEventType_NOTIFY_JOB_FAILURE,
+		Pipeline: client.NewPipeline("edges"),
+	})
+	require.Equal(t, 0, len(pipelinePtr.PendingNotifications))
+}
@@ -0,0 +1,222 @@
+package ppsutil
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+)
+
+// parseAssignments parses a comma-separated list of key=value pairs
+func parseAssignments(s string) ([][2]string, error) {
+	var result [][2]string
+	for _, assignment := range strings.Split(s, ",") {
+		assignment = strings.TrimSpace(assignment)
+		if assignment == "" {
+			continue
+		}
+		parts := strings.SplitN(assignment, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf("invalid assignment %q, must be of the form key=value", assignment)
+		}
+		result = append(result, [2]string{strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])})
+	}
+	return result, nil
+}
+
+// ParseQueueLimits parses the limits of pipeline queues from a comma-separated
+// list of queue=limit pairs, e.g. "backfill=2,etl=10"
+func ParseQueueLimits(s string) (map[string]int64, error) {
+	assignments, err := parseAssignments(s)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]int64)
+	for _, assignment := range assignments {
+		limit, err := strconv.ParseInt(assignment[1], 10, 64)
+		if err != nil || limit < 1 {
+			return nil, errors.Errorf("invalid limit %q for queue %q, must be a positive integer", assignment[1], assignment[0])
+		}
+		result[assignment[0]] = limit
+	}
+	return result, nil
+}
+
+// ParsePriorityClasses parses a mapping from pipeline priorities to Kubernetes
+// PriorityClasses from a comma-separated list of priority=class pairs, e.g.
+// "100=pach-high,0=pach-normal"
+func ParsePriorityClasses(s string) (map[int64]string, error) {
+	assignments, err := parseAssignments(s)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[int64]string)
+	for _, assignment := range assignments {
+		priority, err := strconv.ParseInt(assignment[0], 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid priority %q for PriorityClass %q", assignment[0], assignment[1])
+		}
+		result[priority] = assignment[1]
+	}
+	return result, nil
+}
+
+// PriorityClassName returns the PriorityClass that 'classes' maps 'priority'
+// to, which is the class of the greatest priority in 'classes' that doesn't
+// exceed 'priority'. If there's no such class, it returns "".
+func PriorityClassName(classes map[int64]string, priority int64) string {
+	var result string
+	var best int64
+	for p, class := range classes {
+		if p <= priority && (result == "" || p > best) {
+			result, best = class, p
+		}
+	}
+	return result
+}
+
+// pruneQueue removes the entries of jobs that are finished or no longer exist
+// from 'queue', so that the slots of jobs whose workers died are reclaimed
+func pruneQueue(jobs col.ReadWriteCollection, queue *pps.JobQueue) error {
+	prune := func(entries []*pps.JobQueueEntry) ([]*pps.JobQueueEntry, error) {
+		var result []*pps.JobQueueEntry
+		for _, entry := range entries {
+			jobPtr := &pps.EtcdJobInfo{}
+			if err := jobs.Get(entry.Job.ID, jobPtr); err != nil {
+				if col.IsErrNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+			if !IsTerminal(jobPtr.State) {
+				result = append(result, entry)
+			}
+		}
+		return result, nil
+	}
+	var err error
+	if queue.Running, err = prune(queue.Running); err != nil {
+		return err
+	}
+	queue.Waiting, err = prune(queue.Waiting)
+	return err
+}
+
+// AcquireQueueSlot tries to admit the job 'entry' to the queue 'name',
+// returning true if the job may run and setting entry.Admitted to when it was
+// admitted. If the queue is full, or jobs with a
+// higher priority are waiting, the job is added to the queue's waiting jobs (if
+// it isn't already waiting) and AcquireQueueSlot returns false, along with the
+// job's position among the waiting jobs.
+func AcquireQueueSlot(queues, jobs col.ReadWriteCollection, name string, entry *pps.JobQueueEntry) (bool, int, error) {
+	queue := &pps.JobQueue{}
+	if err := queues.Get(name, queue); err != nil && !col.IsErrNotFound(err) {
+		return false, 0, err
+	}
+	if err := pruneQueue(jobs, queue); err != nil {
+		return false, 0, err
+	}
+	for _, running := range queue.Running {
+		if running.Job.ID == entry.Job.ID {
+			entry.Admitted = running.Admitted
+			return true, 0, nil
+		}
+	}
+	waiting := false
+	for _, w := range queue.Waiting {
+		if w.Job.ID == entry.Job.ID {
+			waiting = true
+			break
+		}
+	}
+	if !waiting {
+		queue.Waiting = append(queue.Waiting, entry)
+	}
+	// Waiting jobs are kept in the order in which they started waiting, so a
+	// stable sort admits jobs of equal priority first come, first served
+	sort.SliceStable(queue.Waiting, func(i, j int) bool {
+		return queue.Waiting[i].Priority > queue.Waiting[j].Priority
+	})
+	position := 0
+	for i, w := range queue.Waiting {
+		if w.Job.ID == entry.Job.ID {
+			position = i
+			break
+		}
+	}
+	admitted := queue.Limit == 0 || int64(len(queue.Running)+position) < queue.Limit
+	if admitted {
+		queue.Waiting = append(queue.Waiting[:position], queue.Waiting[position+1:]...)
+		entry.Admitted = types.TimestampNow()
+		queue.Running = append(queue.Running, entry)
+	}
+	return admitted, position, queues.Put(name, queue)
+}
+
+// QueueAdmitted returns when the job 'job' was admitted to the queue 'name', or
+// nil if it isn't running in the queue
+func QueueAdmitted(queues col.ReadonlyCollection, name string, job *pps.Job) (*types.Timestamp, error) {
+	queue := &pps.JobQueue{}
+	if err := queues.Get(name, queue); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	for _, running := range queue.Running {
+		if running.Job.ID == job.ID {
+			return running.Admitted, nil
+		}
+	}
+	return nil, nil
+}
+
+// ReleaseQueueSlot removes the job 'job' from the queue 'name', whether it's
+// running or waiting
+func ReleaseQueueSlot(queues col.ReadWriteCollection, name string, job *pps.Job) error {
+	queue := &pps.JobQueue{}
+	if err := queues.Get(name, queue); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	remove := func(entries []*pps.JobQueueEntry) []*pps.JobQueueEntry {
+		var result []*pps.JobQueueEntry
+		for _, entry := range entries {
+			if entry.Job.ID != job.ID {
+				result = append(result, entry)
+			}
+		}
+		return result
+	}
+	queue.Running = remove(queue.Running)
+	queue.Waiting = remove(queue.Waiting)
+	return queues.Put(name, queue)
+}
+
+// SetQueueLimit sets the limit of the queue 'name', creating the queue if it
+// doesn't exist
+func SetQueueLimit(queues col.ReadWriteCollection, name string, limit int64) error {
+	queue := &pps.JobQueue{}
+	if err := queues.Get(name, queue); err != nil && !col.IsErrNotFound(err) {
+		return err
+	}
+	if queue.Limit == limit {
+		return nil
+	}
+	queue.Limit = limit
+	return queues.Put(name, queue)
+}
+
+// QueuedReason is the reason given for a job that's waiting in the queue
+// 'name' at 'position' (counting from 0)
+func QueuedReason(name string, position int) string {
+	return fmt.Sprintf("waiting for a slot in queue %q (position %d)", name, position+1)
+}
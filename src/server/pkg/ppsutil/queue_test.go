@@ -0,0 +1,123 @@
+package ppsutil
+
+import (
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testetcd"
+)
+
+func TestParseQueueConfig(t *testing.T) {
+	limits, err := ParseQueueLimits("backfill=2, etl=10")
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"backfill": 2, "etl": 10}, limits)
+	_, err = ParseQueueLimits("backfill=0")
+	require.YesError(t, err)
+	_, err = ParseQueueLimits("backfill")
+	require.YesError(t, err)
+
+	classes, err := ParsePriorityClasses("100=pach-high,0=pach-normal")
+	require.NoError(t, err)
+	require.Equal(t, "pach-high", PriorityClassName(classes, 150))
+	require.Equal(t, "pach-high", PriorityClassName(classes, 100))
+	require.Equal(t, "pach-normal", PriorityClassName(classes, 99))
+	require.Equal(t, "", PriorityClassName(classes, -1))
+	_, err = ParsePriorityClasses("high=pach-high")
+	require.YesError(t, err)
+}
+
+func TestJobQueue(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		jobs := ppsdb.Jobs(env.EtcdClient, "")
+		queues := ppsdb.JobQueues(env.EtcdClient, "")
+		entry := func(id string, priority int64) *pps.JobQueueEntry {
+			return &pps.JobQueueEntry{Job: client.NewJob(id), Pipeline: client.NewPipeline("p"), Priority: priority}
+		}
+		setState := func(id string, state pps.JobState) {
+			_, err := col.NewSTM(env.Context, env.EtcdClient, func(stm col.STM) error {
+				return jobs.ReadWrite(stm).Put(id, &pps.EtcdJobInfo{
+					Job:          client.NewJob(id),
+					Pipeline:     client.NewPipeline("p"),
+					OutputCommit: client.NewCommit("p", id),
+					State:        state,
+				})
+			})
+			require.NoError(t, err)
+		}
+		acquire := func(queue string, e *pps.JobQueueEntry) (admitted bool, position int) {
+			_, err := col.NewSTM(env.Context, env.EtcdClient, func(stm col.STM) error {
+				var err error
+				admitted, position, err = AcquireQueueSlot(queues.ReadWrite(stm), jobs.ReadWrite(stm), queue, e)
+				return err
+			})
+			require.NoError(t, err)
+			return admitted, position
+		}
+		for _, id := range []string{"j1", "j2", "j3"} {
+			setState(id, pps.JobState_JOB_STARTING)
+		}
+		_, err := col.NewSTM(env.Context, env.EtcdClient, func(stm col.STM) error {
+			return SetQueueLimit(queues.ReadWrite(stm), "q", 1)
+		})
+		require.NoError(t, err)
+
+		j1 := entry("j1", 0)
+		admitted, _ := acquire("q", j1)
+		require.True(t, admitted)
+		require.NotNil(t, j1.Admitted)
+		// A job that's already running keeps the time it was admitted
+		again := entry("j1", 0)
+		admitted, _ = acquire("q", again)
+		require.True(t, admitted)
+		require.Equal(t, j1.Admitted, again.Admitted)
+		admittedAt, err := QueueAdmitted(queues.ReadOnly(env.Context), "q", client.NewJob("j1"))
+		require.NoError(t, err)
+		require.Equal(t, j1.Admitted, admittedAt)
+		admitted, position := acquire("q", entry("j2", 0))
+		require.False(t, admitted)
+		require.Equal(t, 0, position)
+		// j3's higher priority puts it ahead of j2
+		admitted, position = acquire("q", entry("j3", 10))
+		require.False(t, admitted)
+		require.Equal(t, 0, position)
+		admitted, position = acquire("q", entry("j2", 0))
+		require.False(t, admitted)
+		require.Equal(t, 1, position)
+		// Waiting jobs haven't been admitted
+		admittedAt, err = QueueAdmitted(queues.ReadOnly(env.Context), "q", client.NewJob("j2"))
+		require.NoError(t, err)
+		require.Nil(t, admittedAt)
+
+		// j1's slot is reclaimed once it finishes, even if it isn't released
+		setState("j1", pps.JobState_JOB_SUCCESS)
+		admitted, _ = acquire("q", entry("j2", 0))
+		require.False(t, admitted)
+		admitted, _ = acquire("q", entry("j3", 10))
+		require.True(t, admitted)
+
+		// A job that's killed while it waits gives up its place in the queue
+		setState("j4", pps.JobState_JOB_STARTING)
+		admitted, position = acquire("q", entry("j4", 0))
+		require.False(t, admitted)
+		require.Equal(t, 1, position)
+		setState("j4", pps.JobState_JOB_KILLED)
+		_, err = col.NewSTM(env.Context, env.EtcdClient, func(stm col.STM) error {
+			return ReleaseQueueSlot(queues.ReadWrite(stm), "q", client.NewJob("j3"))
+		})
+		require.NoError(t, err)
+		admitted, _ = acquire("q", entry("j2", 0))
+		require.True(t, admitted)
+		queue := &pps.JobQueue{}
+		require.NoError(t, queues.ReadOnly(env.Context).Get("q", queue))
+		require.Equal(t, 0, len(queue.Waiting))
+
+		// Queues without a limit admit every job
+		admitted, _ = acquire("unlimited", entry("j1", 0))
+		require.True(t, admitted)
+		return nil
+	}))
+}
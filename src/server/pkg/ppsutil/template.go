@@ -0,0 +1,198 @@
+package ppsutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// templateParameterNameRe matches valid template parameter names, which must
+// be usable as fields in the template's spec, e.g. '{{ .customer }}'
+var templateParameterNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// templateEscaper is the function that's appended to every action in a
+// template's spec, so that each value it writes is JSON-encoded
+const templateEscaper = "_pachyderm_template_json"
+
+// ValidateTemplate checks that the parameters of a pipeline template are
+// well-formed and that its spec parses as a Go text/template
+func ValidateTemplate(parameters []*ppsclient.TemplateParameter, spec string) error {
+	names := make(map[string]bool)
+	for _, param := range parameters {
+		if !templateParameterNameRe.MatchString(param.Name) {
+			return errors.Errorf("invalid parameter name %q: parameter names may only contain alphanumeric characters and underscores, and may not start with a digit", param.Name)
+		}
+		if names[param.Name] {
+			return errors.Errorf("parameter %q is declared more than once", param.Name)
+		}
+		names[param.Name] = true
+		if _, ok := ppsclient.TemplateParameterType_name[int32(param.Type)]; !ok {
+			return errors.Errorf("parameter %q has unknown type %d", param.Name, param.Type)
+		}
+		if HasDefault(param) {
+			if _, err := ParseTemplateArg(param, param.Default); err != nil {
+				return errors.Wrapf(err, "invalid default")
+			}
+		}
+	}
+	if spec == "" {
+		return errors.New("template must have a spec")
+	}
+	if _, err := parseTemplateSpec("", spec); err != nil {
+		return err
+	}
+	return nil
+}
+
+// parseTemplateSpec parses a template's spec, and JSON-encodes the output of
+// each of its actions, as html/template does with HTML escaping. Each value
+// inserted into the spec is then a single JSON scalar, so arguments can't
+// change the structure of the rendered pipeline spec, e.g. by closing a
+// quoted string.
+func parseTemplateSpec(name, spec string) (*template.Template, error) {
+	t, err := template.New(name).Option("missingkey=error").Funcs(template.FuncMap{
+		templateEscaper: jsonEncodeTemplateValue,
+	}).Parse(spec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse template spec")
+	}
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree != nil {
+			escapeTemplateActions(tmpl.Tree.Root)
+		}
+	}
+	return t, nil
+}
+
+// escapeTemplateActions appends templateEscaper to each action under 'node'
+// that writes output
+func escapeTemplateActions(node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			escapeTemplateActions(child)
+		}
+	case *parse.ActionNode:
+		// actions that only declare variables don't write anything
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Args:     []parse.Node{parse.NewIdentifier(templateEscaper).SetTree(nil).SetPos(n.Pos)},
+		})
+	case *parse.IfNode:
+		escapeTemplateActions(n.List)
+		escapeTemplateActions(n.ElseList)
+	case *parse.RangeNode:
+		escapeTemplateActions(n.List)
+		escapeTemplateActions(n.ElseList)
+	case *parse.WithNode:
+		escapeTemplateActions(n.List)
+		escapeTemplateActions(n.ElseList)
+	}
+}
+
+// jsonEncodeTemplateValue encodes a value written by a template action
+func jsonEncodeTemplateValue(v interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return "", errors.EnsureStack(err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// HasDefault returns true if 'param' can be left out when its template is
+// instantiated. Only string parameters can have an empty default.
+func HasDefault(param *ppsclient.TemplateParameter) bool {
+	return param.HasDefault || param.Default != ""
+}
+
+// ParseTemplateArg converts 'value' to the type of 'param', so that it can be
+// passed to the template's spec
+func ParseTemplateArg(param *ppsclient.TemplateParameter, value string) (interface{}, error) {
+	switch param.Type {
+	case ppsclient.TemplateParameterType_PARAMETER_INT:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, errors.Errorf("parameter %q must be an int, but got %q", param.Name, value)
+		}
+		return i, nil
+	case ppsclient.TemplateParameterType_PARAMETER_BOOL:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errors.Errorf("parameter %q must be a bool, but got %q", param.Name, value)
+		}
+		return b, nil
+	default:
+		return value, nil
+	}
+}
+
+// RenderTemplate instantiates a pipeline template with 'args', and returns the
+// resulting pipeline spec. Each value that the template writes is
+// JSON-encoded, so string arguments are written as quoted strings. The
+// returned request records the template and
+// arguments it was rendered from.
+func RenderTemplate(templateInfo *ppsclient.TemplateInfo, args map[string]string) (*ppsclient.CreatePipelineRequest, error) {
+	values := make(map[string]interface{})
+	for _, param := range templateInfo.Parameters {
+		value, ok := args[param.Name]
+		if !ok {
+			if !HasDefault(param) {
+				return nil, errors.Errorf("missing required argument %q", param.Name)
+			}
+			value = param.Default
+		}
+		v, err := ParseTemplateArg(param, value)
+		if err != nil {
+			return nil, err
+		}
+		values[param.Name] = v
+	}
+	var unknown []string
+	for name := range args {
+		if _, ok := values[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, errors.Errorf("template %q has no parameters named %q", templateInfo.Template.Name, unknown)
+	}
+
+	t, err := parseTemplateSpec(templateInfo.Template.Name, templateInfo.Spec)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, values); err != nil {
+		return nil, errors.Wrapf(err, "could not render template")
+	}
+	request, err := NewPipelineManifestReaderFromBytes(buf.Bytes()).NextCreatePipelineRequest()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.Errorf("template %q rendered an empty pipeline spec", templateInfo.Template.Name)
+	} else if err != nil {
+		return nil, err
+	}
+	request.Template = &ppsclient.TemplateRef{
+		Template: templateInfo.Template,
+		Version:  templateInfo.Version,
+		Args:     args,
+	}
+	return request, nil
+}
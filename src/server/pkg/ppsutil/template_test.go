@@ -0,0 +1,128 @@
+package ppsutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+const testTemplateSpec = `
+pipeline:
+  name: {{ printf "%s-ingest" .customer }}
+transform:
+  image: ingest:1.0
+  cmd: [ "ingest", "--customer", {{ .customer }}, {{ printf "--verbose=%t" .verbose }} ]
+parallelism_spec:
+  constant: {{ .workers }}
+input:
+  pfs:
+    repo: {{ printf "%s-raw" .customer }}
+    glob: /*
+`
+
+func testTemplate() *ppsclient.TemplateInfo {
+	return &ppsclient.TemplateInfo{
+		Template: &ppsclient.PipelineTemplate{Name: "ingest"},
+		Version:  3,
+		Parameters: []*ppsclient.TemplateParameter{
+			{Name: "customer"},
+			{Name: "workers", Type: ppsclient.TemplateParameterType_PARAMETER_INT, Default: "2"},
+			{Name: "verbose", Type: ppsclient.TemplateParameterType_PARAMETER_BOOL, Default: "false"},
+		},
+		Spec: testTemplateSpec,
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	templateInfo := testTemplate()
+	require.NoError(t, ValidateTemplate(templateInfo.Parameters, templateInfo.Spec))
+
+	args := map[string]string{"customer": "acme", "verbose": "true"}
+	request, err := RenderTemplate(templateInfo, args)
+	require.NoError(t, err)
+	require.Equal(t, client.NewPipeline("acme-ingest"), request.Pipeline)
+	require.Equal(t, []string{"ingest", "--customer", "acme", "--verbose=true"}, request.Transform.Cmd)
+	require.Equal(t, uint64(2), request.ParallelismSpec.Constant)
+	require.Equal(t, "acme-raw", request.Input.Pfs.Repo)
+	require.Equal(t, "ingest", request.Template.Template.Name)
+	require.Equal(t, int64(3), request.Template.Version)
+	require.Equal(t, args, request.Template.Args)
+}
+
+func TestRenderTemplateEscapesArgs(t *testing.T) {
+	// Arguments can't change the structure of the spec, as each of them is
+	// written as a JSON string
+	templateInfo := testTemplate()
+	customer := `acme", "--admin`
+	request, err := RenderTemplate(templateInfo, map[string]string{"customer": customer})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ingest", "--customer", customer, "--verbose=false"}, request.Transform.Cmd)
+
+	customer = "acme\ntransform: {image: other}"
+	request, err = RenderTemplate(templateInfo, map[string]string{"customer": customer})
+	require.NoError(t, err)
+	require.Equal(t, "ingest:1.0", request.Transform.Image)
+	require.Equal(t, customer+"-raw", request.Input.Pfs.Repo)
+
+	// This includes the output of control structures and variables
+	templateInfo.Spec = `{{ $c := .customer }}{"pipeline": {"name": {{ if .verbose }}{{ $c }}{{ end }}}}`
+	request, err = RenderTemplate(templateInfo, map[string]string{"customer": `a"}, "transform": {"image": "other`, "verbose": "true"})
+	require.NoError(t, err)
+	require.Equal(t, `a"}, "transform": {"image": "other`, request.Pipeline.Name)
+	require.Nil(t, request.Transform)
+}
+
+func TestRenderTemplateErrors(t *testing.T) {
+	templateInfo := testTemplate()
+	_, err := RenderTemplate(templateInfo, map[string]string{})
+	require.YesError(t, err)
+	require.Matches(t, "missing required argument", err.Error())
+
+	_, err = RenderTemplate(templateInfo, map[string]string{"customer": "acme", "workers": "many"})
+	require.YesError(t, err)
+	require.Matches(t, "must be an int", err.Error())
+
+	_, err = RenderTemplate(templateInfo, map[string]string{"customer": "acme", "region": "us"})
+	require.YesError(t, err)
+	require.Matches(t, "no parameters named", err.Error())
+}
+
+func TestRenderTemplateEmptyDefault(t *testing.T) {
+	templateInfo := testTemplate()
+	templateInfo.Parameters = append(templateInfo.Parameters,
+		&ppsclient.TemplateParameter{Name: "suffix", HasDefault: true})
+	templateInfo.Spec = strings.Replace(templateInfo.Spec, `{{ printf "%s-ingest" .customer }}`, `{{ printf "%s-ingest%s" .customer .suffix }}`, 1)
+	require.NoError(t, ValidateTemplate(templateInfo.Parameters, templateInfo.Spec))
+
+	request, err := RenderTemplate(templateInfo, map[string]string{"customer": "acme"})
+	require.NoError(t, err)
+	require.Equal(t, client.NewPipeline("acme-ingest"), request.Pipeline)
+
+	request, err = RenderTemplate(templateInfo, map[string]string{"customer": "acme", "suffix": "-v2"})
+	require.NoError(t, err)
+	require.Equal(t, client.NewPipeline("acme-ingest-v2"), request.Pipeline)
+
+	// Only string parameters can be empty
+	require.YesError(t, ValidateTemplate([]*ppsclient.TemplateParameter{{
+		Name:       "workers",
+		Type:       ppsclient.TemplateParameterType_PARAMETER_INT,
+		HasDefault: true,
+	}}, "{}"))
+}
+
+func TestValidateTemplate(t *testing.T) {
+	params := []*ppsclient.TemplateParameter{{Name: "customer"}}
+	require.NoError(t, ValidateTemplate(params, "pipeline: {name: {{ .customer }}}"))
+	require.YesError(t, ValidateTemplate(params, ""))
+	require.YesError(t, ValidateTemplate(params, "pipeline: {name: {{ .customer }"))
+	require.YesError(t, ValidateTemplate(append(params, &ppsclient.TemplateParameter{Name: "customer"}), "{}"))
+	require.YesError(t, ValidateTemplate([]*ppsclient.TemplateParameter{{Name: "customer-name"}}, "{}"))
+	require.YesError(t, ValidateTemplate([]*ppsclient.TemplateParameter{{
+		Name:    "workers",
+		Type:    ppsclient.TemplateParameterType_PARAMETER_INT,
+		Default: "two",
+	}}, "{}"))
+}
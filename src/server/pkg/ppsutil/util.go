@@ -52,6 +52,12 @@ func PipelineRcName(name string, version uint64) string {
 	return fmt.Sprintf("pipeline-%s-v%d", strings.ToLower(name), version)
 }
 
+// WorkNamespace returns the namespace of the task queue that a pipeline's
+// workers use to split up its jobs' datums between them
+func WorkNamespace(pipelineInfo *pps.PipelineInfo) string {
+	return fmt.Sprintf("/pipeline-%s/v%d", pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+}
+
 // GetRequestsResourceListFromPipeline returns a list of resources that the pipeline,
 // minimally requires.
 func GetRequestsResourceListFromPipeline(pipelineInfo *pps.PipelineInfo) (*v1.ResourceList, error) {
@@ -242,6 +248,14 @@ func SetPipelineState(ctx context.Context, etcdClient *etcd.Client, pipelinesCol
 			}
 		}
 		log.Infof("SetPipelineState moving pipeline %s from %s to %s", pipeline, pipelinePtr.State, to)
+		if eventType, ok := pipelineNotificationEvents[to]; ok && pipelinePtr.State != to {
+			QueueNotification(pipelinePtr, &pps.NotificationEvent{
+				Type:          eventType,
+				Pipeline:      client.NewPipeline(pipeline),
+				PipelineState: to,
+				Reason:        reason,
+			})
+		}
 		pipelinePtr.State = to
 		pipelinePtr.Reason = reason
 		return pipelines.Put(pipeline, pipelinePtr)
@@ -281,6 +295,8 @@ func JobInput(pipelineInfo *pps.PipelineInfo, outputCommitInfo *pfs.CommitInfo)
 }
 
 // PipelineReqFromInfo converts a PipelineInfo into a CreatePipelineRequest.
+// The pipeline's template isn't copied, as CreatePipeline would re-render the
+// template instead of using the rest of the request.
 func PipelineReqFromInfo(pipelineInfo *pps.PipelineInfo) *pps.CreatePipelineRequest {
 	return &pps.CreatePipelineRequest{
 		Pipeline:              pipelineInfo.Pipeline,
@@ -310,6 +326,17 @@ func PipelineReqFromInfo(pipelineInfo *pps.PipelineInfo) *pps.CreatePipelineRequ
 		Standby:               pipelineInfo.Standby,
 		S3Out:                 pipelineInfo.S3Out,
 		Metadata:              pipelineInfo.Metadata,
+		Autoscaling:           pipelineInfo.Autoscaling,
+		FailedDatumBranch:     pipelineInfo.FailedDatumBranch,
+		RetryPolicy:           pipelineInfo.RetryPolicy,
+		Notifications:         pipelineInfo.Notifications,
+		Queue:                 pipelineInfo.Queue,
+		Priority:              pipelineInfo.Priority,
+		GlobalDatumCache:      pipelineInfo.GlobalDatumCache,
+		GlobalDatumCacheTrust: pipelineInfo.GlobalDatumCacheTrust,
+		Outputs:               pipelineInfo.Outputs,
+		Canary:                pipelineInfo.Canary,
+		PersistLogs:           pipelineInfo.PersistLogs,
 	}
 }
 
@@ -320,7 +347,7 @@ func IsTerminal(state pps.JobState) bool {
 	switch state {
 	case pps.JobState_JOB_SUCCESS, pps.JobState_JOB_FAILURE, pps.JobState_JOB_KILLED:
 		return true
-	case pps.JobState_JOB_STARTING, pps.JobState_JOB_RUNNING, pps.JobState_JOB_MERGING, pps.JobState_JOB_EGRESSING:
+	case pps.JobState_JOB_STARTING, pps.JobState_JOB_QUEUED, pps.JobState_JOB_RUNNING, pps.JobState_JOB_MERGING, pps.JobState_JOB_EGRESSING:
 		return false
 	default:
 		panic(fmt.Sprintf("unrecognized job state: %s", state))
@@ -346,6 +373,18 @@ func UpdateJobState(pipelines col.ReadWriteCollection, jobs col.ReadWriteCollect
 	}
 	pipelinePtr.JobCounts[int32(state)]++
 	pipelinePtr.LastJobState = state
+	// A job is created in JOB_STARTING, so its start is identified by not yet
+	// having a start time rather than by a change of state
+	if eventType, ok := jobNotificationEvents[state]; ok &&
+		(jobPtr.State != state || (state == pps.JobState_JOB_STARTING && jobPtr.Started == nil)) {
+		QueueNotification(pipelinePtr, &pps.NotificationEvent{
+			Type:     eventType,
+			Pipeline: jobPtr.Pipeline,
+			Job:      jobPtr.Job,
+			JobState: state,
+			Reason:   reason,
+		})
+	}
 	if err := pipelines.Put(jobPtr.Pipeline.Name, pipelinePtr); err != nil {
 		return err
 	}
@@ -68,6 +68,14 @@ type PachdSpecificConfiguration struct {
 	DeploymentID               string `env:"CLUSTER_DEPLOYMENT_ID,default="`
 	RequireCriticalServersOnly bool   `env:"REQUIRE_CRITICAL_SERVERS_ONLY",default=false"`
 	MetricsEndpoint            string `env:"METRICS_ENDPOINT",default="`
+	// PPSQueueLimits sets the number of jobs that can run at once in each
+	// pipeline queue, e.g. "backfill=2,etl=10". Queues without a limit are
+	// unlimited.
+	PPSQueueLimits string `env:"PPS_QUEUE_LIMITS,default="`
+	// PPSPriorityClasses maps pipeline priorities to Kubernetes PriorityClasses,
+	// e.g. "100=pach-high,0=pach-normal". Each pipeline's workers use the class
+	// of the greatest priority that doesn't exceed the pipeline's.
+	PPSPriorityClasses string `env:"PPS_PRIORITY_CLASSES,default="`
 	// TODO: Merge this with the worker specific pod name (PPS_POD_NAME) into a global configuration pod name.
 	PachdPodName string `env:"PACHD_POD_NAME,required"`
 }
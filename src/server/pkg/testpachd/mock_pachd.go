@@ -784,8 +784,11 @@ type listDatumFunc func(context.Context, *pps.ListDatumRequest) (*pps.ListDatumR
 type listDatumStreamFunc func(*pps.ListDatumRequest, pps.API_ListDatumStreamServer) error
 type restartDatumFunc func(context.Context, *pps.RestartDatumRequest) (*types.Empty, error)
 type createPipelineFunc func(context.Context, *pps.CreatePipelineRequest) (*types.Empty, error)
+type dryRunPipelineFunc func(context.Context, *pps.DryRunPipelineRequest) (*pps.DryRunPipelineResponse, error)
 type inspectPipelineFunc func(context.Context, *pps.InspectPipelineRequest) (*pps.PipelineInfo, error)
 type listPipelineFunc func(context.Context, *pps.ListPipelineRequest) (*pps.PipelineInfos, error)
+type drawPipelineFunc func(context.Context, *pps.DrawPipelineRequest) (*pps.DrawPipelineResponse, error)
+type inspectCanaryFunc func(context.Context, *pps.InspectCanaryRequest) (*pps.InspectCanaryResponse, error)
 type deletePipelineFunc func(context.Context, *pps.DeletePipelineRequest) (*types.Empty, error)
 type startPipelineFunc func(context.Context, *pps.StartPipelineRequest) (*types.Empty, error)
 type stopPipelineFunc func(context.Context, *pps.StopPipelineRequest) (*types.Empty, error)
@@ -795,6 +798,10 @@ type createSecretFunc func(context.Context, *pps.CreateSecretRequest) (*types.Em
 type deleteSecretFunc func(context.Context, *pps.DeleteSecretRequest) (*types.Empty, error)
 type inspectSecretFunc func(context.Context, *pps.InspectSecretRequest) (*pps.SecretInfo, error)
 type listSecretFunc func(context.Context, *types.Empty) (*pps.SecretInfos, error)
+type createTemplateFunc func(context.Context, *pps.CreateTemplateRequest) (*types.Empty, error)
+type inspectTemplateFunc func(context.Context, *pps.InspectTemplateRequest) (*pps.TemplateInfo, error)
+type listTemplateFunc func(context.Context, *types.Empty) (*pps.TemplateInfos, error)
+type deleteTemplateFunc func(context.Context, *pps.DeleteTemplateRequest) (*types.Empty, error)
 type deleteAllPPSFunc func(context.Context, *types.Empty) (*types.Empty, error)
 type getLogsFunc func(*pps.GetLogsRequest, pps.API_GetLogsServer) error
 type garbageCollectFunc func(context.Context, *pps.GarbageCollectRequest) (*pps.GarbageCollectResponse, error)
@@ -813,8 +820,11 @@ type mockListDatum struct{ handler listDatumFunc }
 type mockListDatumStream struct{ handler listDatumStreamFunc }
 type mockRestartDatum struct{ handler restartDatumFunc }
 type mockCreatePipeline struct{ handler createPipelineFunc }
+type mockDryRunPipeline struct{ handler dryRunPipelineFunc }
 type mockInspectPipeline struct{ handler inspectPipelineFunc }
 type mockListPipeline struct{ handler listPipelineFunc }
+type mockDrawPipeline struct{ handler drawPipelineFunc }
+type mockInspectCanary struct{ handler inspectCanaryFunc }
 type mockDeletePipeline struct{ handler deletePipelineFunc }
 type mockStartPipeline struct{ handler startPipelineFunc }
 type mockStopPipeline struct{ handler stopPipelineFunc }
@@ -824,6 +834,10 @@ type mockCreateSecret struct{ handler createSecretFunc }
 type mockDeleteSecret struct{ handler deleteSecretFunc }
 type mockInspectSecret struct{ handler inspectSecretFunc }
 type mockListSecret struct{ handler listSecretFunc }
+type mockCreateTemplate struct{ handler createTemplateFunc }
+type mockInspectTemplate struct{ handler inspectTemplateFunc }
+type mockListTemplate struct{ handler listTemplateFunc }
+type mockDeleteTemplate struct{ handler deleteTemplateFunc }
 type mockDeleteAllPPS struct{ handler deleteAllPPSFunc }
 type mockGetLogs struct{ handler getLogsFunc }
 type mockGarbageCollect struct{ handler garbageCollectFunc }
@@ -842,8 +856,11 @@ func (mock *mockListDatum) Use(cb listDatumFunc)             { mock.handler = cb
 func (mock *mockListDatumStream) Use(cb listDatumStreamFunc) { mock.handler = cb }
 func (mock *mockRestartDatum) Use(cb restartDatumFunc)       { mock.handler = cb }
 func (mock *mockCreatePipeline) Use(cb createPipelineFunc)   { mock.handler = cb }
+func (mock *mockDryRunPipeline) Use(cb dryRunPipelineFunc)   { mock.handler = cb }
 func (mock *mockInspectPipeline) Use(cb inspectPipelineFunc) { mock.handler = cb }
 func (mock *mockListPipeline) Use(cb listPipelineFunc)       { mock.handler = cb }
+func (mock *mockDrawPipeline) Use(cb drawPipelineFunc)       { mock.handler = cb }
+func (mock *mockInspectCanary) Use(cb inspectCanaryFunc)     { mock.handler = cb }
 func (mock *mockDeletePipeline) Use(cb deletePipelineFunc)   { mock.handler = cb }
 func (mock *mockStartPipeline) Use(cb startPipelineFunc)     { mock.handler = cb }
 func (mock *mockStopPipeline) Use(cb stopPipelineFunc)       { mock.handler = cb }
@@ -853,6 +870,10 @@ func (mock *mockCreateSecret) Use(cb createSecretFunc)       { mock.handler = cb
 func (mock *mockDeleteSecret) Use(cb deleteSecretFunc)       { mock.handler = cb }
 func (mock *mockInspectSecret) Use(cb inspectSecretFunc)     { mock.handler = cb }
 func (mock *mockListSecret) Use(cb listSecretFunc)           { mock.handler = cb }
+func (mock *mockCreateTemplate) Use(cb createTemplateFunc)   { mock.handler = cb }
+func (mock *mockInspectTemplate) Use(cb inspectTemplateFunc) { mock.handler = cb }
+func (mock *mockListTemplate) Use(cb listTemplateFunc)       { mock.handler = cb }
+func (mock *mockDeleteTemplate) Use(cb deleteTemplateFunc)   { mock.handler = cb }
 func (mock *mockDeleteAllPPS) Use(cb deleteAllPPSFunc)       { mock.handler = cb }
 func (mock *mockGetLogs) Use(cb getLogsFunc)                 { mock.handler = cb }
 func (mock *mockGarbageCollect) Use(cb garbageCollectFunc)   { mock.handler = cb }
@@ -877,8 +898,11 @@ type mockPPSServer struct {
 	ListDatumStream mockListDatumStream
 	RestartDatum    mockRestartDatum
 	CreatePipeline  mockCreatePipeline
+	DryRunPipeline  mockDryRunPipeline
 	InspectPipeline mockInspectPipeline
 	ListPipeline    mockListPipeline
+	DrawPipeline    mockDrawPipeline
+	InspectCanary   mockInspectCanary
 	DeletePipeline  mockDeletePipeline
 	StartPipeline   mockStartPipeline
 	StopPipeline    mockStopPipeline
@@ -888,6 +912,10 @@ type mockPPSServer struct {
 	DeleteSecret    mockDeleteSecret
 	InspectSecret   mockInspectSecret
 	ListSecret      mockListSecret
+	CreateTemplate  mockCreateTemplate
+	InspectTemplate mockInspectTemplate
+	ListTemplate    mockListTemplate
+	DeleteTemplate  mockDeleteTemplate
 	DeleteAll       mockDeleteAllPPS
 	GetLogs         mockGetLogs
 	GarbageCollect  mockGarbageCollect
@@ -972,6 +1000,12 @@ func (api *ppsServerAPI) CreatePipeline(ctx context.Context, req *pps.CreatePipe
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.CreatePipeline")
 }
+func (api *ppsServerAPI) DryRunPipeline(ctx context.Context, req *pps.DryRunPipelineRequest) (*pps.DryRunPipelineResponse, error) {
+	if api.mock.DryRunPipeline.handler != nil {
+		return api.mock.DryRunPipeline.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.DryRunPipeline")
+}
 func (api *ppsServerAPI) InspectPipeline(ctx context.Context, req *pps.InspectPipelineRequest) (*pps.PipelineInfo, error) {
 	if api.mock.InspectPipeline.handler != nil {
 		return api.mock.InspectPipeline.handler(ctx, req)
@@ -984,6 +1018,18 @@ func (api *ppsServerAPI) ListPipeline(ctx context.Context, req *pps.ListPipeline
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.ListPipeline")
 }
+func (api *ppsServerAPI) DrawPipeline(ctx context.Context, req *pps.DrawPipelineRequest) (*pps.DrawPipelineResponse, error) {
+	if api.mock.DrawPipeline.handler != nil {
+		return api.mock.DrawPipeline.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.DrawPipeline")
+}
+func (api *ppsServerAPI) InspectCanary(ctx context.Context, req *pps.InspectCanaryRequest) (*pps.InspectCanaryResponse, error) {
+	if api.mock.InspectCanary.handler != nil {
+		return api.mock.InspectCanary.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.InspectCanary")
+}
 func (api *ppsServerAPI) DeletePipeline(ctx context.Context, req *pps.DeletePipelineRequest) (*types.Empty, error) {
 	if api.mock.DeletePipeline.handler != nil {
 		return api.mock.DeletePipeline.handler(ctx, req)
@@ -1038,6 +1084,30 @@ func (api *ppsServerAPI) ListSecret(ctx context.Context, in *types.Empty) (*pps.
 	}
 	return nil, errors.Errorf("unhandled pachd mock pps.ListSecret")
 }
+func (api *ppsServerAPI) CreateTemplate(ctx context.Context, req *pps.CreateTemplateRequest) (*types.Empty, error) {
+	if api.mock.CreateTemplate.handler != nil {
+		return api.mock.CreateTemplate.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.CreateTemplate")
+}
+func (api *ppsServerAPI) InspectTemplate(ctx context.Context, req *pps.InspectTemplateRequest) (*pps.TemplateInfo, error) {
+	if api.mock.InspectTemplate.handler != nil {
+		return api.mock.InspectTemplate.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.InspectTemplate")
+}
+func (api *ppsServerAPI) ListTemplate(ctx context.Context, req *types.Empty) (*pps.TemplateInfos, error) {
+	if api.mock.ListTemplate.handler != nil {
+		return api.mock.ListTemplate.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.ListTemplate")
+}
+func (api *ppsServerAPI) DeleteTemplate(ctx context.Context, req *pps.DeleteTemplateRequest) (*types.Empty, error) {
+	if api.mock.DeleteTemplate.handler != nil {
+		return api.mock.DeleteTemplate.handler(ctx, req)
+	}
+	return nil, errors.Errorf("unhandled pachd mock pps.DeleteTemplate")
+}
 func (api *ppsServerAPI) DeleteAll(ctx context.Context, req *types.Empty) (*types.Empty, error) {
 	if api.mock.DeleteAll.handler != nil {
 		return api.mock.DeleteAll.handler(ctx, req)
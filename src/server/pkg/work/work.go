@@ -386,3 +386,21 @@ func (w *Worker) subtaskFunc(subtaskKey string, processFunc ProcessFunc) subtask
 		}
 	}
 }
+
+// OutstandingSubtasks returns the number of subtasks, across all tasks in a
+// namespace, that have not yet been processed. It's used to size the pool of
+// workers that process them.
+func OutstandingSubtasks(ctx context.Context, etcdClient *etcd.Client, etcdPrefix string, taskNamespace string) (int64, error) {
+	subtaskCol := newCollection(etcdClient, path.Join(etcdPrefix, subtaskPrefix, taskNamespace), &TaskInfo{})
+	var count int64
+	subtaskInfo := &TaskInfo{}
+	if err := subtaskCol.ReadOnly(ctx).List(subtaskInfo, col.DefaultOptions, func(string) error {
+		if subtaskInfo.State == State_RUNNING {
+			count++
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
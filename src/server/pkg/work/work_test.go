@@ -200,3 +200,48 @@ func TestRunZeroSubtasks(t *testing.T) {
 		})
 	}))
 }
+
+func TestOutstandingSubtasks(t *testing.T) {
+	require.NoError(t, testetcd.WithEnv(func(env *testetcd.Env) error {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		tq, err := NewTaskQueue(ctx, env.EtcdClient, "", "")
+		if err != nil {
+			return err
+		}
+		numSubtasks := 5
+		var eg errgroup.Group
+		eg.Go(func() error {
+			// With no workers running, the subtasks remain outstanding until
+			// the task is canceled.
+			if err := tq.RunTaskBlock(ctx, func(m *Master) error {
+				var subtasks []*Task
+				for i := 0; i < numSubtasks; i++ {
+					data, err := serializeTestData(&TestData{})
+					if err != nil {
+						return err
+					}
+					subtasks = append(subtasks, &Task{ID: strconv.Itoa(i), Data: data})
+				}
+				return m.RunSubtasks(subtasks, func(_ context.Context, _ *TaskInfo) error {
+					return nil
+				})
+			}); err != nil && !errors.Is(ctx.Err(), context.Canceled) {
+				return err
+			}
+			return nil
+		})
+		require.NoErrorWithinTRetry(t, 30*time.Second, func() error {
+			outstanding, err := OutstandingSubtasks(context.Background(), env.EtcdClient, "", "")
+			if err != nil {
+				return err
+			}
+			if outstanding != int64(numSubtasks) {
+				return errors.Errorf("expected %d outstanding subtasks, got %d", numSubtasks, outstanding)
+			}
+			return nil
+		})
+		cancel()
+		return eg.Wait()
+	}))
+}
@@ -10,7 +10,9 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	pachdclient "github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
@@ -28,6 +30,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/tabwriter"
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
 	"github.com/pachyderm/pachyderm/src/server/pps/pretty"
+	"github.com/pachyderm/pachyderm/src/server/worker/local"
 
 	prompt "github.com/c-bata/go-prompt"
 	units "github.com/docker/go-units"
@@ -106,6 +109,7 @@ If the job fails, the output commit will not be populated with data.`,
 	commands = append(commands, cmdutil.CreateDocsAlias(jobDocs, "job", " job$"))
 
 	var block bool
+	var resources bool
 	inspectJob := &cobra.Command{
 		Use:   "{{alias}} <job>",
 		Short: "Return info about a job.",
@@ -131,11 +135,13 @@ If the job fails, the output commit will not be populated with data.`,
 			ji := &pretty.PrintableJobInfo{
 				JobInfo:        jobInfo,
 				FullTimestamps: fullTimestamps,
+				Resources:      resources,
 			}
 			return pretty.PrintDetailedJobInfo(ji)
 		}),
 	}
 	inspectJob.Flags().BoolVarP(&block, "block", "b", false, "block until the job has either succeeded or failed")
+	inspectJob.Flags().BoolVar(&resources, "resources", false, "print the CPU time, peak memory and disk used by the job's datums")
 	inspectJob.Flags().AddFlagSet(outputFlags)
 	inspectJob.Flags().AddFlagSet(fullTimestampsFlags)
 	shell.RegisterCompletionFunc(inspectJob, shell.JobCompletion)
@@ -475,6 +481,10 @@ each datum.`,
 		worker      bool
 		follow      bool
 		tail        int64
+		persisted   bool
+		since       string
+		until       string
+		grep        string
 	)
 
 	// prettyLogsPrinter helps to print the logs recieved in different colours
@@ -514,7 +524,10 @@ $ {{alias}} --pipeline=filter
 $ {{alias}} --job=aedfa12aedf
 
 # Return logs emitted by the pipeline \"filter\" while processing /apple.txt and a file with the hash 123aef
-$ {{alias}} --pipeline=filter --inputs=/apple.txt,123aef`,
+$ {{alias}} --pipeline=filter --inputs=/apple.txt,123aef
+
+# Return the persisted logs of the "filter" pipeline's jobs from the last week that contain "timeout"
+$ {{alias}} --pipeline=filter --persisted --since=168h --grep=timeout`,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
 			client, err := pachdclient.NewOnUserMachine("user")
 			if err != nil {
@@ -536,7 +549,23 @@ $ {{alias}} --pipeline=filter --inputs=/apple.txt,123aef`,
 			}
 
 			// Issue RPC
-			iter := client.GetLogs(pipelineName, jobID, data, datumID, master, follow, tail)
+			sinceTime, err := parseLogTime(since)
+			if err != nil {
+				return errors.Wrapf(err, "invalid --since")
+			}
+			untilTime, err := parseLogTime(until)
+			if err != nil {
+				return errors.Wrapf(err, "invalid --until")
+			}
+			var iter *pachdclient.LogsIter
+			if persisted {
+				if master || follow || tail != 0 {
+					return errors.New("--master, --follow and --tail can't be used with --persisted")
+				}
+				iter = client.GetPersistedLogs(pipelineName, jobID, data, datumID, sinceTime, untilTime, grep)
+			} else {
+				iter = client.GetFilteredLogs(pipelineName, jobID, data, datumID, master, follow, tail, sinceTime, untilTime, grep)
+			}
 			var buf bytes.Buffer
 			encoder := json.NewEncoder(&buf)
 			for iter.Next() {
@@ -573,6 +602,10 @@ $ {{alias}} --pipeline=filter --inputs=/apple.txt,123aef`,
 	getLogs.Flags().BoolVar(&raw, "raw", false, "Return log messages verbatim from server.")
 	getLogs.Flags().BoolVarP(&follow, "follow", "f", false, "Follow logs as more are created.")
 	getLogs.Flags().Int64VarP(&tail, "tail", "t", 0, "Lines of recent logs to display.")
+	getLogs.Flags().BoolVar(&persisted, "persisted", false, "Return the log messages that were persisted by finished jobs, rather than those of running workers.")
+	getLogs.Flags().StringVar(&since, "since", "", "Return log messages logged after this time (accepts a duration, such as 24h, or an RFC 3339 timestamp).")
+	getLogs.Flags().StringVar(&until, "until", "", "Return log messages logged before this time (accepts a duration, such as 24h, or an RFC 3339 timestamp).")
+	getLogs.Flags().StringVar(&grep, "grep", "", "Return log messages that match this regular expression.")
 	shell.RegisterCompletionFunc(getLogs,
 		func(flag, text string, maxCompletions int64) ([]prompt.Suggest, shell.CacheFunc) {
 			if flag == "--pipeline" || flag == "-p" {
@@ -604,11 +637,21 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	var registry string
 	var username string
 	var pipelinePath string
+	var dryRun bool
+	var sampleSize int64
+	var templateName string
+	var templateArgs []string
 	createPipeline := &cobra.Command{
 		Short: "Create a new pipeline.",
 		Long:  "Create a new pipeline from a pipeline specification. For details on the format, see http://docs.pachyderm.io/en/latest/reference/pipeline_spec.html.",
 		Run: cmdutil.RunFixedArgs(0, func(args []string) (retErr error) {
-			return pipelineHelper(false, build, pushImages, registry, username, pipelinePath, false)
+			if templateName != "" {
+				return templatePipelineHelper(templateName, templateArgs, false, false)
+			}
+			if dryRun {
+				return dryRunPipelineHelper(pipelinePath, sampleSize, raw, output)
+			}
+			return pipelineHelper(false, build, pushImages, registry, username, pipelinePath, false, nil)
 		}),
 	}
 	createPipeline.Flags().StringVarP(&pipelinePath, "file", "f", "-", "The JSON file containing the pipeline, it can be a url or local file. - reads from stdin.")
@@ -616,14 +659,35 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	createPipeline.Flags().BoolVarP(&pushImages, "push-images", "p", false, "If true, push local docker images into the docker registry.")
 	createPipeline.Flags().StringVarP(&registry, "registry", "r", "index.docker.io", "The registry to push images to.")
 	createPipeline.Flags().StringVarP(&username, "username", "u", "", "The username to push images as.")
+	createPipeline.Flags().BoolVar(&dryRun, "dry-run", false, "If true, don't create the pipeline; instead, print the datums that it would process from the current heads of its input branches.")
+	createPipeline.Flags().Int64Var(&sampleSize, "sample", 10, "The number of datums to print with --dry-run.")
+	createPipeline.Flags().AddFlagSet(outputFlags)
+	createPipeline.Flags().StringVar(&templateName, "template", "", "Instantiate the pipeline from this pipeline template, instead of reading its spec from --file.")
+	createPipeline.Flags().StringArrayVar(&templateArgs, "arg", nil, "An argument to the pipeline template, as <name>=<value> (can be repeated).")
 	commands = append(commands, cmdutil.CreateAlias(createPipeline, "create pipeline"))
 
 	var reprocess bool
+	var canary bool
+	var canarySample int64
 	updatePipeline := &cobra.Command{
 		Short: "Update an existing Pachyderm pipeline.",
-		Long:  "Update a Pachyderm pipeline with a new pipeline specification. For details on the format, see http://docs.pachyderm.io/en/latest/reference/pipeline_spec.html.",
+		Long: `Update a Pachyderm pipeline with a new pipeline specification. For details on the format, see http://docs.pachyderm.io/en/latest/reference/pipeline_spec.html.
+
+With --canary, the pipeline isn't updated. Instead, the new specification is run as a canary pipeline, <pipeline>-canary, on the same inputs (or a sample of their datums), and its outputs can be compared with the pipeline's using 'inspect canary'. The canary can then be promoted to replace the pipeline with 'promote canary', or deleted with 'discard canary'.`,
 		Run: cmdutil.RunFixedArgs(0, func(args []string) (retErr error) {
-			return pipelineHelper(reprocess, build, pushImages, registry, username, pipelinePath, true)
+			var canarySpec *ppsclient.CanarySpec
+			if canary {
+				canarySpec = &ppsclient.CanarySpec{SamplePercent: canarySample}
+			} else if canarySample != 0 {
+				return errors.New("--canary-sample can only be used with --canary")
+			}
+			if templateName != "" {
+				if canary {
+					return errors.New("--canary cannot be used with --template")
+				}
+				return templatePipelineHelper(templateName, templateArgs, true, reprocess)
+			}
+			return pipelineHelper(reprocess, build, pushImages, registry, username, pipelinePath, true, canarySpec)
 		}),
 	}
 	updatePipeline.Flags().StringVarP(&pipelinePath, "file", "f", "-", "The JSON file containing the pipeline, it can be a url or local file. - reads from stdin.")
@@ -632,6 +696,10 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	updatePipeline.Flags().StringVarP(&registry, "registry", "r", "index.docker.io", "The registry to push images to.")
 	updatePipeline.Flags().StringVarP(&username, "username", "u", "", "The username to push images as.")
 	updatePipeline.Flags().BoolVar(&reprocess, "reprocess", false, "If true, reprocess datums that were already processed by previous version of the pipeline.")
+	updatePipeline.Flags().StringVar(&templateName, "template", "", "Instantiate the pipeline from the latest version of this pipeline template, instead of reading its spec from --file.")
+	updatePipeline.Flags().StringArrayVar(&templateArgs, "arg", nil, "An argument to the pipeline template, as <name>=<value> (can be repeated).")
+	updatePipeline.Flags().BoolVar(&canary, "canary", false, "If true, run the new version of the pipeline as a canary, <pipeline>-canary, instead of updating the pipeline.")
+	updatePipeline.Flags().Int64Var(&canarySample, "canary-sample", 0, "The percentage of datums that the canary processes (with --canary). If 0, every datum is processed.")
 	commands = append(commands, cmdutil.CreateAlias(updatePipeline, "update pipeline"))
 
 	runPipeline := &cobra.Command{
@@ -696,6 +764,97 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	}
 	commands = append(commands, cmdutil.CreateAlias(runCron, "run cron"))
 
+	var localRoot string
+	var localOut string
+	var datumLimit int64
+	var diffOutput bool
+	var uploadOutput string
+	runLocal := &cobra.Command{
+		Use:   "{{alias}} <pipeline-spec>",
+		Short: "Run a pipeline's transform on this machine, without Kubernetes.",
+		Long:  "Run a pipeline's transform on this machine, without Kubernetes. The pipeline's datums are computed from the current heads of its input branches in the cluster, and each datum is downloaded into a local directory with the same layout as /pfs in a worker. The transform's cmd then runs as a local process in the current directory, with the same environment variables that it's given in a worker (e.g. $<input name> is the path of each input), and each datum's output is merged into the --out directory. The output can be diffed against the pipeline's output branch or uploaded to a branch. The pipeline doesn't need to exist in the cluster.",
+		Example: `
+		# Run the pipeline in edges.json, and print how its output differs from the pipeline's output branch
+		$ {{alias}} edges.json --diff
+
+		# Run the first 3 datums, and keep the output in ./out
+		$ {{alias}} edges.json --datums 3 --out ./out
+
+		# Run the pipeline with inputs under /pfs, as in a worker, and upload its output to the "local" branch of the "edges" repo
+		$ {{alias}} edges.json --root / --upload edges@local`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) (retErr error) {
+			pipelineReader, err := ppsutil.NewPipelineManifestReader(args[0])
+			if err != nil {
+				return err
+			}
+			request, err := pipelineReader.NextCreatePipelineRequest()
+			if err != nil {
+				return err
+			}
+			if _, err := pipelineReader.NextCreatePipelineRequest(); !errors.Is(err, io.EOF) {
+				return errors.New("run local runs one pipeline at a time")
+			}
+			if request.Pipeline == nil {
+				return errors.New("no `pipeline` specified")
+			}
+			var upload *pfs.Branch
+			if uploadOutput != "" {
+				if upload, err = cmdutil.ParseBranch(uploadOutput); err != nil {
+					return err
+				}
+			}
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			out := localOut
+			if out == "" {
+				if out, err = ioutil.TempDir("", request.Pipeline.Name+"-out-"); err != nil {
+					return errors.EnsureStack(err)
+				}
+			}
+			n, err := local.Run(client, request, &local.Options{
+				Root:       localRoot,
+				Out:        out,
+				DatumLimit: datumLimit,
+				Stdout:     os.Stdout,
+				Stderr:     os.Stderr,
+			})
+			fmt.Fprintf(os.Stderr, "processed %d datums, output is in %s\n", n, out)
+			if err != nil {
+				return err
+			}
+			if diffOutput {
+				branch := request.OutputBranch
+				if branch == "" {
+					branch = "master"
+				}
+				diffs, err := local.Diff(client, out, request.Pipeline.Name, branch)
+				if err != nil {
+					return err
+				}
+				for _, diff := range diffs {
+					fmt.Println(diff)
+				}
+			}
+			if upload != nil {
+				commit, err := local.Upload(client, out, upload.Repo.Name, upload.Name)
+				if err != nil {
+					return err
+				}
+				fmt.Fprintf(os.Stderr, "uploaded output to %s@%s\n", commit.Repo.Name, commit.ID)
+			}
+			return nil
+		}),
+	}
+	runLocal.Flags().StringVar(&localRoot, "root", "", "The directory in which to create the pfs directory that the transform reads its input from and writes its output to. Set it to / to use the same paths as a worker (which requires write access to /pfs). Defaults to a temporary directory.")
+	runLocal.Flags().StringVar(&localOut, "out", "", "The directory into which the output of every datum is merged. It must be empty or not exist yet. Defaults to a new temporary directory.")
+	runLocal.Flags().Int64Var(&datumLimit, "datums", 0, "If set, process only this many datums.")
+	runLocal.Flags().BoolVar(&diffOutput, "diff", false, "Print the files in which the output differs from the head of the pipeline's output branch.")
+	runLocal.Flags().StringVar(&uploadOutput, "upload", "", "Upload the output to a new commit on this branch (<repo>@<branch>), replacing the branch's previous contents. The repo is created if it doesn't exist.")
+	commands = append(commands, cmdutil.CreateAlias(runLocal, "run local"))
+
 	inspectPipeline := &cobra.Command{
 		Use:   "{{alias}} <pipeline>",
 		Short: "Return info about a pipeline.",
@@ -899,6 +1058,260 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	listPipeline.Flags().StringArrayVar(&stateStrs, "state", []string{}, "Return only pipelines with the specified state. Can be repeated to include multiple states")
 	commands = append(commands, cmdutil.CreateAlias(listPipeline, "list pipeline"))
 
+	listPipelineVersions := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Return info about each version of a pipeline.",
+		Long:  "Return info about each version of a pipeline, including the fields of its spec that changed since the previous version.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "error connecting to pachd")
+			}
+			defer client.Close()
+			pipelineInfos, err := client.ListPipelineHistory(args[0], -1)
+			if err != nil {
+				return err
+			}
+			if raw {
+				e := encoder(output)
+				for _, pipelineInfo := range pipelineInfos {
+					if err := e.EncodeProto(pipelineInfo); err != nil {
+						return err
+					}
+				}
+				return nil
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.PipelineVersionHeader)
+			// pipelineInfos are ordered from newest to oldest
+			for i, pipelineInfo := range pipelineInfos {
+				var changes []string
+				if i+1 < len(pipelineInfos) {
+					diffs, err := ppsutil.DiffPipelineSpecs(
+						ppsutil.PipelineReqFromInfo(pipelineInfos[i+1]),
+						ppsutil.PipelineReqFromInfo(pipelineInfo),
+					)
+					if err != nil {
+						return err
+					}
+					for _, diff := range diffs {
+						changes = append(changes, diff.Path)
+					}
+				}
+				pretty.PrintPipelineVersion(writer, pipelineInfo, changes, fullTimestamps)
+			}
+			return writer.Flush()
+		}),
+	}
+	listPipelineVersions.Flags().AddFlagSet(outputFlags)
+	listPipelineVersions.Flags().AddFlagSet(fullTimestampsFlags)
+	shell.RegisterCompletionFunc(listPipelineVersions, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(listPipelineVersions, "list pipeline-versions"))
+
+	diffPipeline := &cobra.Command{
+		Use:   "{{alias}} <pipeline> <from-version> [<to-version>]",
+		Short: "Show the differences between two versions of a pipeline's spec.",
+		Long:  "Show the fields that differ between two versions of a pipeline's spec. If <to-version> is omitted, the pipeline's current version is used.",
+		Run: cmdutil.RunBoundedArgs(2, 3, func(args []string) error {
+			fromVersion, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return errors.Errorf("invalid version %q", args[1])
+			}
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "error connecting to pachd")
+			}
+			defer client.Close()
+			from, err := client.InspectPipelineVersion(args[0], fromVersion)
+			if err != nil {
+				return err
+			}
+			var to *ppsclient.PipelineInfo
+			if len(args) == 3 {
+				toVersion, err := strconv.ParseUint(args[2], 10, 64)
+				if err != nil {
+					return errors.Errorf("invalid version %q", args[2])
+				}
+				to, err = client.InspectPipelineVersion(args[0], toVersion)
+				if err != nil {
+					return err
+				}
+			} else {
+				to, err = client.InspectPipeline(args[0])
+				if err != nil {
+					return err
+				}
+			}
+			diffs, err := ppsutil.DiffPipelineSpecs(ppsutil.PipelineReqFromInfo(from), ppsutil.PipelineReqFromInfo(to))
+			if err != nil {
+				return err
+			}
+			for _, diff := range diffs {
+				fmt.Println(diff)
+			}
+			return nil
+		}),
+	}
+	shell.RegisterCompletionFunc(diffPipeline, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(diffPipeline, "diff pipeline"))
+
+	var graphFormat string
+	drawPipeline := &cobra.Command{
+		Use:   "{{alias}} [<pipeline>...]",
+		Short: "Draw the graph of repos and pipelines.",
+		Long: `Draw the graph of repos and pipelines, including the structure of each pipeline's input and the state and last job state of each pipeline. If pipelines are given, only they and the repos and pipelines upstream of them are drawn.
+
+The graph can be output as JSON, as Graphviz DOT or as a Mermaid flowchart.`,
+		Example: `
+# Render the whole DAG as an SVG with Graphviz
+$ {{alias}} --format dot | dot -Tsvg > dag.svg
+
+# Print a Mermaid flowchart of "montage" and everything upstream of it
+$ {{alias}} montage --format mermaid`,
+		Run: cmdutil.Run(func(args []string) error {
+			format, ok := ppsclient.GraphFormat_value["GRAPH_"+strings.ToUpper(graphFormat)]
+			if !ok {
+				return errors.Errorf("unrecognized format %q; must be one of json, dot or mermaid", graphFormat)
+			}
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "error connecting to pachd")
+			}
+			defer client.Close()
+			response, err := client.DrawPipeline(ppsclient.GraphFormat(format), args...)
+			if err != nil {
+				return err
+			}
+			fmt.Print(response.Text)
+			return nil
+		}),
+	}
+	drawPipeline.Flags().StringVar(&graphFormat, "format", "dot", "The format in which to draw the graph: json, dot or mermaid.")
+	shell.RegisterCompletionFunc(drawPipeline, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(drawPipeline, "draw pipeline"))
+
+	rollbackPipeline := &cobra.Command{
+		Use:   "{{alias}} <pipeline> <version>",
+		Short: "Update a pipeline to the spec of one of its earlier versions.",
+		Long:  "Update a pipeline to the spec of one of its earlier versions. This creates a new version of the pipeline, so the rollback itself can be rolled back.",
+		Run: cmdutil.RunFixedArgs(2, func(args []string) error {
+			version, err := strconv.ParseUint(args[1], 10, 64)
+			if err != nil {
+				return errors.Errorf("invalid version %q", args[1])
+			}
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "error connecting to pachd")
+			}
+			defer client.Close()
+			pipelineInfo, err := client.InspectPipelineVersion(args[0], version)
+			if err != nil {
+				return err
+			}
+			request := ppsutil.PipelineReqFromInfo(pipelineInfo)
+			request.Update = true
+			request.Reprocess = reprocess
+			if _, err := client.PpsAPIClient.CreatePipeline(
+				client.Ctx(),
+				request,
+			); err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			return nil
+		}),
+	}
+	rollbackPipeline.Flags().BoolVar(&reprocess, "reprocess", false, "If true, reprocess datums that were already processed by previous version of the pipeline.")
+	shell.RegisterCompletionFunc(rollbackPipeline, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(rollbackPipeline, "rollback pipeline"))
+
+	var maxPaths int64
+	inspectCanary := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Compare the outputs of a pipeline's canary with the pipeline's.",
+		Long:  "Compare the outputs of a pipeline's canary, created by 'update pipeline --canary', with the pipeline's outputs, at the heads of their output branches. Files only in the canary's output are added, files only in the pipeline's output are removed (unless the canary samples datums) and files in both with different contents are changed.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "error connecting to pachd")
+			}
+			defer client.Close()
+			response, err := client.InspectCanary(args[0], maxPaths)
+			if err != nil {
+				return err
+			}
+			if raw {
+				return encoder(output).EncodeProto(response)
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			pretty.PrintCanaryResponse(os.Stdout, response)
+			return nil
+		}),
+	}
+	inspectCanary.Flags().Int64Var(&maxPaths, "max-paths", 0, "The maximum number of added, removed and changed paths to print. If 0, at most 100 of each are printed.")
+	inspectCanary.Flags().AddFlagSet(outputFlags)
+	shell.RegisterCompletionFunc(inspectCanary, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(inspectCanary, "inspect canary"))
+
+	promoteCanary := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Replace a pipeline with its canary.",
+		Long:  "Update a pipeline to the spec of its canary, created by 'update pipeline --canary', and delete the canary.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "error connecting to pachd")
+			}
+			defer client.Close()
+			canaryInfo, err := client.InspectPipeline(pachdclient.CanaryPipeline(args[0]))
+			if err != nil {
+				return err
+			}
+			if canaryInfo.Canary == nil || canaryInfo.Canary.Pipeline.Name != args[0] {
+				return errors.Errorf("pipeline %q is not a canary of pipeline %q", canaryInfo.Pipeline.Name, args[0])
+			}
+			request := ppsutil.PipelineReqFromInfo(canaryInfo)
+			request.Pipeline = canaryInfo.Canary.Pipeline
+			request.Canary = nil
+			request.Update = true
+			request.Reprocess = reprocess
+			if _, err := client.PpsAPIClient.CreatePipeline(
+				client.Ctx(),
+				request,
+			); err != nil {
+				return grpcutil.ScrubGRPC(err)
+			}
+			return client.DeletePipeline(canaryInfo.Pipeline.Name, false)
+		}),
+	}
+	promoteCanary.Flags().BoolVar(&reprocess, "reprocess", false, "If true, reprocess datums that were already processed by previous version of the pipeline.")
+	shell.RegisterCompletionFunc(promoteCanary, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(promoteCanary, "promote canary"))
+
+	discardCanary := &cobra.Command{
+		Use:   "{{alias}} <pipeline>",
+		Short: "Delete a pipeline's canary.",
+		Long:  "Delete a pipeline's canary, created by 'update pipeline --canary', along with its output repo. The pipeline isn't changed.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return errors.Wrapf(err, "error connecting to pachd")
+			}
+			defer client.Close()
+			canaryInfo, err := client.InspectPipeline(pachdclient.CanaryPipeline(args[0]))
+			if err != nil {
+				return err
+			}
+			if canaryInfo.Canary == nil || canaryInfo.Canary.Pipeline.Name != args[0] {
+				return errors.Errorf("pipeline %q is not a canary of pipeline %q", canaryInfo.Pipeline.Name, args[0])
+			}
+			return client.DeletePipeline(canaryInfo.Pipeline.Name, false)
+		}),
+	}
+	shell.RegisterCompletionFunc(discardCanary, shell.PipelineCompletion)
+	commands = append(commands, cmdutil.CreateAlias(discardCanary, "discard canary"))
+
 	var (
 		all              bool
 		force            bool
@@ -1097,6 +1510,120 @@ All jobs created by a pipeline will create commits in the pipeline's output repo
 	}
 	commands = append(commands, cmdutil.CreateAlias(listSecret, "list secret"))
 
+	var templatePath string
+	var templateParams []string
+	var propagate bool
+	createTemplate := &cobra.Command{
+		Use:   "{{alias}} <template>",
+		Short: "Create a new pipeline template.",
+		Long: `Create a new pipeline template.
+
+A pipeline template is a pipeline spec, in JSON or YAML, that is rendered as a
+Go text/template. Each of the template's parameters is declared with --param,
+as <name>[:<type>][=<default>], where type is string (the default), int or
+bool. A string parameter can have an empty default, e.g. --param suffix=.
+Parameters without a default must be passed, with --arg, to every
+"pachctl create pipeline --template" that instantiates the template.`,
+		Example: `
+# Create a template whose spec refers to {{ .customer }} and {{ .workers }}
+$ {{alias}} ingest -f ingest.yaml --param customer --param workers:int=2`,
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			return templateHelper(args[0], templatePath, templateParams, false, false)
+		}),
+	}
+	createTemplate.Flags().StringVarP(&templatePath, "file", "f", "-", "The file containing the template's pipeline spec, it can be a url or local file. - reads from stdin.")
+	createTemplate.Flags().StringArrayVar(&templateParams, "param", nil, "A parameter of the template, as <name>[:<type>][=<default>] (can be repeated).")
+	commands = append(commands, cmdutil.CreateAlias(createTemplate, "create template"))
+
+	updateTemplate := &cobra.Command{
+		Use:   "{{alias}} <template>",
+		Short: "Update an existing pipeline template.",
+		Long:  "Update a pipeline template with a new spec and parameters. Pipelines instantiated from the template keep using its previous version unless --propagate is set.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			return templateHelper(args[0], templatePath, templateParams, true, propagate)
+		}),
+	}
+	updateTemplate.Flags().StringVarP(&templatePath, "file", "f", "-", "The file containing the template's pipeline spec, it can be a url or local file. - reads from stdin.")
+	updateTemplate.Flags().StringArrayVar(&templateParams, "param", nil, "A parameter of the template, as <name>[:<type>][=<default>] (can be repeated).")
+	updateTemplate.Flags().BoolVar(&propagate, "propagate", false, "If true, update every pipeline instantiated from the template to the new version, with the same arguments.")
+	commands = append(commands, cmdutil.CreateAlias(updateTemplate, "update template"))
+
+	inspectTemplate := &cobra.Command{
+		Use:   "{{alias}} <template>",
+		Short: "Return info about a pipeline template.",
+		Long:  "Return info about a pipeline template.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			templateInfo, err := client.InspectTemplate(args[0])
+			if err != nil {
+				return err
+			}
+			if raw {
+				return encoder(output).EncodeProto(templateInfo)
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			pretty.PrintDetailedTemplateInfo(os.Stdout, templateInfo)
+			return nil
+		}),
+	}
+	inspectTemplate.Flags().AddFlagSet(outputFlags)
+	commands = append(commands, cmdutil.CreateAlias(inspectTemplate, "inspect template"))
+
+	listTemplate := &cobra.Command{
+		Short: "Return info about all pipeline templates.",
+		Long:  "Return info about all pipeline templates.",
+		Run: cmdutil.RunFixedArgs(0, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			templateInfos, err := client.ListTemplate()
+			if err != nil {
+				return err
+			}
+			if raw {
+				e := encoder(output)
+				for _, templateInfo := range templateInfos {
+					if err := e.EncodeProto(templateInfo); err != nil {
+						return err
+					}
+				}
+				return nil
+			} else if output != "" {
+				cmdutil.ErrorAndExit("cannot set --output (-o) without --raw")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, pretty.TemplateHeader)
+			for _, templateInfo := range templateInfos {
+				pretty.PrintTemplateInfo(writer, templateInfo, fullTimestamps)
+			}
+			return writer.Flush()
+		}),
+	}
+	listTemplate.Flags().AddFlagSet(outputFlags)
+	listTemplate.Flags().AddFlagSet(fullTimestampsFlags)
+	commands = append(commands, cmdutil.CreateAlias(listTemplate, "list template"))
+
+	deleteTemplate := &cobra.Command{
+		Use:   "{{alias}} <template>",
+		Short: "Delete a pipeline template.",
+		Long:  "Delete a pipeline template. Pipelines instantiated from the template are not deleted.",
+		Run: cmdutil.RunFixedArgs(1, func(args []string) error {
+			client, err := pachdclient.NewOnUserMachine("user")
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			return client.DeleteTemplate(args[0])
+		}),
+	}
+	commands = append(commands, cmdutil.CreateAlias(deleteTemplate, "delete template"))
+
 	var memory string
 	garbageCollect := &cobra.Command{
 		Short: "Garbage collect unused data.",
@@ -1142,7 +1669,135 @@ you can increase the amount of memory used for the bloom filters with the
 	return commands
 }
 
-func pipelineHelper(reprocess bool, build bool, pushImages bool, registry, username, pipelinePath string, update bool) error {
+func templateHelper(name, templatePath string, params []string, update, propagate bool) error {
+	spec, err := ppsutil.ReadPipelineSpec(templatePath)
+	if err != nil {
+		return err
+	}
+	var parameters []*ppsclient.TemplateParameter
+	for _, param := range params {
+		parameter, err := parseTemplateParameter(param)
+		if err != nil {
+			return err
+		}
+		parameters = append(parameters, parameter)
+	}
+	if !update && propagate {
+		return errors.New("--propagate can only be used when updating a template")
+	}
+
+	pc, err := pachdclient.NewOnUserMachine("user")
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to pachd")
+	}
+	defer pc.Close()
+	return pc.CreateTemplate(name, string(spec), parameters, update, propagate)
+}
+
+// parseTemplateParameter parses a template parameter from
+// <name>[:<type>][=<default>]
+func parseTemplateParameter(param string) (*ppsclient.TemplateParameter, error) {
+	result := &ppsclient.TemplateParameter{}
+	if i := strings.Index(param, "="); i >= 0 {
+		param, result.Default = param[:i], param[i+1:]
+		result.HasDefault = true
+	}
+	if i := strings.Index(param, ":"); i >= 0 {
+		var paramType string
+		param, paramType = param[:i], param[i+1:]
+		t, ok := ppsclient.TemplateParameterType_value["PARAMETER_"+strings.ToUpper(paramType)]
+		if !ok {
+			return nil, errors.Errorf("parameter %q has unknown type %q, must be string, int or bool", param, paramType)
+		}
+		result.Type = ppsclient.TemplateParameterType(t)
+	}
+	result.Name = param
+	return result, nil
+}
+
+func templatePipelineHelper(template string, args []string, update, reprocess bool) error {
+	templateArgs := make(map[string]string)
+	for _, arg := range args {
+		i := strings.Index(arg, "=")
+		if i < 0 {
+			return errors.Errorf("invalid argument %q, must be <name>=<value>", arg)
+		}
+		templateArgs[arg[:i]] = arg[i+1:]
+	}
+
+	pc, err := pachdclient.NewOnUserMachine("user")
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to pachd")
+	}
+	defer pc.Close()
+	_, err = pc.PpsAPIClient.CreatePipeline(
+		pc.Ctx(),
+		&ppsclient.CreatePipelineRequest{
+			Template: &ppsclient.TemplateRef{
+				Template: &ppsclient.PipelineTemplate{Name: template},
+				Args:     templateArgs,
+			},
+			Update:    update,
+			Reprocess: reprocess,
+		},
+	)
+	return grpcutil.ScrubGRPC(err)
+}
+
+func dryRunPipelineHelper(pipelinePath string, sampleSize int64, raw bool, output string) error {
+	if sampleSize < 0 {
+		return errors.Errorf("sample must be zero or positive")
+	}
+	if !raw && output != "" {
+		return errors.New("cannot set --output (-o) without --raw")
+	}
+	pipelineReader, err := ppsutil.NewPipelineManifestReader(pipelinePath)
+	if err != nil {
+		return err
+	}
+
+	pc, err := pachdclient.NewOnUserMachine("user")
+	if err != nil {
+		return errors.Wrapf(err, "error connecting to pachd")
+	}
+	defer pc.Close()
+
+	for {
+		request, err := pipelineReader.NextCreatePipelineRequest()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+		if request.Pipeline == nil {
+			return errors.New("no `pipeline` specified")
+		}
+		response, err := pc.DryRunPipeline(request, sampleSize)
+		if err != nil {
+			return err
+		}
+		if raw {
+			if err := encoder(output).EncodeProto(response); err != nil {
+				return err
+			}
+			continue
+		}
+		pretty.PrintDryRunPipelineResponse(os.Stdout, request.Pipeline.Name, response)
+		if len(response.Datums) > 0 {
+			fmt.Printf("Sample (%d of %d datums):\n", len(response.Datums), response.DatumCount)
+			writer := tabwriter.NewWriter(os.Stdout, pretty.DryRunDatumHeader)
+			for _, datumInfo := range response.Datums {
+				pretty.PrintDryRunDatum(writer, datumInfo)
+			}
+			if err := writer.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func pipelineHelper(reprocess bool, build bool, pushImages bool, registry, username, pipelinePath string, update bool, canary *ppsclient.CanarySpec) error {
 	if build && pushImages {
 		logrus.Warning("`--push-images` is redundant, as it's already enabled with `--build`")
 	}
@@ -1250,6 +1905,15 @@ func pipelineHelper(reprocess bool, build bool, pushImages bool, registry, usern
 			}
 		}
 
+		if canary != nil {
+			// Run the new version alongside the pipeline, rather than replacing it
+			request.Canary = &ppsclient.CanarySpec{
+				Pipeline:      request.Pipeline,
+				SamplePercent: canary.SamplePercent,
+			}
+			request.Pipeline = pachdclient.NewPipeline(pachdclient.CanaryPipeline(request.Pipeline.Name))
+		}
+
 		if _, err := pc.PpsAPIClient.CreatePipeline(
 			pc.Ctx(),
 			request,
@@ -1574,3 +2238,20 @@ func ParsePipelineStates(stateStrs []string) (string, error) {
 	}
 	return validateJQConditionString(strings.Join(conditions, " or "))
 }
+
+// parseLogTime parses the argument of 'pachctl logs --since' or '--until',
+// which is either a duration before now or an RFC 3339 timestamp. An empty
+// argument is parsed as the zero time.
+func parseLogTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return time.Now().Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, errors.Errorf("%q is neither a duration nor an RFC 3339 timestamp", s)
+	}
+	return t, nil
+}
@@ -619,6 +619,54 @@ func TestEditPipeline(t *testing.T) {
 		`).Run())
 }
 
+func TestPipelineVersions(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration tests in short mode")
+	}
+	require.NoError(t, tu.BashCmd(`
+		yes | pachctl delete all
+	`).Run())
+	require.NoError(t, tu.BashCmd(`
+		pachctl create repo data
+		pachctl create pipeline <<EOF
+		  pipeline:
+		    name: my-pipeline
+		  input:
+		    pfs:
+		      glob: /*
+		      repo: data
+		  transform:
+		    cmd: [ /bin/bash ]
+		    stdin:
+		      - "cp /pfs/data/* /pfs/out"
+		EOF
+		pachctl update pipeline <<EOF
+		  pipeline:
+		    name: my-pipeline
+		  input:
+		    pfs:
+		      glob: /
+		      repo: data
+		  transform:
+		    cmd: [ /bin/bash ]
+		    stdin:
+		      - "cp -r /pfs/data/* /pfs/out"
+		EOF
+		`).Run())
+	require.NoError(t, tu.BashCmd(`
+		pachctl list pipeline-versions my-pipeline \
+		| match 'input.pfs.glob, transform.stdin\[0\]'
+		pachctl diff pipeline my-pipeline 1 2 \
+		| match '~ input.pfs.glob: "/\*" -> "/"'
+		pachctl rollback pipeline my-pipeline 1
+		pachctl inspect pipeline my-pipeline --raw \
+		| match '"version": "3"' \
+		| match '"glob": "/\*"'
+		pachctl diff pipeline my-pipeline 1 \
+		| match -v glob
+		`).Run())
+}
+
 func TestPipelineBuildLifecyclePython(t *testing.T) {
 	if os.Getenv("RUN_BAD_TESTS") == "" {
 		t.Skip("Skipping because RUN_BAD_TESTS was empty")
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -17,20 +18,30 @@ import (
 	pfsclient "github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/pretty"
 )
 
 const (
 	// PipelineHeader is the header for pipelines.
-	PipelineHeader = "NAME\tVERSION\tINPUT\tCREATED\tSTATE / LAST JOB\tDESCRIPTION\t\n"
+	PipelineHeader = "NAME\tVERSION\tTEMPLATE\tTEMPLATE VERSION\tINPUT\tCREATED\tSTATE / LAST JOB\tDESCRIPTION\t\n"
+	// PipelineVersionHeader is the header for the versions of a pipeline
+	PipelineVersionHeader = "VERSION\tSPEC COMMIT\tCREATED\tCHANGES\t\n"
 	// JobHeader is the header for jobs
 	JobHeader = "ID\tPIPELINE\tSTARTED\tDURATION\tRESTART\tPROGRESS\tDL\tUL\tSTATE\t\n"
 	// DatumHeader is the header for datums
 	DatumHeader = "ID\tFILES\tSTATUS\tTIME\t\n"
 	// SecretHeader is the header for secrets
 	SecretHeader = "NAME\tTYPE\tCREATED\t\n"
+	// TemplateHeader is the header for pipeline templates
+	TemplateHeader = "NAME\tVERSION\tPARAMETERS\tCREATED\t\n"
+	// DryRunDatumHeader is the header for the datums of a pipeline dry run
+	DryRunDatumHeader = "FILES\tSIZE\t\n"
 	// jobReasonLen is the amount of the job reason that we print
 	jobReasonLen = 25
+	// pipelineChangesLen is the amount of a pipeline version's changes that we
+	// print
+	pipelineChangesLen = 80
 )
 
 func safeTrim(s string, l int) string {
@@ -73,11 +84,20 @@ func PrintPipelineInfo(w io.Writer, pipelineInfo *ppsclient.PipelineInfo, fullTi
 		fmt.Fprint(w, "-\t")
 		fmt.Fprint(w, "-\t")
 		fmt.Fprint(w, "-\t")
+		fmt.Fprint(w, "-\t")
+		fmt.Fprint(w, "-\t")
 		fmt.Fprintf(w, "%s / %s\t", pipelineState(pipelineInfo.State), JobState(pipelineInfo.LastJobState))
 		fmt.Fprint(w, "could not retrieve pipeline spec\t")
 	} else {
 		fmt.Fprintf(w, "%s\t", pipelineInfo.Pipeline.Name)
 		fmt.Fprintf(w, "%d\t", pipelineInfo.Version)
+		if pipelineInfo.Template != nil {
+			fmt.Fprintf(w, "%s\t", pipelineInfo.Template.Template.Name)
+			fmt.Fprintf(w, "%d\t", pipelineInfo.Template.Version)
+		} else {
+			fmt.Fprint(w, "-\t")
+			fmt.Fprint(w, "-\t")
+		}
 		fmt.Fprintf(w, "%s\t", ShorthandInput(pipelineInfo.Input))
 		if fullTimestamps {
 			fmt.Fprintf(w, "%s\t", pipelineInfo.CreatedAt.String())
@@ -90,6 +110,28 @@ func PrintPipelineInfo(w io.Writer, pipelineInfo *ppsclient.PipelineInfo, fullTi
 	fmt.Fprintln(w)
 }
 
+// PrintPipelineVersion pretty-prints one version of a pipeline, along with
+// the paths of the spec fields that changed since its previous version.
+func PrintPipelineVersion(w io.Writer, pipelineInfo *ppsclient.PipelineInfo, changes []string, fullTimestamps bool) {
+	fmt.Fprintf(w, "%d\t", pipelineInfo.Version)
+	if pipelineInfo.SpecCommit != nil {
+		fmt.Fprintf(w, "%s\t", pipelineInfo.SpecCommit.ID)
+	} else {
+		fmt.Fprint(w, "-\t")
+	}
+	if fullTimestamps {
+		fmt.Fprintf(w, "%s\t", pipelineInfo.CreatedAt.String())
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(pipelineInfo.CreatedAt))
+	}
+	if len(changes) == 0 {
+		fmt.Fprint(w, "-\t")
+	} else {
+		fmt.Fprintf(w, "%s\t", safeTrim(strings.Join(changes, ", "), pipelineChangesLen))
+	}
+	fmt.Fprintln(w)
+}
+
 // PrintWorkerStatusHeader pretty prints a worker status header.
 func PrintWorkerStatusHeader(w io.Writer) {
 	fmt.Fprint(w, "WORKER\tJOB\tDATUM\tSTARTED\tQUEUE\t\n")
@@ -117,6 +159,7 @@ func PrintWorkerStatus(w io.Writer, workerStatus *ppsclient.WorkerStatus, fullTi
 type PrintableJobInfo struct {
 	*ppsclient.JobInfo
 	FullTimestamps bool
+	Resources      bool
 }
 
 // NewPrintableJobInfo constructs a PrintableJobInfo from just a JobInfo.
@@ -146,7 +189,11 @@ Data Downloaded: {{prettySize .Stats.DownloadBytes}}
 Data Uploaded: {{prettySize .Stats.UploadBytes}}
 Download Time: {{prettyDuration .Stats.DownloadTime}}
 Process Time: {{prettyDuration .Stats.ProcessTime}}
-Upload Time: {{prettyDuration .Stats.UploadTime}}
+Upload Time: {{prettyDuration .Stats.UploadTime}}{{if .Resources}}
+Resources:
+  CPU Time: {{prettyDuration .Stats.CpuTime}}
+  Peak Memory (largest datum): {{prettySize .Stats.PeakMemoryBytes}}
+  Disk Used (largest datum): {{prettySize .Stats.DiskBytes}}{{end}}
 Datum Timeout: {{.DatumTimeout}}
 Job Timeout: {{.JobTimeout}}
 Worker Status:
@@ -172,7 +219,8 @@ Transform:
 {{prettyTransform .Transform}} {{if .OutputCommit}}
 Output Commit: {{.OutputCommit.ID}} {{end}} {{ if .StatsCommit }}
 Stats Commit: {{.StatsCommit.ID}} {{end}} {{ if .Egress }}
-Egress: {{.Egress.URL}} {{end}}
+Egress: {{egressTarget .Egress}} {{end}} {{ if .EgressProgress }}
+Egress Progress: {{egressProgress .EgressProgress}} {{end}}
 `)
 	if err != nil {
 		return err
@@ -209,8 +257,10 @@ State: {{pipelineState .State}}
 Reason: {{.Reason}}
 Workers Available: {{.WorkersAvailable}}/{{.WorkersRequested}}
 Stopped: {{ .Stopped }}
-Parallelism Spec: {{.ParallelismSpec}}
-{{ if .ResourceRequests }}ResourceRequests:
+{{ if .Template }}Template: {{.Template.Template.Name}} (version {{.Template.Version}}{{templateArgs .Template.Args}})
+{{end}}Parallelism Spec: {{.ParallelismSpec}}
+{{ if .Autoscaling }}Autoscaling: {{autoscaling .Autoscaling}}
+{{end}}{{ if .ResourceRequests }}ResourceRequests:
   CPU: {{ .ResourceRequests.Cpu }}
   Memory: {{ .ResourceRequests.Memory }} {{end}}
 {{ if .ResourceLimits }}ResourceLimits:
@@ -221,14 +271,24 @@ Parallelism Spec: {{.ParallelismSpec}}
     Number: {{ .ResourceLimits.Gpu.Number }} {{end}} {{end}}
 Datum Timeout: {{.DatumTimeout}}
 Job Timeout: {{.JobTimeout}}
-Input:
+{{ if .Queue }}Queue: {{.Queue}}
+{{end}}{{ if .Priority }}Priority: {{.Priority}}
+{{end}}{{ if .GlobalDatumCache }}Global Datum Cache: enabled{{ if .GlobalDatumCacheOwner }} (owner {{.GlobalDatumCacheOwner}}){{end}}
+{{end}}{{ if .GlobalDatumCacheTrust }}Global Datum Cache Trust: {{join .GlobalDatumCacheTrust ", "}}
+{{end}}{{ if .PersistLogs }}Persist Logs: enabled
+{{end}}{{ if .Outputs }}Named Outputs: {{outputRepos .PipelineInfo}}
+{{end}}{{ if .Canary }}Canary Of: {{canary .Canary}}
+{{end}}Input:
 {{pipelineInput .PipelineInfo}}
 {{ if .GithookURL }}Githook URL: {{.GithookURL}} {{end}}
 Output Branch: {{.OutputBranch}}
-Transform:
+{{ if .FailedDatumBranch }}Failed Datum Branch: {{.FailedDatumBranch}}
+{{end}}Transform:
 {{prettyTransform .Transform}}
-{{ if .Egress }}Egress: {{.Egress.URL}} {{end}}
-{{if .RecentError}} Recent Error: {{.RecentError}} {{end}}
+{{ if .Egress }}Egress: {{egressTarget .Egress}} {{end}}
+{{ if .Notifications }}Notifications:
+{{notifications .Notifications}}
+{{end}}{{if .RecentError}} Recent Error: {{.RecentError}} {{end}}
 Job Counts:
 {{jobCounts .JobCounts}}
 `)
@@ -268,6 +328,61 @@ func datumFiles(datumInfo *ppsclient.DatumInfo) string {
 	return builder.String()
 }
 
+// PrintDryRunPipelineResponse pretty-prints a summary of the datums that a
+// pipeline dry run found
+func PrintDryRunPipelineResponse(w io.Writer, pipelineName string, response *ppsclient.DryRunPipelineResponse) {
+	fmt.Fprintf(w, "Pipeline: %s\n", pipelineName)
+	fmt.Fprintf(w, "Datums: %d\n", response.DatumCount)
+	fmt.Fprintf(w, "Estimated Input Size: %s\n", pretty.Size(uint64(response.EstimatedBytes)))
+	var commits []string
+	for _, commit := range response.InputCommits {
+		commits = append(commits, fmt.Sprintf("%s@%s", commit.Repo.Name, commit.ID))
+	}
+	if len(commits) > 0 {
+		fmt.Fprintf(w, "Input Commits: %s\n", strings.Join(commits, ", "))
+	}
+}
+
+// PrintCanaryResponse pretty-prints a summary of the difference between the
+// outputs of a canary and the outputs of the pipeline it's a canary of
+func PrintCanaryResponse(w io.Writer, response *ppsclient.InspectCanaryResponse) {
+	fmt.Fprintf(w, "Canary: %s\n", response.Canary.Name)
+	fmt.Fprintf(w, "Canary Of: %s\n", canary(response.Spec))
+	fmt.Fprintf(w, "Last Job State: %s\n", JobState(response.LastJobState))
+	fmt.Fprintf(w, "Files Added: %d\n", response.FilesAdded)
+	fmt.Fprintf(w, "Files Removed: %d\n", response.FilesRemoved)
+	fmt.Fprintf(w, "Files Changed: %d\n", response.FilesChanged)
+	fmt.Fprintf(w, "Files Unchanged: %d\n", response.FilesUnchanged)
+	for _, list := range []struct {
+		name  string
+		paths []string
+	}{
+		{"Added", response.Added},
+		{"Removed", response.Removed},
+		{"Changed", response.Changed},
+	} {
+		if len(list.paths) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "%s:\n", list.name)
+		for _, p := range list.paths {
+			fmt.Fprintf(w, "  %s\n", p)
+		}
+	}
+	if response.Truncated {
+		fmt.Fprintf(w, "(some paths were omitted, use --max-paths to see more)\n")
+	}
+}
+
+// PrintDryRunDatum pretty-prints one of the datums found by a pipeline dry run
+func PrintDryRunDatum(w io.Writer, datumInfo *ppsclient.DatumInfo) {
+	var size uint64
+	for _, fi := range datumInfo.Data {
+		size += fi.SizeBytes
+	}
+	fmt.Fprintf(w, "%s\t%s\t\n", datumFiles(datumInfo), pretty.Size(size))
+}
+
 // PrintDetailedDatumInfo pretty-prints detailed info about a datum
 func PrintDetailedDatumInfo(w io.Writer, datumInfo *ppsclient.DatumInfo) {
 	fmt.Fprintf(w, "ID\t%s\n", datumInfo.Datum.ID)
@@ -306,6 +421,17 @@ func PrintDetailedDatumInfo(w io.Writer, datumInfo *ppsclient.DatumInfo) {
 	}
 	fmt.Fprintf(w, "Upload Time\t%s\n", uploadTime)
 
+	var cpuTime string
+	cpu, err := types.DurationFromProto(datumInfo.Stats.CpuTime)
+	if err != nil {
+		cpuTime = err.Error()
+	} else {
+		cpuTime = cpu.String()
+	}
+	fmt.Fprintf(w, "CPU Time\t%s\n", cpuTime)
+	fmt.Fprintf(w, "Peak Memory\t%s\n", pretty.Size(datumInfo.Stats.PeakMemoryBytes))
+	fmt.Fprintf(w, "Disk Used\t%s\n", pretty.Size(datumInfo.Stats.DiskBytes))
+
 	fmt.Fprintf(w, "PFS State:\n")
 	tw := ansiterm.NewTabWriter(w, 10, 1, 3, ' ', 0)
 	PrintFileHeader(tw)
@@ -325,6 +451,62 @@ func PrintSecretInfo(w io.Writer, secretInfo *ppsclient.SecretInfo) {
 	fmt.Fprintf(w, "%s\t%s\t%s\t\n", secretInfo.Secret.Name, secretInfo.Type, pretty.Ago(secretInfo.CreationTimestamp))
 }
 
+// PrintTemplateInfo pretty-prints pipeline template info.
+func PrintTemplateInfo(w io.Writer, templateInfo *ppsclient.TemplateInfo, fullTimestamps bool) {
+	var params []string
+	for _, param := range templateInfo.Parameters {
+		params = append(params, param.Name)
+	}
+	fmt.Fprintf(w, "%s\t%d\t%s\t", templateInfo.Template.Name, templateInfo.Version, strings.Join(params, ", "))
+	if fullTimestamps {
+		fmt.Fprintf(w, "%s\t", templateInfo.CreatedAt.String())
+	} else {
+		fmt.Fprintf(w, "%s\t", pretty.Ago(templateInfo.CreatedAt))
+	}
+	fmt.Fprintln(w)
+}
+
+// PrintDetailedTemplateInfo pretty-prints detailed pipeline template info.
+func PrintDetailedTemplateInfo(w io.Writer, templateInfo *ppsclient.TemplateInfo) {
+	fmt.Fprintf(w, "Name: %s\n", templateInfo.Template.Name)
+	fmt.Fprintf(w, "Version: %d\n", templateInfo.Version)
+	fmt.Fprintf(w, "Created: %s\n", pretty.Ago(templateInfo.CreatedAt))
+	if templateInfo.Owner != "" {
+		fmt.Fprintf(w, "Owner: %s\n", templateInfo.Owner)
+	}
+	fmt.Fprintf(w, "Parameters:\n")
+	for _, param := range templateInfo.Parameters {
+		fmt.Fprintf(w, "  %s (%s)", param.Name, templateParameterType(param.Type))
+		if ppsutil.HasDefault(param) {
+			fmt.Fprintf(w, ", default %q", param.Default)
+		} else {
+			fmt.Fprintf(w, ", required")
+		}
+		if param.Description != "" {
+			fmt.Fprintf(w, ": %s", param.Description)
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "Spec:\n%s\n", strings.TrimRight(templateInfo.Spec, "\n"))
+}
+
+func templateParameterType(t ppsclient.TemplateParameterType) string {
+	return strings.ToLower(strings.TrimPrefix(t.String(), "PARAMETER_"))
+}
+
+func templateArgs(args map[string]string) string {
+	var keys []string
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	builder := &strings.Builder{}
+	for _, k := range keys {
+		fmt.Fprintf(builder, ", %s=%s", k, args[k])
+	}
+	return builder.String()
+}
+
 // PrintFileHeader prints the header for a pfs file.
 func PrintFileHeader(w io.Writer) {
 	fmt.Fprintf(w, "  REPO\tCOMMIT\tPATH\t\n")
@@ -354,6 +536,8 @@ func JobState(jobState ppsclient.JobState) string {
 	switch jobState {
 	case ppsclient.JobState_JOB_STARTING:
 		return color.New(color.FgYellow).SprintFunc()("starting")
+	case ppsclient.JobState_JOB_QUEUED:
+		return color.New(color.FgYellow).SprintFunc()("queued")
 	case ppsclient.JobState_JOB_RUNNING:
 		return color.New(color.FgYellow).SprintFunc()("running")
 	case ppsclient.JobState_JOB_MERGING:
@@ -455,6 +639,14 @@ func ShorthandInput(input *ppsclient.Input) string {
 	case input == nil:
 		return "none"
 	case input.Pfs != nil:
+		if window := input.Pfs.Window; window != nil {
+			if window.Commits > 0 {
+				return fmt.Sprintf("%s:%s (last %d commits)", input.Pfs.Repo, input.Pfs.Glob, window.Commits)
+			}
+			if d, err := types.DurationFromProto(window.Duration); err == nil {
+				return fmt.Sprintf("%s:%s (last %s)", input.Pfs.Repo, input.Pfs.Glob, d)
+			}
+		}
 		return fmt.Sprintf("%s:%s", input.Pfs.Repo, input.Pfs.Glob)
 	case input.Cross != nil:
 		var subInput []string
@@ -481,11 +673,80 @@ func ShorthandInput(input *ppsclient.Input) string {
 		}
 		return "(" + strings.Join(subInput, " ∪ ") + ")"
 	case input.Cron != nil:
+		if input.Cron.Timezone != "" {
+			return fmt.Sprintf("%s:%s (%s)", input.Cron.Name, input.Cron.Spec, input.Cron.Timezone)
+		}
 		return fmt.Sprintf("%s:%s", input.Cron.Name, input.Cron.Spec)
 	}
 	return ""
 }
 
+func egressTarget(egress *ppsclient.Egress) string {
+	switch {
+	case egress.SQL != nil:
+		return fmt.Sprintf("%s table %s (%s)", egress.SQL.Driver, egress.SQL.Table, egress.SQL.FileFormat)
+	case egress.HTTP != nil:
+		method := egress.HTTP.Method
+		if method == "" {
+			method = "POST"
+		}
+		return fmt.Sprintf("%s %s", method, egress.HTTP.URL)
+	case egress.Queue != nil:
+		return fmt.Sprintf("%s topic %s", egress.Queue.Kind, egress.Queue.Topic)
+	}
+	return egress.URL
+}
+
+func notifications(notifications []*ppsclient.Notification) string {
+	var lines []string
+	for _, notification := range notifications {
+		target := "repo " + notification.Repo
+		if notification.Webhook != nil {
+			target = "webhook " + notification.Webhook.URL
+		}
+		events := "all events"
+		if len(notification.Events) > 0 {
+			var names []string
+			for _, e := range notification.Events {
+				names = append(names, e.String())
+			}
+			events = strings.Join(names, ", ")
+		}
+		lines = append(lines, fmt.Sprintf("  %s (%s)", target, events))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func egressProgress(progress *ppsclient.EgressProgress) string {
+	return fmt.Sprintf("%d/%d files, %s", progress.FilesEgressed, progress.FilesTotal, pretty.Size(progress.BytesEgressed))
+}
+
+func autoscaling(spec *ppsclient.AutoscalingSpec) string {
+	if spec.TargetDatumsPerWorker == 0 {
+		return fmt.Sprintf("%d-%d workers", spec.MinWorkers, spec.MaxWorkers)
+	}
+	return fmt.Sprintf("%d-%d workers, %d datums per worker", spec.MinWorkers, spec.MaxWorkers, spec.TargetDatumsPerWorker)
+}
+
+// outputRepos lists a pipeline's named outputs and the repos they're committed
+// to, e.g. "good (edges_good), rejected (edges_rejected)"
+func outputRepos(pipelineInfo *ppsclient.PipelineInfo) string {
+	var outputs []string
+	for _, output := range pipelineInfo.Outputs {
+		outputs = append(outputs, fmt.Sprintf("%s (%s)", output, client.OutputRepo(pipelineInfo.Pipeline.Name, output)))
+	}
+	return strings.Join(outputs, ", ")
+}
+
+// canary describes the pipeline that a canary is a canary of and the datums
+// that it samples, e.g. "edges (10% of datums)"
+func canary(spec *ppsclient.CanarySpec) string {
+	if spec.SamplePercent == 0 || spec.SamplePercent >= 100 {
+		return fmt.Sprintf("%s (all datums)", spec.Pipeline.Name)
+	}
+	return fmt.Sprintf("%s (%d%% of datums)", spec.Pipeline.Name, spec.SamplePercent)
+}
+
 var funcMap = template.FuncMap{
 	"pipelineState":        pipelineState,
 	"jobState":             JobState,
@@ -499,4 +760,12 @@ var funcMap = template.FuncMap{
 	"prettySize":           pretty.Size,
 	"jobCounts":            jobCounts,
 	"prettyTransform":      prettyTransform,
+	"egressTarget":         egressTarget,
+	"egressProgress":       egressProgress,
+	"autoscaling":          autoscaling,
+	"templateArgs":         templateArgs,
+	"notifications":        notifications,
+	"outputRepos":          outputRepos,
+	"canary":               canary,
+	"join":                 strings.Join,
 }
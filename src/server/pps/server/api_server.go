@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"net/url"
 	"path"
 	"path/filepath"
 	"sort"
@@ -31,10 +32,12 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/ancestry"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
 	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/cronutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
 	"github.com/pachyderm/pachyderm/src/server/pkg/log"
 	"github.com/pachyderm/pachyderm/src/server/pkg/lokiutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/metrics"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
 	ppath "github.com/pachyderm/pachyderm/src/server/pkg/path"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
@@ -53,7 +56,6 @@ import (
 	"github.com/gogo/protobuf/jsonpb"
 	"github.com/gogo/protobuf/types"
 	opentracing "github.com/opentracing/opentracing-go"
-	"github.com/robfig/cron"
 	logrus "github.com/sirupsen/logrus"
 	"github.com/willf/bloom"
 	"golang.org/x/net/context"
@@ -118,15 +120,22 @@ type apiServer struct {
 	crashingMonitorCancels map[string]func() // also protected by monitorCancelsMu
 	pollPipelinesMu        sync.Mutex
 	pollCancel             func() // protected by pollPipelinesMu
+	notifyMu               sync.Mutex
+	notifyCancel           func() // protected by notifyMu
 	workerUsesRoot         bool
 	workerGrpcPort         uint16
 	port                   uint16
 	httpPort               uint16
 	peerPort               uint16
 	gcPercent              int
+	// queueLimits and priorityClasses are parsed from pachd's configuration
+	queueLimits     map[string]int64
+	priorityClasses map[int64]string
 	// collections
 	pipelines col.Collection
 	jobs      col.Collection
+	templates col.Collection
+	jobQueues col.Collection
 }
 
 func merge(from, to map[string]bool) {
@@ -189,6 +198,26 @@ func validateNames(names map[string]bool, input *pps.Input) error {
 	return nil
 }
 
+// validateWindow checks that exactly one of 'window's bounds is set
+func validateWindow(window *pps.PFSWindow) error {
+	if window.Commits < 0 {
+		return errors.Errorf("window cannot have a negative number of commits")
+	}
+	if window.Duration != nil {
+		d, err := types.DurationFromProto(window.Duration)
+		if err != nil {
+			return errors.Wrapf(err, "invalid window duration")
+		}
+		if d <= 0 {
+			return errors.Errorf("window duration must be positive")
+		}
+	}
+	if (window.Commits > 0) == (window.Duration != nil) {
+		return errors.Errorf("window must specify exactly one of 'commits' and 'duration'")
+	}
+	return nil
+}
+
 func (a *apiServer) validateInput(pachClient *client.APIClient, pipelineName string, input *pps.Input, job bool) error {
 	if err := validateNames(make(map[string]bool), input); err != nil {
 		return err
@@ -228,6 +257,20 @@ func (a *apiServer) validateInput(pachClient *client.APIClient, pipelineName str
 						"'empty_files', as 's3' requires input data to be accessed via " +
 						"Pachyderm's S3 gateway rather than the file system")
 				}
+				if window := input.Pfs.Window; window != nil {
+					if err := validateWindow(window); err != nil {
+						return err
+					}
+					switch {
+					case input.Pfs.S3:
+						return errors.Errorf("input cannot specify both 's3' and " +
+							"'window', as 's3' inputs expose a single commit")
+					case input.Pfs.JoinOn != "" || input.Pfs.GroupBy != "":
+						return errors.Errorf("input cannot specify 'window' with " +
+							"'join_on' or 'group_by', as a windowed input already " +
+							"groups files with the same path")
+					}
+				}
 				// Note that input.Pfs.Commit is empty if a) this is a job b) one of
 				// the job pipeline's input branches has no commits yet
 				if job && input.Pfs.Commit != "" {
@@ -290,9 +333,12 @@ func (a *apiServer) validateInput(pachClient *client.APIClient, pipelineName str
 				if len(input.Cron.Name) == 0 {
 					return errors.Errorf("input must specify a name")
 				}
-				if _, err := cron.ParseStandard(input.Cron.Spec); err != nil {
+				if _, err := cronutil.ParseSchedule(input.Cron.Spec, input.Cron.Timezone, input.Cron.Jitter, input.Cron.Repo); err != nil {
 					return errors.Wrapf(err, "error parsing cron-spec")
 				}
+				if _, ok := pfs.CronCatchUp_name[int32(input.Cron.CatchUp)]; !ok {
+					return errors.Errorf("unknown cron catch_up %d", input.Cron.CatchUp)
+				}
 			}
 			if input.Git != nil {
 				if set {
@@ -571,6 +617,7 @@ func (a *apiServer) UpdateJobStateInTransaction(txnCtx *txnenv.TransactionContex
 	jobPtr.DataRecovered = request.DataRecovered
 	jobPtr.DataTotal = request.DataTotal
 	jobPtr.Stats = request.Stats
+	jobPtr.EgressProgress = request.EgressProgress
 
 	return ppsutil.UpdateJobState(a.pipelines.ReadWrite(txnCtx.Stm), jobs, jobPtr, request.State, request.Reason)
 }
@@ -843,22 +890,23 @@ func (a *apiServer) listJob(pachClient *client.APIClient, pipeline *pps.Pipeline
 
 func (a *apiServer) jobInfoFromPtr(pachClient *client.APIClient, jobPtr *pps.EtcdJobInfo, full bool) (*pps.JobInfo, error) {
 	result := &pps.JobInfo{
-		Job:           jobPtr.Job,
-		Pipeline:      jobPtr.Pipeline,
-		OutputRepo:    &pfs.Repo{Name: jobPtr.Pipeline.Name},
-		OutputCommit:  jobPtr.OutputCommit,
-		Restart:       jobPtr.Restart,
-		DataProcessed: jobPtr.DataProcessed,
-		DataSkipped:   jobPtr.DataSkipped,
-		DataTotal:     jobPtr.DataTotal,
-		DataFailed:    jobPtr.DataFailed,
-		DataRecovered: jobPtr.DataRecovered,
-		Stats:         jobPtr.Stats,
-		StatsCommit:   jobPtr.StatsCommit,
-		State:         jobPtr.State,
-		Reason:        jobPtr.Reason,
-		Started:       jobPtr.Started,
-		Finished:      jobPtr.Finished,
+		Job:            jobPtr.Job,
+		Pipeline:       jobPtr.Pipeline,
+		OutputRepo:     &pfs.Repo{Name: jobPtr.Pipeline.Name},
+		OutputCommit:   jobPtr.OutputCommit,
+		Restart:        jobPtr.Restart,
+		DataProcessed:  jobPtr.DataProcessed,
+		DataSkipped:    jobPtr.DataSkipped,
+		DataTotal:      jobPtr.DataTotal,
+		DataFailed:     jobPtr.DataFailed,
+		DataRecovered:  jobPtr.DataRecovered,
+		Stats:          jobPtr.Stats,
+		StatsCommit:    jobPtr.StatsCommit,
+		State:          jobPtr.State,
+		Reason:         jobPtr.Reason,
+		Started:        jobPtr.Started,
+		Finished:       jobPtr.Finished,
+		EgressProgress: jobPtr.EgressProgress,
 	}
 	commitInfo, err := pachClient.InspectCommit(jobPtr.OutputCommit.Repo.Name, jobPtr.OutputCommit.ID)
 	if err != nil {
@@ -897,7 +945,11 @@ func (a *apiServer) jobInfoFromPtr(pachClient *client.APIClient, jobPtr *pps.Etc
 		result.Transform = pipelineInfo.Transform
 		result.PipelineVersion = pipelineInfo.Version
 		result.ParallelismSpec = pipelineInfo.ParallelismSpec
-		result.Egress = pipelineInfo.Egress
+		// Canaries don't egress their outputs, as they're only compared with
+		// the outputs of the pipelines that they're canaries of
+		if pipelineInfo.Canary == nil {
+			result.Egress = pipelineInfo.Egress
+		}
 		result.Service = pipelineInfo.Service
 		result.Spout = pipelineInfo.Spout
 		result.OutputBranch = pipelineInfo.OutputBranch
@@ -1456,6 +1508,9 @@ func (a *apiServer) InspectDatum(ctx context.Context, request *pps.InspectDatumR
 
 // GetLogs implements the protobuf pps.GetLogs RPC
 func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer) (retErr error) {
+	if request.Persisted {
+		return a.getLogsPersisted(request, apiGetLogsServer)
+	}
 	if a.env.LokiLogging || request.UseLokiBackend {
 		return a.getLogsLoki(request, apiGetLogsServer)
 	}
@@ -1463,6 +1518,10 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
 	pachClient := a.env.GetPachClient(apiGetLogsServer.Context())
 	ctx := pachClient.Ctx() // pachClient will propagate auth info
+	filter, err := ppsutil.NewLogFilter(request)
+	if err != nil {
+		return err
+	}
 
 	// Authorize request and get list of pods containing logs we're interested in
 	// (based on pipeline and job filters)
@@ -1514,7 +1573,7 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 				return err
 			}
 			if ci.Finished != nil {
-				return a.getLogsFromStats(pachClient, request, apiGetLogsServer, statsCommit)
+				return a.getLogsFromStats(pachClient, request, apiGetLogsServer, request.Job.ID, statsCommit, filter)
 			}
 		}
 
@@ -1555,12 +1614,17 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 				if *tailLines <= 0 {
 					tailLines = nil
 				}
+				var sinceTime *metav1.Time
+				if !filter.Since.IsZero() {
+					sinceTime = &metav1.Time{Time: filter.Since}
+				}
 				// Get full set of logs from pod i
 				stream, err := a.env.GetKubeClient().CoreV1().Pods(a.namespace).GetLogs(
 					pod.ObjectMeta.Name, &v1.PodLogOptions{
 						Container: containerName,
 						Follow:    request.Follow,
 						TailLines: tailLines,
+						SinceTime: sinceTime,
 					}).Timeout(10 * time.Second).Stream()
 				if err != nil {
 					return err
@@ -1577,6 +1641,9 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 					msg := new(pps.LogMessage)
 					if containerName == "pachd" {
 						msg.Message = scanner.Text()
+						if !filter.MatchMessage(msg.Message) {
+							continue
+						}
 					} else {
 						logBytes := scanner.Bytes()
 						if err := jsonpb.Unmarshal(bytes.NewReader(logBytes), msg); err != nil {
@@ -1599,6 +1666,9 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 						if !workercommon.MatchDatum(request.DataFilters, msg.Data) {
 							continue
 						}
+						if !filter.Match(msg) {
+							continue
+						}
 					}
 					msg.Message = strings.TrimSuffix(msg.Message, "\n")
 
@@ -1628,11 +1698,92 @@ func (a *apiServer) GetLogs(request *pps.GetLogsRequest, apiGetLogsServer pps.AP
 	return egErr
 }
 
-func (a *apiServer) getLogsFromStats(pachClient *client.APIClient, request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer, statsCommit *pfs.Commit) error {
+// getLogsPersisted returns the log messages that were persisted in the stats
+// commits of the request's job, or of the jobs of the request's pipeline
+// that ran during the request's time range
+func (a *apiServer) getLogsPersisted(request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer) (retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
+	pachClient := a.env.GetPachClient(apiGetLogsServer.Context())
+
+	if request.Pipeline == nil && request.Job == nil {
+		return errors.New("must specify a job or pipeline to get persisted logs")
+	}
+	if request.Follow {
+		return errors.New("cannot follow persisted logs")
+	}
+	filter, err := ppsutil.NewLogFilter(request)
+	if err != nil {
+		return err
+	}
+	var jobInfos []*pps.JobInfo
+	if request.Job != nil {
+		jobInfo, err := pachClient.InspectJob(request.Job.ID, false)
+		if err != nil {
+			return errors.Wrapf(err, "could not get job information for \"%s\"", request.Job.ID)
+		}
+		if request.Pipeline != nil && request.Pipeline.Name != jobInfo.Pipeline.Name {
+			return errors.Errorf("job %q is not from pipeline %q", request.Job.ID, request.Pipeline.Name)
+		}
+		jobInfos = append(jobInfos, jobInfo)
+	} else {
+		if err := pachClient.ListJobF(request.Pipeline.Name, nil, nil, -1, false, func(jobInfo *pps.JobInfo) error {
+			if filter.Overlaps(jobInfo) {
+				jobInfos = append(jobInfos, jobInfo)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+	if len(jobInfos) == 0 {
+		return nil
+	}
+
+	// Check whether the caller is authorized to get logs from this pipeline
+	pipelineInfo, err := a.inspectPipeline(pachClient, jobInfos[0].Pipeline.Name)
+	if err != nil {
+		return errors.Wrapf(err, "could not get pipeline information for %s", jobInfos[0].Pipeline.Name)
+	}
+	if err := a.authorizePipelineOp(pachClient, pipelineOpGetLogs, pipelineInfo.Input, pipelineInfo.Pipeline.Name); err != nil {
+		return err
+	}
+
+	for _, jobInfo := range jobInfos {
+		if jobInfo.StatsCommit == nil {
+			if request.Job != nil {
+				return errors.Errorf("the logs of job %q weren't persisted, as its pipeline doesn't set persist_logs or enable_stats", jobInfo.Job.ID)
+			}
+			continue
+		}
+		commitInfo, err := pachClient.InspectCommit(jobInfo.StatsCommit.Repo.Name, jobInfo.StatsCommit.ID)
+		if err != nil {
+			return err
+		}
+		if commitInfo.Finished == nil {
+			// The job's logs are persisted when it finishes
+			if request.Job != nil {
+				return errors.Errorf("job %q hasn't finished, so its logs haven't been persisted yet", jobInfo.Job.ID)
+			}
+			continue
+		}
+		if err := a.getLogsFromStats(pachClient, request, apiGetLogsServer, jobInfo.Job.ID, jobInfo.StatsCommit, filter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getLogsFromStats returns the log messages of the job 'jobID' that are
+// stored in its stats commit 'statsCommit'. Stats commits also hold the logs
+// of the datums that the job skipped, which were logged by earlier jobs, so
+// only the logs of the datums marked with the job's ID are read, and only the
+// messages logged by 'jobID' itself are returned.
+func (a *apiServer) getLogsFromStats(pachClient *client.APIClient, request *pps.GetLogsRequest, apiGetLogsServer pps.API_GetLogsServer, jobID string, statsCommit *pfs.Commit, filter *ppsutil.LogFilter) error {
 	pfsClient := pachClient.PfsAPIClient
 	fs, err := pfsClient.GlobFileStream(pachClient.Ctx(), &pfs.GlobFileRequest{
 		Commit:  statsCommit,
-		Pattern: "*/logs", // this is the path where logs reside
+		Pattern: fmt.Sprintf("*/job:%s", jobID), // this marks the datums that the job processed
 	})
 	if err != nil {
 		return grpcutil.ScrubGRPC(err)
@@ -1653,7 +1804,12 @@ func (a *apiServer) getLogsFromStats(pachClient *client.APIClient, request *pps.
 			limiter.Acquire()
 			defer limiter.Release()
 			var buf bytes.Buffer
-			if err := pachClient.GetFile(fileInfo.File.Commit.Repo.Name, fileInfo.File.Commit.ID, fileInfo.File.Path, 0, 0, &buf); err != nil {
+			logsPath := filepath.Join(filepath.Dir(fileInfo.File.Path), "logs") // this is the path where logs reside
+			if err := pachClient.GetFile(fileInfo.File.Commit.Repo.Name, fileInfo.File.Commit.ID, logsPath, 0, 0, &buf); err != nil {
+				if pfsServer.IsFileNotFoundErr(err) {
+					// the datum didn't log anything
+					return nil
+				}
 				return err
 			}
 			// Parse pods' log lines, and filter out irrelevant ones
@@ -1667,7 +1823,7 @@ func (a *apiServer) getLogsFromStats(pachClient *client.APIClient, request *pps.
 				if request.Pipeline != nil && request.Pipeline.Name != msg.PipelineName {
 					continue
 				}
-				if request.Job != nil && request.Job.ID != msg.JobID {
+				if jobID != msg.JobID {
 					continue
 				}
 				if request.Datum != nil && request.Datum.ID != msg.DatumID {
@@ -1679,6 +1835,9 @@ func (a *apiServer) getLogsFromStats(pachClient *client.APIClient, request *pps.
 				if !workercommon.MatchDatum(request.DataFilters, msg.Data) {
 					continue
 				}
+				if !filter.Match(msg) {
+					continue
+				}
 
 				mu.Lock()
 				if err := apiGetLogsServer.Send(msg); err != nil {
@@ -1705,12 +1864,23 @@ func (a *apiServer) getLogsLoki(request *pps.GetLogsRequest, apiGetLogsServer pp
 	if err != nil {
 		return err
 	}
+	filter, err := ppsutil.NewLogFilter(request)
+	if err != nil {
+		return err
+	}
+	until := filter.Until
+	if until.IsZero() {
+		until = time.Now()
+	}
 	if request.Pipeline == nil && request.Job == nil {
 		if len(request.DataFilters) > 0 || request.Datum != nil {
 			return errors.Errorf("must specify the Job or Pipeline that the datum is from to get logs for it")
 		}
 		// no authorization is done to get logs from master
-		return lokiutil.QueryRange(loki, `{app="pachd"}`, time.Time{}, time.Now(), func(t time.Time, line string) error {
+		return lokiutil.QueryRange(loki, `{app="pachd"}`, filter.Since, until, func(t time.Time, line string) error {
+			if !filter.MatchMessage(line) {
+				return nil
+			}
 			return apiGetLogsServer.Send(&pps.LogMessage{
 				Message: strings.TrimSuffix(line, "\n"),
 			})
@@ -1756,7 +1926,7 @@ func (a *apiServer) getLogsLoki(request *pps.GetLogsRequest, apiGetLogsServer pp
 	for _, filter := range request.DataFilters {
 		query += contains(filter)
 	}
-	return lokiutil.QueryRange(loki, query, time.Time{}, time.Now(), func(t time.Time, line string) error {
+	return lokiutil.QueryRange(loki, query, filter.Since, until, func(t time.Time, line string) error {
 		msg := &pps.LogMessage{}
 		// These filters are almost always unnecessary because we apply
 		// them in the Loki request, but many of them are just done with
@@ -1782,6 +1952,9 @@ func (a *apiServer) getLogsLoki(request *pps.GetLogsRequest, apiGetLogsServer pp
 		if !workercommon.MatchDatum(request.DataFilters, msg.Data) {
 			return nil
 		}
+		if !filter.Match(msg) {
+			return nil
+		}
 		msg.Message = strings.TrimSuffix(msg.Message, "\n")
 		return apiGetLogsServer.Send(msg)
 	})
@@ -1813,15 +1986,21 @@ func (a *apiServer) validatePipelineRequest(request *pps.CreatePipelineRequest)
 	if request.S3Out && ((request.Service != nil) || (request.Spout != nil)) {
 		return errors.New("s3 output is not supported in spouts or services")
 	}
-	if request.S3Out && request.EnableStats {
+	if request.S3Out && (request.EnableStats || request.PersistLogs) {
 		return errors.New("stats are not supported for pipelines that output via Pachyderm's S3 gateway")
 	}
-	if request.Transform == nil {
+	if request.Transform == nil && !isBuiltinSpout(request.Spout) {
 		return errors.Errorf("pipeline must specify a transform")
 	}
 	return nil
 }
 
+// isBuiltinSpout returns true if 'spout' is one of the built-in spout
+// connectors, which don't run user code
+func isBuiltinSpout(spout *pps.Spout) bool {
+	return spout.GetHTTP() != nil || spout.GetWatch() != nil
+}
+
 func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) error {
 	if pipelineInfo.Pipeline == nil {
 		return errors.New("invalid pipeline spec: Pipeline field cannot be nil")
@@ -1846,6 +2025,12 @@ func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo
 	if err := a.validateInput(pachClient, pipelineInfo.Pipeline.Name, pipelineInfo.Input, false); err != nil {
 		return err
 	}
+	if err := validateEgress(pipelineInfo.Egress); err != nil {
+		return errors.Wrapf(err, "invalid egress")
+	}
+	if err := a.validateNotifications(pachClient, pipelineInfo.Notifications); err != nil {
+		return errors.Wrapf(err, "invalid notifications")
+	}
 	if pipelineInfo.ParallelismSpec != nil {
 		if pipelineInfo.ParallelismSpec.Coefficient < 0 {
 			return errors.New("ParallelismSpec.Coefficient cannot be negative")
@@ -1859,6 +2044,21 @@ func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo
 			return errors.New("services can only be run with a constant parallelism of 1")
 		}
 	}
+	if pipelineInfo.Autoscaling != nil {
+		if pipelineInfo.ParallelismSpec != nil {
+			return errors.New("contradictory parallelism strategies: must set at " +
+				"most one of ParallelismSpec and Autoscaling")
+		}
+		if pipelineInfo.Service != nil || pipelineInfo.Spout != nil {
+			return errors.New("services and spouts cannot be autoscaled")
+		}
+		if pipelineInfo.Autoscaling.MaxWorkers == 0 {
+			return errors.New("Autoscaling.MaxWorkers must be > 0")
+		}
+		if pipelineInfo.Autoscaling.MinWorkers > pipelineInfo.Autoscaling.MaxWorkers {
+			return errors.New("Autoscaling.MinWorkers cannot be greater than Autoscaling.MaxWorkers")
+		}
+	}
 	if pipelineInfo.HashtreeSpec != nil {
 		if pipelineInfo.HashtreeSpec.Constant == 0 {
 			return errors.New("invalid pipeline spec: HashtreeSpec.Constant must be > 0")
@@ -1867,6 +2067,18 @@ func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo
 	if pipelineInfo.OutputBranch == "" {
 		return errors.New("pipeline needs to specify an output branch")
 	}
+	if pipelineInfo.FailedDatumBranch != "" {
+		if err := ancestry.ValidateName(pipelineInfo.FailedDatumBranch); err != nil {
+			return errors.Wrapf(err, "invalid failed datum branch")
+		}
+		if pipelineInfo.FailedDatumBranch == pipelineInfo.OutputBranch ||
+			pipelineInfo.FailedDatumBranch == "stats" {
+			return errors.Errorf("failed datum branch cannot be %q", pipelineInfo.FailedDatumBranch)
+		}
+		if pipelineInfo.Service != nil || pipelineInfo.Spout != nil {
+			return errors.New("services and spouts cannot have a failed datum branch")
+		}
+	}
 	if _, err := resource.ParseQuantity(pipelineInfo.CacheSize); err != nil {
 		return errors.Wrapf(err, "could not parse cacheSize '%s'", pipelineInfo.CacheSize)
 	}
@@ -1882,6 +2094,64 @@ func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo
 			return err
 		}
 	}
+	if pipelineInfo.RetryPolicy != nil {
+		if err := validateRetryPolicy(pipelineInfo.RetryPolicy); err != nil {
+			return err
+		}
+	}
+	if pipelineInfo.Queue != "" {
+		if strings.Contains(pipelineInfo.Queue, "/") {
+			return errors.Errorf("queue name %q cannot contain '/'", pipelineInfo.Queue)
+		}
+		if pipelineInfo.Service != nil || pipelineInfo.Spout != nil {
+			return errors.New("services and spouts cannot be in a queue")
+		}
+	}
+	if pipelineInfo.GlobalDatumCache {
+		if pipelineInfo.Service != nil || pipelineInfo.Spout != nil {
+			return errors.New("services and spouts cannot use the global datum cache")
+		}
+		if pipelineInfo.S3Out {
+			return errors.New("pipelines with s3_out cannot use the global datum cache, as their outputs aren't stored per datum")
+		}
+	} else if len(pipelineInfo.GlobalDatumCacheTrust) > 0 {
+		return errors.New("global_datum_cache_trust can only be set if global_datum_cache is")
+	}
+	if len(pipelineInfo.Outputs) > 0 {
+		if pipelineInfo.Service != nil || pipelineInfo.Spout != nil || pipelineInfo.S3Out {
+			return errors.New("services, spouts and pipelines with s3_out cannot have named outputs")
+		}
+		// The repos of cron and git inputs are created by the pipeline too, and
+		// a cron input's repo is named like a named output's repo by default
+		inputRepos := make(map[string]string)
+		pps.VisitInput(pipelineInfo.Input, func(input *pps.Input) {
+			if input.Cron != nil {
+				inputRepos[input.Cron.Repo] = input.Cron.Name
+			}
+			if input.Git != nil {
+				inputRepos[input.Git.Name] = input.Git.Name
+			}
+		})
+		outputs := make(map[string]bool)
+		for _, output := range pipelineInfo.Outputs {
+			if err := ancestry.ValidateName(output); err != nil {
+				return errors.Wrapf(err, "invalid output name")
+			}
+			if outputs[output] {
+				return errors.Errorf("output %q is declared more than once", output)
+			}
+			outputs[output] = true
+			repo := client.OutputRepo(pipelineInfo.Pipeline.Name, output)
+			if input, ok := inputRepos[repo]; ok {
+				return errors.Errorf("output %q's repo, %q, is also the repo of input %q", output, repo, input)
+			}
+		}
+	}
+	if pipelineInfo.Canary != nil {
+		if err := validateCanary(pachClient, pipelineInfo); err != nil {
+			return errors.Wrapf(err, "invalid canary")
+		}
+	}
 	if pipelineInfo.PodSpec != "" && !json.Valid([]byte(pipelineInfo.PodSpec)) {
 		return errors.Errorf("malformed PodSpec")
 	}
@@ -1912,6 +2182,205 @@ func (a *apiServer) validatePipeline(pachClient *client.APIClient, pipelineInfo
 		if pipelineInfo.Spout.Service == nil && pipelineInfo.Input != nil {
 			return errors.Errorf("spout pipelines (without a service) must not have an input")
 		}
+		if err := validateBuiltinSpout(pipelineInfo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateCanary checks that a canary pipeline is a canary of an existing
+// pipeline that isn't itself a canary, and that it samples a valid
+// percentage of datums
+func validateCanary(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) error {
+	canary := pipelineInfo.Canary
+	if canary.Pipeline == nil || canary.Pipeline.Name == "" {
+		return errors.New("the pipeline that this is a canary of must be set")
+	}
+	if canary.Pipeline.Name == pipelineInfo.Pipeline.Name {
+		return errors.New("a pipeline cannot be a canary of itself")
+	}
+	if canary.SamplePercent < 0 || canary.SamplePercent > 100 {
+		return errors.Errorf("sample percent must be between 0 and 100, but was %d", canary.SamplePercent)
+	}
+	if pipelineInfo.Service != nil || pipelineInfo.Spout != nil {
+		return errors.New("services and spouts cannot be canaries")
+	}
+	target, err := pachClient.InspectPipeline(canary.Pipeline.Name)
+	if err != nil {
+		return errors.Wrapf(err, "could not inspect pipeline %q", canary.Pipeline.Name)
+	}
+	if target.Canary != nil {
+		return errors.Errorf("pipeline %q is itself a canary", canary.Pipeline.Name)
+	}
+	return nil
+}
+
+// validateBuiltinSpout checks that a pipeline sets at most one built-in spout
+// connector, and that the connector is configured correctly
+func validateBuiltinSpout(pipelineInfo *pps.PipelineInfo) error {
+	spout := pipelineInfo.Spout
+	if !isBuiltinSpout(spout) {
+		return nil
+	}
+	if spout.HTTP != nil && spout.Watch != nil {
+		return errors.New("only one of spout.http and spout.watch may be set")
+	}
+	if len(pipelineInfo.Transform.Cmd) > 0 {
+		return errors.New("built-in spouts don't run user code, so they cannot set transform.cmd")
+	}
+	if spout.HTTP != nil {
+		if spout.Service == nil {
+			return errors.New("http spouts must set spout.service, whose internal_port they listen on")
+		}
+		if secret := spout.HTTP.Secret; secret != nil && (secret.Name == "" || secret.Key == "") {
+			return errors.New("http spout secrets must set both name and key")
+		}
+		if spout.HTTP.BatchBytes < 0 {
+			return errors.New("http spout batch_bytes cannot be negative")
+		}
+		if spout.HTTP.BatchInterval != nil {
+			if _, err := types.DurationFromProto(spout.HTTP.BatchInterval); err != nil {
+				return errors.Wrapf(err, "invalid http spout batch_interval")
+			}
+		}
+	}
+	if spout.Watch != nil {
+		if spout.Marker == "" {
+			return errors.New("watch spouts must set spout.marker, where they record the objects that they've committed")
+		}
+		if _, err := obj.ParseURL(spout.Watch.URL); err != nil {
+			return errors.Wrapf(err, "invalid watch spout url")
+		}
+		if spout.Watch.PollInterval != nil {
+			if _, err := types.DurationFromProto(spout.Watch.PollInterval); err != nil {
+				return errors.Wrapf(err, "invalid watch spout poll_interval")
+			}
+		}
+	}
+	return nil
+}
+
+// validateRetryPolicy checks that a retry policy's backoffs are well-formed,
+// and that it doesn't both retry and fail on any exit code
+func validateRetryPolicy(policy *pps.RetryPolicy) error {
+	var initial, max time.Duration
+	var err error
+	if policy.InitialBackoff != nil {
+		if initial, err = types.DurationFromProto(policy.InitialBackoff); err != nil {
+			return errors.Wrapf(err, "invalid RetryPolicy.InitialBackoff")
+		}
+	}
+	if policy.MaxBackoff != nil {
+		if max, err = types.DurationFromProto(policy.MaxBackoff); err != nil {
+			return errors.Wrapf(err, "invalid RetryPolicy.MaxBackoff")
+		}
+	}
+	if initial < 0 || max < 0 {
+		return errors.New("RetryPolicy backoffs cannot be negative")
+	}
+	if policy.MaxBackoff != nil && max < initial {
+		return errors.New("RetryPolicy.MaxBackoff cannot be less than RetryPolicy.InitialBackoff")
+	}
+	fatal := make(map[int64]bool)
+	for _, code := range policy.FatalReturnCode {
+		fatal[code] = true
+	}
+	for _, code := range policy.RetryReturnCode {
+		if fatal[code] {
+			return errors.Errorf("return code %d cannot be both retried and fatal", code)
+		}
+	}
+	return nil
+}
+
+// validateNotifications checks that each notification sets exactly one
+// target, and that the caller can write to any repo targets, which the PPS
+// master writes events into on the pipeline's behalf
+func (a *apiServer) validateNotifications(pachClient *client.APIClient, notifications []*pps.Notification) error {
+	for _, notification := range notifications {
+		if (notification.Webhook == nil) == (notification.Repo == "") {
+			return errors.New("each notification must set exactly one of webhook and repo")
+		}
+		for _, e := range notification.Events {
+			if _, ok := pps.NotificationEventType_name[int32(e)]; !ok {
+				return errors.Errorf("unknown notification event %d", e)
+			}
+		}
+		if notification.Webhook != nil {
+			if _, err := url.ParseRequestURI(notification.Webhook.URL); err != nil {
+				return errors.Wrapf(err, "invalid webhook url")
+			}
+			if secret := notification.Webhook.Secret; secret != nil && (secret.Name == "" || secret.Key == "") {
+				return errors.New("webhook secrets must set both name and key")
+			}
+			continue
+		}
+		if _, err := pachClient.InspectRepo(notification.Repo); err != nil {
+			return errors.Wrapf(grpcutil.ScrubGRPC(err), "could not inspect notification repo %q", notification.Repo)
+		}
+		resp, err := pachClient.Authorize(pachClient.Ctx(), &auth.AuthorizeRequest{
+			Repo:  notification.Repo,
+			Scope: auth.Scope_WRITER,
+		})
+		if auth.IsErrNotActivated(err) {
+			continue
+		} else if err != nil {
+			return grpcutil.ScrubGRPC(err)
+		}
+		if !resp.Authorized {
+			return &auth.ErrNotAuthorized{
+				Repo:     notification.Repo,
+				Required: auth.Scope_WRITER,
+			}
+		}
+	}
+	return nil
+}
+
+func validateEgress(egress *pps.Egress) error {
+	if egress == nil {
+		return nil
+	}
+	targets := 0
+	for _, set := range []bool{egress.URL != "", egress.SQL != nil, egress.HTTP != nil, egress.Queue != nil} {
+		if set {
+			targets++
+		}
+	}
+	if targets > 1 {
+		return errors.New("only one of URL, sql, http and queue may be set")
+	}
+	for _, secret := range []*pps.EgressSecret{egress.GetSQL().GetSecret(), egress.GetHTTP().GetSecret()} {
+		if secret != nil && (secret.Name == "" || secret.Key == "") {
+			return errors.New("egress secrets must set both name and key")
+		}
+	}
+	switch {
+	case egress.SQL != nil:
+		if egress.SQL.Driver != "postgres" {
+			return errors.Errorf("unsupported sql driver %q (only \"postgres\" is supported)", egress.SQL.Driver)
+		}
+		if egress.SQL.URL == "" && egress.SQL.Secret == nil {
+			return errors.New("sql egress must set url or secret")
+		}
+		if egress.SQL.Table == "" {
+			return errors.New("sql egress must set table")
+		}
+		if egress.SQL.FileFormat != "csv" && egress.SQL.FileFormat != "json" {
+			return errors.Errorf("unsupported sql file_format %q (must be \"csv\" or \"json\")", egress.SQL.FileFormat)
+		}
+	case egress.HTTP != nil:
+		if _, err := url.ParseRequestURI(egress.HTTP.URL); err != nil {
+			return errors.Wrapf(err, "invalid http url")
+		}
+	case egress.Queue != nil:
+		if egress.Queue.Kind != "kafka" {
+			return errors.Errorf("unsupported queue kind %q (only \"kafka\" is supported)", egress.Queue.Kind)
+		}
+		if len(egress.Queue.Brokers) == 0 || egress.Queue.Topic == "" {
+			return errors.New("queue egress must set brokers and topic")
+		}
 	}
 	return nil
 }
@@ -2170,12 +2639,49 @@ func (a *apiServer) fixPipelineInputRepoACLsInTransaction(txnCtx *txnenv.Transac
 			})
 		})
 	}
-	// Add pipeline to its output repo's ACL as a WRITER if it's new
+	// Remove pipeline from the ACLs of the named output repos that it no longer
+	// writes to
+	if pipelineInfo != nil && prevPipelineInfo != nil {
+		for _, output := range removedOutputs(pipelineInfo, prevPipelineInfo) {
+			repo := client.OutputRepo(pipelineName, output)
+			eg.Go(func() error {
+				return a.sudoTransaction(txnCtx, func(superTxnCtx *txnenv.TransactionContext) error {
+					_, err := superTxnCtx.Auth().SetScopeInTransaction(superTxnCtx, &auth.SetScopeRequest{
+						Repo:     repo,
+						Username: auth.PipelinePrefix + pipelineName,
+						Scope:    auth.Scope_NONE,
+					})
+					if isNotFoundErr(err) {
+						// can happen if the output repo was deleted; nothing to remove
+						return nil
+					}
+					return err
+				})
+			})
+		}
+	}
+	// Add pipeline to its output repo's ACL as a WRITER if it's new, and to the
+	// ACLs of any new named output repos
+	outputRepos := make(map[string]bool)
 	if prevPipelineInfo == nil {
+		outputRepos[pipelineName] = true
+	}
+	if pipelineInfo != nil {
+		for _, output := range pipelineInfo.Outputs {
+			outputRepos[client.OutputRepo(pipelineName, output)] = true
+		}
+		if prevPipelineInfo != nil {
+			for _, output := range prevPipelineInfo.Outputs {
+				delete(outputRepos, client.OutputRepo(pipelineName, output))
+			}
+		}
+	}
+	for repo := range outputRepos {
+		repo := repo
 		eg.Go(func() error {
 			return a.sudoTransaction(txnCtx, func(superTxnCtx *txnenv.TransactionContext) error {
 				_, err := superTxnCtx.Auth().SetScopeInTransaction(superTxnCtx, &auth.SetScopeRequest{
-					Repo:     pipelineName,
+					Repo:     repo,
 					Username: auth.PipelinePrefix + pipelineName,
 					Scope:    auth.Scope_WRITER,
 				})
@@ -2193,6 +2699,11 @@ func (a *apiServer) fixPipelineInputRepoACLsInTransaction(txnCtx *txnenv.Transac
 // the parallelism spec in CreatePipelineRequest.Parallelism into a constant
 // that can be stored in EtcdPipelineInfo.Parallelism
 func getExpectedNumWorkers(kc *kube.Clientset, pipelineInfo *pps.PipelineInfo) (int, error) {
+	// Autoscaled pipelines split their work as though they were running their
+	// maximum number of workers. The PPS master decides how many actually run.
+	if pipelineInfo.Autoscaling != nil {
+		return int(pipelineInfo.Autoscaling.MaxWorkers), nil
+	}
 	switch pspec := pipelineInfo.ParallelismSpec; {
 	case pspec == nil, pspec.Constant == 0 && pspec.Coefficient == 0:
 		return 1, nil
@@ -2216,6 +2727,55 @@ func getExpectedNumWorkers(kc *kube.Clientset, pipelineInfo *pps.PipelineInfo) (
 	}
 }
 
+// newPipelineInfo returns the PipelineInfo for the first version of the
+// pipeline that 'request' creates, before defaults are set
+func newPipelineInfo(request *pps.CreatePipelineRequest) *pps.PipelineInfo {
+	return &pps.PipelineInfo{
+		Pipeline:              request.Pipeline,
+		Version:               1,
+		Transform:             request.Transform,
+		TFJob:                 request.TFJob,
+		ParallelismSpec:       request.ParallelismSpec,
+		HashtreeSpec:          request.HashtreeSpec,
+		Input:                 request.Input,
+		OutputBranch:          request.OutputBranch,
+		Egress:                request.Egress,
+		CreatedAt:             now(),
+		ResourceRequests:      request.ResourceRequests,
+		ResourceLimits:        request.ResourceLimits,
+		SidecarResourceLimits: request.SidecarResourceLimits,
+		Description:           request.Description,
+		CacheSize:             request.CacheSize,
+		EnableStats:           request.EnableStats,
+		Salt:                  request.Salt,
+		MaxQueueSize:          request.MaxQueueSize,
+		Service:               request.Service,
+		Spout:                 request.Spout,
+		ChunkSpec:             request.ChunkSpec,
+		DatumTimeout:          request.DatumTimeout,
+		JobTimeout:            request.JobTimeout,
+		Standby:               request.Standby,
+		DatumTries:            request.DatumTries,
+		SchedulingSpec:        request.SchedulingSpec,
+		PodSpec:               request.PodSpec,
+		PodPatch:              request.PodPatch,
+		S3Out:                 request.S3Out,
+		Metadata:              request.Metadata,
+		Autoscaling:           request.Autoscaling,
+		FailedDatumBranch:     request.FailedDatumBranch,
+		RetryPolicy:           request.RetryPolicy,
+		Template:              request.Template,
+		Notifications:         request.Notifications,
+		Queue:                 request.Queue,
+		Priority:              request.Priority,
+		GlobalDatumCache:      request.GlobalDatumCache,
+		GlobalDatumCacheTrust: request.GlobalDatumCacheTrust,
+		Outputs:               request.Outputs,
+		Canary:                request.Canary,
+		PersistLogs:           request.PersistLogs,
+	}
+}
+
 // CreatePipeline implements the protobuf pps.CreatePipeline RPC
 //
 // Implementation note:
@@ -2243,7 +2803,15 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "CreatePipeline")
 	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
 
-	// Validate request
+	// Instantiate the pipeline from its template, if it has one
+	if request.Template != nil {
+		var err error
+		if request, err = a.renderTemplate(ctx, request); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate request
 	if err := a.validatePipelineRequest(request); err != nil {
 		return nil, err
 	}
@@ -2265,41 +2833,19 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 	if request.Salt == "" || request.Reprocess {
 		request.Salt = uuid.NewWithoutDashes()
 	}
-	pipelineInfo := &pps.PipelineInfo{
-		Pipeline:              request.Pipeline,
-		Version:               1,
-		Transform:             request.Transform,
-		TFJob:                 request.TFJob,
-		ParallelismSpec:       request.ParallelismSpec,
-		HashtreeSpec:          request.HashtreeSpec,
-		Input:                 request.Input,
-		OutputBranch:          request.OutputBranch,
-		Egress:                request.Egress,
-		CreatedAt:             now(),
-		ResourceRequests:      request.ResourceRequests,
-		ResourceLimits:        request.ResourceLimits,
-		SidecarResourceLimits: request.SidecarResourceLimits,
-		Description:           request.Description,
-		CacheSize:             request.CacheSize,
-		EnableStats:           request.EnableStats,
-		Salt:                  request.Salt,
-		MaxQueueSize:          request.MaxQueueSize,
-		Service:               request.Service,
-		Spout:                 request.Spout,
-		ChunkSpec:             request.ChunkSpec,
-		DatumTimeout:          request.DatumTimeout,
-		JobTimeout:            request.JobTimeout,
-		Standby:               request.Standby,
-		DatumTries:            request.DatumTries,
-		SchedulingSpec:        request.SchedulingSpec,
-		PodSpec:               request.PodSpec,
-		PodPatch:              request.PodPatch,
-		S3Out:                 request.S3Out,
-		Metadata:              request.Metadata,
-	}
+	pipelineInfo := newPipelineInfo(request)
 	if err := setPipelineDefaults(pipelineInfo); err != nil {
 		return nil, err
 	}
+	// Outputs in the global datum cache are shared under the pipeline's owner,
+	// which is set here rather than by the request so that it can't be forged
+	if pipelineInfo.GlobalDatumCache {
+		if me, err := pachClient.WhoAmI(ctx, &auth.WhoAmIRequest{}); err == nil {
+			pipelineInfo.GlobalDatumCacheOwner = me.Username
+		} else if !auth.IsErrNotActivated(err) {
+			return nil, err
+		}
+	}
 	// Validate final PipelineInfo (now that defaults have been populated)
 	if err := a.validatePipeline(pachClient, pipelineInfo); err != nil {
 		return nil, err
@@ -2341,11 +2887,14 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 	pipelineName := pipelineInfo.Pipeline.Name
 	pps.SortInput(pipelineInfo.Input) // Makes datum hashes comparable
 	update := false
-	if request.Update {
-		// inspect the pipeline to see if this is a real update
-		if _, err := a.inspectPipeline(pachClient, request.Pipeline.Name); err == nil {
-			update = true
-		}
+	var prevOutputs []string
+	// inspect the pipeline to see if this is a real update
+	if prevPipelineInfo, err := a.inspectPipeline(pachClient, request.Pipeline.Name); err == nil {
+		update = request.Update
+		prevOutputs = prevPipelineInfo.Outputs
+	}
+	if err := checkNamedOutputRepos(pachClient, pipelineInfo, prevOutputs); err != nil {
+		return nil, err
 	}
 	var (
 		// provenance for the pipeline's output branch (includes the spec branch)
@@ -2434,6 +2983,7 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 				pipelinePtr.Reason = ""
 				// Update pipeline parallelism
 				pipelinePtr.Parallelism = uint64(parallelism)
+				pipelinePtr.Notifications = pipelineInfo.Notifications
 
 				// Generate new pipeline auth token (added due to & add pipeline to the ACLs of input/output
 				// repos
@@ -2500,6 +3050,21 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 			}
 		}
 
+		if err := createNamedOutputRepos(pachClient, pipelineInfo, oldPipelineInfo.Outputs); err != nil {
+			return nil, err
+		}
+		// Outputs that the update removed are no longer downstream of the
+		// output branch, so that their repos stop getting new commits
+		for _, output := range removedOutputs(pipelineInfo, oldPipelineInfo) {
+			if err := pachClient.CreateBranch(
+				client.OutputRepo(pipelineName, output),
+				oldPipelineInfo.OutputBranch,
+				oldPipelineInfo.OutputBranch,
+				nil,
+			); err != nil && !isNotFoundErr(err) {
+				return nil, err
+			}
+		}
 		if pipelinePtr.AuthToken != "" {
 			if err := a.fixPipelineInputRepoACLs(ctx, pipelineInfo, oldPipelineInfo); err != nil {
 				return nil, err
@@ -2514,6 +3079,9 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 			}); err != nil && !isAlreadyExistsErr(err) {
 			return nil, err
 		}
+		if err := createNamedOutputRepos(pachClient, pipelineInfo, nil); err != nil {
+			return nil, err
+		}
 
 		// Must create spec commit before restoring output branch provenance, so
 		// that no commits are created with a missing spec commit
@@ -2540,9 +3108,10 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 		// pipelinePtr will be written to etcd, pointing at 'commit'. May include an
 		// auth token
 		pipelinePtr := &pps.EtcdPipelineInfo{
-			SpecCommit:  commit,
-			State:       pps.PipelineState_PIPELINE_STARTING,
-			Parallelism: uint64(parallelism),
+			SpecCommit:    commit,
+			State:         pps.PipelineState_PIPELINE_STARTING,
+			Parallelism:   uint64(parallelism),
+			Notifications: pipelineInfo.Notifications,
 		}
 
 		// Generate pipeline's auth token & add pipeline to the ACLs of input/output
@@ -2600,6 +3169,27 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 	}); err != nil {
 		return nil, errors.Wrapf(err, "could not create/update output branch")
 	}
+	// Each named output's branch is downstream of the output branch, so that
+	// each output commit has a commit in every named output repo
+	for _, output := range pipelineInfo.Outputs {
+		repo := client.OutputRepo(pipelineName, output)
+		branch := client.NewBranch(repo, pipelineInfo.OutputBranch)
+		var head *pfs.Commit
+		if !request.Reprocess {
+			if _, err := pfsClient.InspectBranch(ctx, &pfs.InspectBranchRequest{Branch: branch}); err != nil && !isNotFoundErr(err) {
+				return nil, err
+			} else if err == nil {
+				head = client.NewCommit(repo, pipelineInfo.OutputBranch)
+			}
+		}
+		if _, err := pfsClient.CreateBranch(ctx, &pfs.CreateBranchRequest{
+			Branch:     branch,
+			Provenance: []*pfs.Branch{outputBranch},
+			Head:       head,
+		}); err != nil {
+			return nil, errors.Wrapf(err, "could not create/update branch of output repo %q", repo)
+		}
+	}
 	if pipelineInfo.EnableStats {
 		if _, err := pfsClient.CreateBranch(ctx, &pfs.CreateBranchRequest{
 			Branch:     client.NewBranch(pipelineName, "stats"),
@@ -2621,12 +3211,173 @@ func (a *apiServer) CreatePipeline(ctx context.Context, request *pps.CreatePipel
 	return &types.Empty{}, nil
 }
 
+// checkNamedOutputRepos checks that the repos of the named outputs of the
+// pipeline 'pipelineInfo' that aren't in 'prevOutputs' (the outputs of the
+// pipeline that it updates, if any) don't exist yet, as a pipeline only writes
+// to named output repos that it created
+func checkNamedOutputRepos(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, prevOutputs []string) error {
+	for _, output := range newOutputs(pipelineInfo.Outputs, prevOutputs) {
+		repo := client.OutputRepo(pipelineInfo.Pipeline.Name, output)
+		if _, err := pachClient.InspectRepo(repo); err == nil {
+			return errors.Errorf("cannot create repo %q for output %q, as it already exists", repo, output)
+		} else if !isNotFoundErr(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// createNamedOutputRepos creates the repos of the named outputs of the pipeline
+// 'pipelineInfo'. The repos of outputs in 'prevOutputs' (the outputs of the
+// pipeline that it updates, if any) were created by the pipeline and may
+// already exist, but the repos of new outputs must not.
+func createNamedOutputRepos(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, prevOutputs []string) error {
+	created := make(map[string]bool)
+	for _, output := range prevOutputs {
+		created[output] = true
+	}
+	for _, output := range pipelineInfo.Outputs {
+		repo := client.OutputRepo(pipelineInfo.Pipeline.Name, output)
+		if _, err := pachClient.PfsAPIClient.CreateRepo(pachClient.Ctx(), &pfs.CreateRepoRequest{
+			Repo:        client.NewRepo(repo),
+			Description: fmt.Sprintf("Output repo %q of pipeline %s.", output, pipelineInfo.Pipeline.Name),
+		}); err != nil && !(isAlreadyExistsErr(err) && created[output]) {
+			return errors.Wrapf(grpcutil.ScrubGRPC(err), "could not create output repo %q", repo)
+		}
+	}
+	return nil
+}
+
+// newOutputs returns the named outputs in 'outputs' that aren't in
+// 'prevOutputs'
+func newOutputs(outputs, prevOutputs []string) []string {
+	prev := make(map[string]bool)
+	for _, output := range prevOutputs {
+		prev[output] = true
+	}
+	var result []string
+	for _, output := range outputs {
+		if !prev[output] {
+			result = append(result, output)
+		}
+	}
+	return result
+}
+
+// removedOutputs returns the named outputs of 'prevPipelineInfo' that an
+// update to 'pipelineInfo' removes
+func removedOutputs(pipelineInfo, prevPipelineInfo *pps.PipelineInfo) []string {
+	return newOutputs(prevPipelineInfo.Outputs, pipelineInfo.Outputs)
+}
+
+// defaultDryRunSampleSize is the number of datums returned by DryRunPipeline
+// when the request doesn't set a sample size
+const defaultDryRunSampleSize = 10
+
+// DryRunPipeline implements the protobuf pps.DryRunPipeline RPC
+func (a *apiServer) DryRunPipeline(ctx context.Context, request *pps.DryRunPipelineRequest) (response *pps.DryRunPipelineResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "DryRunPipeline")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+
+	pachClient := a.env.GetPachClient(ctx)
+	if _, err := checkLoggedIn(pachClient); err != nil {
+		return nil, err
+	}
+	if request.Pipeline == nil {
+		return nil, errors.New("must specify a pipeline to dry run")
+	}
+	if err := a.validatePipelineRequest(request.Pipeline); err != nil {
+		return nil, err
+	}
+	pipelineName := request.Pipeline.Pipeline.Name
+	input := request.Pipeline.Input
+	setInputDefaults(pipelineName, input)
+	if err := a.validateInput(pachClient, pipelineName, input, false); err != nil {
+		return nil, err
+	}
+	// Dry runs only read the pipeline's inputs, so they need the same access
+	// as ListDatum
+	if err := a.authorizePipelineOp(pachClient, pipelineOpListDatum, input, ""); err != nil {
+		return nil, err
+	}
+	pps.SortInput(input) // Match the order of the created pipeline's datums
+
+	// Compute datums from the current head of each input branch. Branches
+	// without a head (including the cron repos of pipelines that don't exist
+	// yet) contribute no datums, as they would in the pipeline's first job.
+	response = &pps.DryRunPipelineResponse{}
+	headCommit := func(repo, branch string) (string, error) {
+		ci, err := pachClient.InspectCommit(repo, branch)
+		if err != nil {
+			if isNotFoundErr(err) {
+				return "", nil
+			}
+			return "", err
+		}
+		response.InputCommits = append(response.InputCommits, ci.Commit)
+		return ci.Commit.ID, nil
+	}
+	var visitErr error
+	pps.VisitInput(input, func(input *pps.Input) {
+		if visitErr != nil {
+			return
+		}
+		switch {
+		case input.Pfs != nil:
+			input.Pfs.Commit, visitErr = headCommit(input.Pfs.Repo, input.Pfs.Branch)
+		case input.Cron != nil:
+			input.Cron.Commit, visitErr = headCommit(input.Cron.Repo, "master")
+		case input.Git != nil:
+			input.Git.Commit, visitErr = headCommit(input.Git.Name, input.Git.Branch)
+		}
+	})
+	if visitErr != nil {
+		return nil, visitErr
+	}
+
+	dit, err := datum.NewIterator(pachClient, input)
+	if err != nil {
+		return nil, err
+	}
+	sampleSize := request.SampleSize
+	if sampleSize <= 0 {
+		sampleSize = defaultDryRunSampleSize
+	}
+	dit.Reset()
+	for dit.Next() {
+		files := dit.Datum()
+		datumInfo := &pps.DatumInfo{
+			Datum: &pps.Datum{},
+			State: pps.DatumState_STARTING,
+		}
+		for _, file := range files {
+			response.EstimatedBytes += int64(file.FileInfo.SizeBytes)
+			datumInfo.Data = append(datumInfo.Data, file.FileInfo)
+		}
+		if response.DatumCount < sampleSize {
+			response.Datums = append(response.Datums, datumInfo)
+		}
+		response.DatumCount++
+	}
+	return response, nil
+}
+
 // setPipelineDefaults sets the default values for a pipeline info
 func setPipelineDefaults(pipelineInfo *pps.PipelineInfo) error {
+	if pipelineInfo.Transform == nil {
+		// Built-in spouts don't run user code, so they needn't set a transform
+		pipelineInfo.Transform = &pps.Transform{}
+	}
 	if pipelineInfo.Transform.Image == "" {
 		pipelineInfo.Transform.Image = DefaultUserImage
 	}
 	setInputDefaults(pipelineInfo.Pipeline.Name, pipelineInfo.Input)
+	if pipelineInfo.PersistLogs {
+		// Datum logs are persisted alongside each datum's stats
+		pipelineInfo.EnableStats = true
+	}
 	if pipelineInfo.OutputBranch == "" {
 		// Output branches default to master
 		pipelineInfo.OutputBranch = "master"
@@ -2811,6 +3562,91 @@ func (a *apiServer) ListPipeline(ctx context.Context, request *pps.ListPipelineR
 	return pipelineInfos, nil
 }
 
+// DrawPipeline implements the protobuf pps.DrawPipeline RPC
+func (a *apiServer) DrawPipeline(ctx context.Context, request *pps.DrawPipelineRequest) (response *pps.DrawPipelineResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, nil, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "DrawPipeline")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+
+	pachClient := a.env.GetPachClient(ctx)
+	if _, err := checkLoggedIn(pachClient); err != nil {
+		return nil, err
+	}
+	var pipelineInfos []*pps.PipelineInfo
+	if err := a.listPipeline(pachClient, &pps.ListPipelineRequest{}, func(pi *pps.PipelineInfo) error {
+		pipelineInfos = append(pipelineInfos, pi)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	var pipelines []string
+	for _, pipeline := range request.Pipelines {
+		pipelines = append(pipelines, pipeline.Name)
+	}
+	graph, err := ppsutil.NewPipelineGraph(pipelineInfos, pipelines)
+	if err != nil {
+		return nil, err
+	}
+	text, err := ppsutil.DrawPipelineGraph(graph, request.Format)
+	if err != nil {
+		return nil, err
+	}
+	return &pps.DrawPipelineResponse{Graph: graph, Text: text}, nil
+}
+
+// InspectCanary implements the protobuf pps.InspectCanary RPC
+func (a *apiServer) InspectCanary(ctx context.Context, request *pps.InspectCanaryRequest) (response *pps.InspectCanaryResponse, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "InspectCanary")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+
+	pachClient := a.env.GetPachClient(ctx)
+	if _, err := checkLoggedIn(pachClient); err != nil {
+		return nil, err
+	}
+	if request.Pipeline == nil {
+		return nil, errors.New("must specify a pipeline")
+	}
+	pipelineInfo, err := a.inspectPipeline(pachClient, request.Pipeline.Name)
+	if err != nil {
+		return nil, err
+	}
+	canaryInfo, err := a.inspectPipeline(pachClient, client.CanaryPipeline(request.Pipeline.Name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not inspect the canary of pipeline %q", request.Pipeline.Name)
+	}
+	if canaryInfo.Canary == nil || canaryInfo.Canary.Pipeline.Name != pipelineInfo.Pipeline.Name {
+		return nil, errors.Errorf("pipeline %q is not a canary of pipeline %q", canaryInfo.Pipeline.Name, pipelineInfo.Pipeline.Name)
+	}
+	response = &pps.InspectCanaryResponse{
+		Canary:       canaryInfo.Pipeline,
+		Spec:         canaryInfo.Canary,
+		LastJobState: canaryInfo.LastJobState,
+	}
+	canaryRepo, canaryBranch := canaryInfo.Pipeline.Name, canaryInfo.OutputBranch
+	newFiles, oldFiles, err := pachClient.DiffFile(
+		canaryRepo, canaryBranch, "/",
+		pipelineInfo.Pipeline.Name, pipelineInfo.OutputBranch, "/",
+		false)
+	if err != nil {
+		return nil, err
+	}
+	var total int64
+	if err := pachClient.Walk(canaryRepo, canaryBranch, "/", func(fileInfo *pfs.FileInfo) error {
+		if fileInfo.FileType == pfs.FileType_FILE {
+			total++
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sampled := canaryInfo.Canary.SamplePercent > 0 && canaryInfo.Canary.SamplePercent < 100
+	ppsutil.DiffCanaryOutputs(response, newFiles, oldFiles, total, sampled, request.MaxPaths)
+	return response, nil
+}
+
 func (a *apiServer) listPipeline(pachClient *client.APIClient, request *pps.ListPipelineRequest, f func(*pps.PipelineInfo) error) error {
 	var jqCode *gojq.Code
 	var enc serde.Encoder
@@ -3005,8 +3841,24 @@ func (a *apiServer) deletePipeline(pachClient *client.APIClient, request *pps.De
 			); err != nil {
 				return nil, err
 			}
+			for _, output := range pipelineInfo.Outputs {
+				if err := pachClient.CreateBranch(
+					client.OutputRepo(request.Pipeline.Name, output),
+					pipelineInfo.OutputBranch,
+					pipelineInfo.OutputBranch,
+					nil,
+				); err != nil && !isNotFoundErr(err) {
+					return nil, err
+				}
+			}
 		} else {
-			// delete the pipeline's output repo
+			// delete the pipeline's named output repos, which are downstream of
+			// its output repo, and then the output repo
+			for _, output := range pipelineInfo.Outputs {
+				if err := pachClient.DeleteRepo(client.OutputRepo(request.Pipeline.Name, output), request.Force, request.SplitTransaction); err != nil && !isNotFoundErr(err) {
+					return nil, err
+				}
+			}
 			if err := pachClient.DeleteRepo(request.Pipeline.Name, request.Force, request.SplitTransaction); err != nil {
 				return nil, err
 			}
@@ -3521,6 +4373,273 @@ func (a *apiServer) ListSecret(ctx context.Context, in *types.Empty) (response *
 	}, nil
 }
 
+// CreateTemplate implements the protobuf pps.CreateTemplate RPC
+func (a *apiServer) CreateTemplate(ctx context.Context, request *pps.CreateTemplateRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "CreateTemplate")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	var owner string
+	if me, err := pachClient.WhoAmI(ctx, &auth.WhoAmIRequest{}); err == nil {
+		owner = me.Username
+	} else if !auth.IsErrNotActivated(err) {
+		return nil, err
+	}
+	if request.Template == nil || request.Template.Name == "" {
+		return nil, errors.New("must specify a template name")
+	}
+	if err := ancestry.ValidateName(request.Template.Name); err != nil {
+		return nil, errors.Wrapf(err, "invalid template name")
+	}
+	if err := ppsutil.ValidateTemplate(request.Parameters, request.Spec); err != nil {
+		return nil, errors.Wrapf(err, "invalid template")
+	}
+
+	templateInfo := &pps.TemplateInfo{
+		Template:   request.Template,
+		Version:    1,
+		Parameters: request.Parameters,
+		Spec:       request.Spec,
+		CreatedAt:  now(),
+		Owner:      owner,
+	}
+	prevTemplateInfo := &pps.TemplateInfo{}
+	if err := a.templates.ReadOnly(ctx).Get(request.Template.Name, prevTemplateInfo); err == nil {
+		if !request.Update {
+			return nil, errors.Errorf("template %q already exists", request.Template.Name)
+		}
+		if err := authorizeTemplateOp(pachClient, prevTemplateInfo, "update"); err != nil {
+			return nil, err
+		}
+		templateInfo.Version = prevTemplateInfo.Version + 1
+		templateInfo.Owner = prevTemplateInfo.Owner
+	} else if !col.IsErrNotFound(err) {
+		return nil, err
+	}
+
+	// Check that every pipeline instantiated from the template can be updated
+	// to its new version before anything is changed, so that a template isn't
+	// propagated to only some of its pipelines
+	var updates []*pps.CreatePipelineRequest
+	if request.Update && request.Propagate {
+		var err error
+		if updates, err = a.templatePipelineUpdates(pachClient, templateInfo); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		templates := a.templates.ReadWrite(stm)
+		currTemplateInfo := &pps.TemplateInfo{}
+		if err := templates.Get(request.Template.Name, currTemplateInfo); err != nil && !col.IsErrNotFound(err) {
+			return err
+		}
+		// The previous version was authorized and validated against above
+		if currTemplateInfo.Version != templateInfo.Version-1 {
+			return errors.Errorf("template %q was modified concurrently; please try again", request.Template.Name)
+		}
+		return templates.Put(request.Template.Name, templateInfo)
+	}); err != nil {
+		return nil, err
+	}
+
+	// Update every pipeline, even if some fail, and report each failure
+	var failed []string
+	for _, update := range updates {
+		if _, err := a.CreatePipeline(ctx, update); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", update.Pipeline.Name, err))
+		}
+	}
+	if len(failed) > 0 {
+		return nil, errors.Errorf("could not update %d of the %d pipelines instantiated from template %q:\n%s",
+			len(failed), len(updates), request.Template.Name, strings.Join(failed, "\n"))
+	}
+	return &types.Empty{}, nil
+}
+
+// authorizeTemplateOp checks that the caller can perform 'op' on the template
+// in 'templateInfo', i.e. that they're its owner or an admin
+func authorizeTemplateOp(pachClient *client.APIClient, templateInfo *pps.TemplateInfo, op string) error {
+	me, err := pachClient.WhoAmI(pachClient.Ctx(), &auth.WhoAmIRequest{})
+	if auth.IsErrNotActivated(err) {
+		return nil // Auth isn't activated, skip authorization completely
+	} else if err != nil {
+		return err
+	}
+	if templateInfo.Owner != "" && me.Username == templateInfo.Owner {
+		return nil
+	}
+	for _, role := range me.ClusterRoles.Roles {
+		if role == auth.ClusterRole_SUPER {
+			return nil
+		}
+	}
+	return errors.Wrapf(&auth.ErrNotAuthorized{Subject: me.Username}, "only the owner of template %q or an admin can %s it", templateInfo.Template.Name, op)
+}
+
+// templatePipelineUpdates returns the requests that update every pipeline
+// instantiated from 'templateInfo' to its version, keeping their arguments.
+// It returns an error if any of them is invalid, or can't be updated by the
+// caller.
+func (a *apiServer) templatePipelineUpdates(pachClient *client.APIClient, templateInfo *pps.TemplateInfo) ([]*pps.CreatePipelineRequest, error) {
+	pipelineInfos, err := a.ListPipeline(pachClient.Ctx(), &pps.ListPipelineRequest{})
+	if err != nil {
+		return nil, err
+	}
+	var result []*pps.CreatePipelineRequest
+	var invalid []string
+	for _, pipelineInfo := range pipelineInfos.PipelineInfo {
+		if pipelineInfo.Template == nil || pipelineInfo.Template.Template.Name != templateInfo.Template.Name {
+			continue
+		}
+		if err := a.validateTemplatePipelineUpdate(pachClient, templateInfo, pipelineInfo); err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s: %v", pipelineInfo.Pipeline.Name, err))
+			continue
+		}
+		result = append(result, &pps.CreatePipelineRequest{
+			Pipeline: pipelineInfo.Pipeline,
+			Template: &pps.TemplateRef{
+				Template: templateInfo.Template,
+				Args:     pipelineInfo.Template.Args,
+			},
+			Update: true,
+		})
+	}
+	if len(invalid) > 0 {
+		return nil, errors.Errorf("could not propagate template %q, as %d of the pipelines instantiated from it can't be updated:\n%s",
+			templateInfo.Template.Name, len(invalid), strings.Join(invalid, "\n"))
+	}
+	return result, nil
+}
+
+// validateTemplatePipelineUpdate checks that 'pipelineInfo' can be updated
+// to the version of its template in 'templateInfo', as CreatePipeline would
+func (a *apiServer) validateTemplatePipelineUpdate(pachClient *client.APIClient, templateInfo *pps.TemplateInfo, pipelineInfo *pps.PipelineInfo) error {
+	request, err := ppsutil.RenderTemplate(templateInfo, pipelineInfo.Template.Args)
+	if err != nil {
+		return err
+	}
+	request.Pipeline = pipelineInfo.Pipeline
+	request.Update = true
+	if err := a.validatePipelineRequest(request); err != nil {
+		return err
+	}
+	updated := newPipelineInfo(request)
+	if err := setPipelineDefaults(updated); err != nil {
+		return err
+	}
+	if err := a.validatePipeline(pachClient, updated); err != nil {
+		return err
+	}
+	return a.authorizePipelineOp(pachClient, pipelineOpUpdate, updated.Input, updated.Pipeline.Name)
+}
+
+// renderTemplate renders the template that 'request' refers to, and returns
+// the resulting request
+func (a *apiServer) renderTemplate(ctx context.Context, request *pps.CreatePipelineRequest) (*pps.CreatePipelineRequest, error) {
+	if request.Template.Template == nil || request.Template.Template.Name == "" {
+		return nil, errors.New("must specify a template name")
+	}
+	name := request.Template.Template.Name
+	templateInfo := &pps.TemplateInfo{}
+	if err := a.templates.ReadOnly(ctx).Get(name, templateInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("template %q not found", name)
+		}
+		return nil, err
+	}
+	result, err := ppsutil.RenderTemplate(templateInfo, request.Template.Args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not instantiate template %q", name)
+	}
+	if request.Pipeline != nil {
+		result.Pipeline = request.Pipeline
+	}
+	result.Update = request.Update
+	result.Reprocess = request.Reprocess
+	return result, nil
+}
+
+// InspectTemplate implements the protobuf pps.InspectTemplate RPC
+func (a *apiServer) InspectTemplate(ctx context.Context, request *pps.InspectTemplateRequest) (response *pps.TemplateInfo, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	if _, err := checkLoggedIn(pachClient); err != nil {
+		return nil, err
+	}
+	if request.Template == nil {
+		return nil, errors.New("must specify a template")
+	}
+	templateInfo := &pps.TemplateInfo{}
+	if err := a.templates.ReadOnly(ctx).Get(request.Template.Name, templateInfo); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil, errors.Errorf("template %q not found", request.Template.Name)
+		}
+		return nil, err
+	}
+	return templateInfo, nil
+}
+
+// ListTemplate implements the protobuf pps.ListTemplate RPC
+func (a *apiServer) ListTemplate(ctx context.Context, request *types.Empty) (response *pps.TemplateInfos, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	if _, err := checkLoggedIn(pachClient); err != nil {
+		return nil, err
+	}
+	response = &pps.TemplateInfos{}
+	templateInfo := &pps.TemplateInfo{}
+	if err := a.templates.ReadOnly(ctx).List(templateInfo, col.DefaultOptions, func(string) error {
+		templateInfoCopy := *templateInfo
+		response.TemplateInfo = append(response.TemplateInfo, &templateInfoCopy)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// DeleteTemplate implements the protobuf pps.DeleteTemplate RPC. Pipelines
+// that were instantiated from the template are not affected.
+func (a *apiServer) DeleteTemplate(ctx context.Context, request *pps.DeleteTemplateRequest) (response *types.Empty, retErr error) {
+	func() { a.Log(request, nil, nil, 0) }()
+	defer func(start time.Time) { a.Log(request, response, retErr, time.Since(start)) }(time.Now())
+	metricsFn := metrics.ReportUserAction(ctx, a.reporter, "DeleteTemplate")
+	defer func(start time.Time) { metricsFn(start, retErr) }(time.Now())
+
+	pachClient := a.env.GetPachClient(ctx)
+	ctx = pachClient.Ctx() // pachClient will propagate auth info
+	if request.Template == nil {
+		return nil, errors.New("must specify a template")
+	}
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		templates := a.templates.ReadWrite(stm)
+		templateInfo := &pps.TemplateInfo{}
+		if err := templates.Get(request.Template.Name, templateInfo); err != nil {
+			if col.IsErrNotFound(err) {
+				return errors.Errorf("template %q not found", request.Template.Name)
+			}
+			return err
+		}
+		if err := authorizeTemplateOp(pachClient, templateInfo, "delete"); err != nil {
+			return err
+		}
+		return templates.Delete(request.Template.Name)
+	}); err != nil {
+		return nil, err
+	}
+	return &types.Empty{}, nil
+}
+
 // DeleteAll implements the protobuf pps.DeleteAll RPC
 func (a *apiServer) DeleteAll(ctx context.Context, request *types.Empty) (response *types.Empty, retErr error) {
 	func() { a.Log(request, nil, nil, 0) }()
@@ -3558,6 +4677,13 @@ func (a *apiServer) DeleteAll(ctx context.Context, request *types.Empty) (respon
 		return nil, err
 	}
 
+	if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		a.templates.ReadWrite(stm).DeleteAll()
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
 	// PFS doesn't delete the spec repo, so do it here
 	if err := pachClient.DeleteRepo(ppsconsts.SpecRepo, true); err != nil && !isNotFoundErr(err) {
 		return nil, err
@@ -3689,6 +4815,27 @@ func CollectActiveObjectsAndTags(ctx context.Context, pachClient *client.APIClie
 		return nil, err
 	}
 
+	// Entries in the global datum cache are kept only as long as their outputs
+	// are still the output of a datum of some pipeline (i.e. are tagged with
+	// the datum tag of a pipeline, which was added above). Once every pipeline
+	// that produced or reused an output is deleted, the entry is evicted.
+	globalTags, err := pachClient.ObjectAPIClient.ListTags(pachClient.Ctx(), &pfs.ListTagsRequest{
+		Prefix:        client.GlobalDatumTagPrefix,
+		IncludeObject: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "error listing global datum cache")
+	}
+	for resp, err := globalTags.Recv(); !errors.Is(err, io.EOF); resp, err = globalTags.Recv() {
+		if err != nil {
+			return nil, err
+		}
+		if resp.Object != nil && result.Objects.TestString(resp.Object.Hash) {
+			result.Tags.AddString(resp.Tag.Name)
+			result.NTags++
+		}
+	}
+
 	return result, nil
 }
 
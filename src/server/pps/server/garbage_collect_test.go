@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+)
+
+func TestCollectGlobalDatumCache(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		pipeline := &pps.PipelineInfo{Salt: "salt"}
+		// 'shared' is the output of one of the pipeline's datums, and 'orphaned'
+		// was only the output of a datum of a pipeline that has been deleted
+		_, _, err := c.PutObject(strings.NewReader("shared"), client.DatumTagPrefix(pipeline.Salt)+"datum", client.GlobalDatumTagPrefix+"shared")
+		require.NoError(t, err)
+		_, _, err = c.PutObject(strings.NewReader("orphaned"), client.GlobalDatumTagPrefix+"orphaned")
+		require.NoError(t, err)
+
+		activeStat, err := CollectActiveObjectsAndTags(context.Background(), c, nil, []*pps.PipelineInfo{pipeline}, 0, "")
+		require.NoError(t, err)
+		require.True(t, activeStat.Tags.TestString(client.GlobalDatumTagPrefix+"shared"))
+		require.False(t, activeStat.Tags.TestString(client.GlobalDatumTagPrefix+"orphaned"))
+
+		// Once the pipeline is deleted too, its outputs are evicted
+		activeStat, err = CollectActiveObjectsAndTags(context.Background(), c, nil, nil, 0, "")
+		require.NoError(t, err)
+		require.False(t, activeStat.Tags.TestString(client.GlobalDatumTagPrefix+"shared"))
+		return nil
+	}))
+}
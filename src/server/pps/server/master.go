@@ -15,6 +15,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pkg/tracing"
 	"github.com/pachyderm/pachyderm/src/client/pps"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
 	"github.com/pachyderm/pachyderm/src/server/pkg/dlock"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
@@ -57,6 +58,14 @@ func (a *apiServer) master() {
 
 		// start pollPipelines in the background to regularly refresh pipelines
 		a.startPipelinePoller(pachClient)
+		// start sendNotifications in the background to deliver job and pipeline
+		// notifications
+		a.startNotifier(pachClient)
+		// apply the configured queue limits, which workers read when admitting
+		// jobs to their pipeline's queue
+		if err := a.setQueueLimits(ctx); err != nil {
+			return err
+		}
 
 		// TODO(msteffen) request only keys, since pipeline_controller.go reads
 		// fresh values for each event anyway
@@ -167,12 +176,37 @@ func (a *apiServer) master() {
 		// subsequent iteration
 		a.cancelAllMonitorsAndCrashingMonitors(nil)
 		a.cancelPipelinePoller()
+		a.cancelNotifier()
 		log.Errorf("PPS master: error running the master process: %v; retrying in %v", err, d)
 		return nil
 	})
 	panic("internal error: PPS master has somehow exited. Restarting pod...")
 }
 
+// setQueueLimits writes the queue limits in pachd's configuration to each job
+// queue's etcd record. Queues without a configured limit are unlimited.
+func (a *apiServer) setQueueLimits(ctx context.Context) error {
+	limits := make(map[string]int64)
+	queue := &pps.JobQueue{}
+	if err := a.jobQueues.ReadOnly(ctx).List(queue, col.DefaultOptions, func(name string) error {
+		limits[name] = 0
+		return nil
+	}); err != nil {
+		return err
+	}
+	for name, limit := range a.queueLimits {
+		limits[name] = limit
+	}
+	for name, limit := range limits {
+		if _, err := col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+			return ppsutil.SetQueueLimit(a.jobQueues.ReadWrite(stm), name, limit)
+		}); err != nil {
+			return errors.Wrapf(err, "could not set the limit of queue %q", name)
+		}
+	}
+	return nil
+}
+
 func (a *apiServer) setPipelineFailure(ctx context.Context, pipelineName string, reason string) error {
 	return a.setPipelineState(ctx, pipelineName, pps.PipelineState_PIPELINE_FAILURE, reason)
 }
@@ -24,9 +24,9 @@ import (
 
 	"github.com/gogo/protobuf/types"
 	opentracing "github.com/opentracing/opentracing-go"
-	"github.com/robfig/cron"
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/sync/errgroup"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
@@ -36,12 +36,26 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pps"
 	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/cronutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/work"
 	workerserver "github.com/pachyderm/pachyderm/src/server/worker/server"
 )
 
-const crashingBackoff = time.Second * 15
+const (
+	crashingBackoff = time.Second * 15
+
+	// autoscalingInterval is how often the PPS master checks the task queue of
+	// an autoscaled pipeline
+	autoscalingInterval = time.Second * 10
+	// autoscalingCooldown is how long an autoscaled pipeline's task queue must
+	// stay shorter than its worker pool before any workers are removed. This
+	// keeps pipelines whose jobs arrive in quick succession from thrashing.
+	autoscalingCooldown = time.Minute * 2
+)
 
 // startMonitorThread is a helper used by startMonitor, startCrashingMonitor,
 // and startPipelinePoller (in poller.go). It doesn't manipulate any of
@@ -189,6 +203,14 @@ func (a *apiServer) monitorPipeline(pachClient *client.APIClient, pipelineInfo *
 			})
 		}
 	})
+	if pipelineInfo.Autoscaling != nil {
+		eg.Go(func() error {
+			return backoff.RetryNotify(func() error {
+				return a.autoscalePipeline(pachClient, pipelineInfo)
+			}, backoff.NewInfiniteBackOff(),
+				backoff.NotifyCtx(pachClient.Ctx(), "autoscaling for "+pipeline))
+		})
+	}
 	if pipelineInfo.Standby {
 		// Capacity 1 gives us a bit of buffer so we don't needlessly go into
 		// standby when SubscribeCommit takes too long to return.
@@ -339,9 +361,124 @@ func (a *apiServer) monitorPipeline(pachClient *client.APIClient, pipelineInfo *
 	}
 }
 
+// autoscaledWorkers returns the number of workers that an autoscaled pipeline
+// should run to process 'subtasks' outstanding subtasks, holding 'datums'
+// outstanding datums: one per subtask, but no more than one per
+// TargetDatumsPerWorker datums, within the bounds of its autoscaling spec. At
+// least one worker is always kept, as the pipeline's worker master runs in one
+// of them.
+func autoscaledWorkers(subtasks, datums int64, spec *pps.AutoscalingSpec) int {
+	workers := subtasks
+	if target := int64(spec.TargetDatumsPerWorker); target > 0 {
+		if byDatums := (datums + target - 1) / target; byDatums < workers {
+			workers = byDatums
+		}
+	}
+	if min := int64(spec.MinWorkers); workers < min {
+		workers = min
+	}
+	if max := int64(spec.MaxWorkers); workers > max {
+		workers = max
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return int(workers)
+}
+
+// outstandingDatums returns the number of datums that the running jobs of
+// 'pipeline' haven't finished processing yet
+func outstandingDatums(ctx context.Context, jobs col.Collection, pipeline *pps.Pipeline) (int64, error) {
+	var datums int64
+	jobPtr := &pps.EtcdJobInfo{}
+	if err := jobs.ReadOnly(ctx).GetByIndex(ppsdb.JobsPipelineIndex, pipeline, jobPtr, col.DefaultOptions, func(string) error {
+		if jobPtr.State != pps.JobState_JOB_RUNNING {
+			return nil
+		}
+		if remaining := jobPtr.DataTotal - jobPtr.DataProcessed - jobPtr.DataSkipped - jobPtr.DataFailed - jobPtr.DataRecovered; remaining > 0 {
+			datums += remaining
+		}
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+	return datums, nil
+}
+
+// autoscalePipeline is a helper used by monitorPipeline. It periodically
+// resizes the RC of an autoscaled pipeline to match the number of subtasks
+// waiting in the pipeline's task queue. Workers are added as soon as they're
+// needed, but only removed once the queue has drained and stayed empty for
+// autoscalingCooldown.
+//
+// Kubernetes chooses which pods to remove when an RC is scaled down, so the
+// pool is only shrunk while none of its workers hold a subtask; otherwise a
+// removed worker's subtask would have to be processed again from the start.
+func (a *apiServer) autoscalePipeline(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) error {
+	ctx := pachClient.Ctx()
+	rcName := ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+	rcs := a.env.GetKubeClient().CoreV1().ReplicationControllers(a.namespace)
+	taskNamespace := ppsutil.WorkNamespace(pipelineInfo)
+	lastBusy := time.Now()
+	ticker := time.NewTicker(autoscalingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		subtasks, err := work.OutstandingSubtasks(ctx, a.env.GetEtcdClient(), a.etcdPrefix, taskNamespace)
+		if err != nil {
+			return err
+		}
+		datums, err := outstandingDatums(ctx, a.jobs, pipelineInfo.Pipeline)
+		if err != nil {
+			return err
+		}
+		rc, err := rcs.Get(rcName, metav1.GetOptions{})
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if rc.Spec.Replicas == nil || *rc.Spec.Replicas == 0 {
+			// The pipeline is paused or in standby, and the pipeline controller
+			// will scale it up when it's needed again
+			continue
+		}
+		current := int(*rc.Spec.Replicas)
+		desired := autoscaledWorkers(subtasks, datums, pipelineInfo.Autoscaling)
+		if subtasks > 0 {
+			lastBusy = time.Now()
+		}
+		if desired == current || (desired < current && time.Since(lastBusy) < autoscalingCooldown) {
+			continue
+		}
+		replicas := int32(desired)
+		rc.Spec.Replicas = &replicas
+		if _, err := rcs.Update(rc); err != nil {
+			return errors.EnsureStack(err)
+		}
+		log.Infof("PPS master: autoscaled pipeline %q from %d to %d workers (%d outstanding subtasks, %d outstanding datums)",
+			pipelineInfo.Pipeline.Name, current, desired, subtasks, datums)
+	}
+}
+
 // allWorkersUp is a helper used by monitorCrashingPipeline
 func (a *apiServer) allWorkersUp(ctx context.Context, parallelism64 uint64, pipelineInfo *pps.PipelineInfo) (bool, error) {
 	parallelism := int(parallelism64)
+	if pipelineInfo.Autoscaling != nil {
+		// The size of an autoscaled pipeline's worker pool changes, so compare
+		// against its current size
+		rc, err := a.env.GetKubeClient().CoreV1().ReplicationControllers(a.namespace).Get(
+			ppsutil.PipelineRcName(pipelineInfo.Pipeline.Name, pipelineInfo.Version),
+			metav1.GetOptions{})
+		if err != nil {
+			return false, errors.EnsureStack(err)
+		}
+		if rc.Spec.Replicas != nil {
+			parallelism = int(*rc.Spec.Replicas)
+		}
+	}
 	if parallelism == 0 {
 		parallelism = 1
 	}
@@ -407,7 +544,7 @@ func (a *apiServer) getLatestCronTime(pachClient *client.APIClient, in *pps.Inpu
 // makeCronCommits makes commits to a single cron input's repo. It's
 // a helper function called by monitorPipeline.
 func (a *apiServer) makeCronCommits(pachClient *client.APIClient, in *pps.Input) error {
-	schedule, err := cron.ParseStandard(in.Cron.Spec)
+	schedule, err := cronutil.ParseSchedule(in.Cron.Spec, in.Cron.Timezone, in.Cron.Jitter, in.Cron.Repo)
 	if err != nil {
 		return err // Shouldn't happen, as the input is validated in CreatePipeline
 	}
@@ -430,9 +567,15 @@ func (a *apiServer) makeCronCommits(pachClient *client.APIClient, in *pps.Input)
 	for {
 		// get the time of the next time from the latest time using the cron schedule
 		next := schedule.Next(latestTime)
-		// and wait until then to make the next commit
+		if in.Cron.CatchUp == pfs.CronCatchUp_CRON_SKIP_MISSED {
+			if now := time.Now(); schedule.Due(next).Before(now) {
+				next = schedule.NextDue(now)
+			}
+		}
+		// and wait until it's due (which includes any jitter) to make the next
+		// commit
 		select {
-		case <-time.After(time.Until(next)):
+		case <-time.After(time.Until(schedule.Due(next))):
 		case <-pachClient.Ctx().Done():
 			return pachClient.Ctx().Err()
 		}
@@ -453,8 +596,10 @@ func (a *apiServer) makeCronCommits(pachClient *client.APIClient, in *pps.Input)
 			}
 		}
 
-		// Put in an empty file named by the timestamp
-		_, err = pachClient.PutFile(in.Cron.Repo, "master", next.Format(time.RFC3339), strings.NewReader(""))
+		// Put in an empty file named by the timestamp. Timestamps are always in
+		// UTC, whatever the input's time zone, so that sorting the files by name
+		// sorts them by time (see getLatestCronTime)
+		_, err = pachClient.PutFile(in.Cron.Repo, "master", next.UTC().Format(time.RFC3339), strings.NewReader(""))
 		if err != nil {
 			return errors.Wrapf(err, "put error")
 		}
@@ -0,0 +1,254 @@
+package server
+
+// notifier.go contains the PPS master's notifier, which delivers the events
+// that ppsutil.UpdateJobState and ppsutil.SetPipelineState queue in each
+// pipeline's EtcdPipelineInfo.PendingNotifications. Because events are queued
+// in the same etcd transaction as the state change that caused them, they're
+// delivered even if the PPS master restarts. Events that can't be delivered
+// are retried with backoff whenever the pipeline is next written (which
+// pollPipelines guarantees happens regularly), up to maxNotificationAttempts
+// times.
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/types"
+	log "github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	col "github.com/pachyderm/pachyderm/src/server/pkg/collection"
+	"github.com/pachyderm/pachyderm/src/server/pkg/watch"
+)
+
+const (
+	// maxNotificationAttempts is the number of times a notification is
+	// attempted before it's dropped
+	maxNotificationAttempts = 10
+	// notificationRetryInterval is the delay before a failed notification's
+	// first retry. It doubles with each subsequent attempt.
+	notificationRetryInterval = 10 * time.Second
+	// notificationTimeout bounds each attempt to deliver a notification
+	notificationTimeout = 30 * time.Second
+
+	// notificationEventHeader is the header in which webhooks are sent the
+	// type of the event in the request's body
+	notificationEventHeader = "Pach-Event"
+	// notificationSignatureHeader is the header in which webhooks are sent the
+	// HMAC-SHA256 signature of the request's body, if the webhook has a secret
+	notificationSignatureHeader = "Pach-Signature"
+)
+
+// startNotifier starts a new goroutine running sendNotifications
+func (a *apiServer) startNotifier(ppsMasterClient *client.APIClient) {
+	a.notifyMu.Lock()
+	defer a.notifyMu.Unlock()
+	a.notifyCancel = startMonitorThread(ppsMasterClient, "sendNotifications", a.sendNotifications)
+}
+
+func (a *apiServer) cancelNotifier() {
+	a.notifyMu.Lock()
+	defer a.notifyMu.Unlock()
+	if a.notifyCancel != nil {
+		a.notifyCancel()
+		a.notifyCancel = nil
+	}
+}
+
+// sendNotifications watches the pipelines collection, and delivers the pending
+// notifications of each pipeline that's written
+func (a *apiServer) sendNotifications(pachClient *client.APIClient) {
+	ctx := pachClient.Ctx()
+	if err := backoff.RetryUntilCancel(ctx, func() error {
+		pipelineWatcher, err := a.pipelines.ReadOnly(ctx).Watch()
+		if err != nil {
+			return errors.Wrapf(err, "error creating watch")
+		}
+		defer pipelineWatcher.Close()
+		for {
+			select {
+			case event := <-pipelineWatcher.Watch():
+				if event.Err != nil {
+					return errors.Wrapf(event.Err, "event err")
+				}
+				if event.Type != watch.EventPut {
+					continue
+				}
+				pipeline := string(event.Key)
+				if err := a.deliverNotifications(pachClient, pipeline); err != nil {
+					log.Errorf("PPS master: could not deliver notifications for %q: %v", pipeline, err)
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}, backoff.NewInfiniteBackOff(), backoff.NotifyContinue("sendNotifications")); err != nil {
+		if ctx.Err() == nil {
+			panic("sendNotifications is exiting prematurely which should not happen; restarting pod...")
+		}
+	}
+}
+
+// deliverNotifications attempts to deliver each of 'pipeline's pending
+// notifications that isn't waiting to be retried, and then removes the
+// delivered notifications from the pipeline's EtcdPipelineInfo.
+func (a *apiServer) deliverNotifications(pachClient *client.APIClient, pipeline string) error {
+	ctx := pachClient.Ctx()
+	// Read the pipeline rather than using the watch event's value, which may
+	// include notifications that were delivered after it was written
+	pipelinePtr := &pps.EtcdPipelineInfo{}
+	if err := a.pipelines.ReadOnly(ctx).Get(pipeline, pipelinePtr); err != nil {
+		if col.IsErrNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	now := time.Now()
+	attempted := make(map[string]bool)
+	failed := make(map[string]bool)
+	for _, pending := range pipelinePtr.PendingNotifications {
+		if !notificationDue(pending, now) {
+			continue
+		}
+		attempted[pending.ID] = true
+		if err := a.deliverNotification(pachClient, pending); err != nil {
+			log.Errorf("PPS master: could not send %s notification for %q (attempt %d): %v",
+				pending.Event.Type, pipeline, pending.Attempts+1, err)
+			failed[pending.ID] = true
+		}
+	}
+	if len(attempted) == 0 {
+		return nil
+	}
+	lastAttempt, err := types.TimestampProto(now)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	_, err = col.NewSTM(ctx, a.env.GetEtcdClient(), func(stm col.STM) error {
+		pipelines := a.pipelines.ReadWrite(stm)
+		pipelinePtr := &pps.EtcdPipelineInfo{}
+		if err := pipelines.Get(pipeline, pipelinePtr); err != nil {
+			return err
+		}
+		var remaining []*pps.PendingNotification
+		for _, pending := range pipelinePtr.PendingNotifications {
+			if !attempted[pending.ID] {
+				remaining = append(remaining, pending)
+				continue
+			}
+			if !failed[pending.ID] {
+				continue
+			}
+			pending.Attempts++
+			pending.LastAttempt = lastAttempt
+			if pending.Attempts >= maxNotificationAttempts {
+				log.Errorf("PPS master: dropping %s notification %s for %q after %d attempts",
+					pending.Event.Type, pending.ID, pipeline, pending.Attempts)
+				continue
+			}
+			remaining = append(remaining, pending)
+		}
+		pipelinePtr.PendingNotifications = remaining
+		return pipelines.Put(pipeline, pipelinePtr)
+	})
+	if col.IsErrNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// notificationDue returns true if 'pending' hasn't been attempted yet, or if
+// its backoff since its last attempt has elapsed
+func notificationDue(pending *pps.PendingNotification, now time.Time) bool {
+	if pending.Attempts == 0 || pending.LastAttempt == nil {
+		return true
+	}
+	lastAttempt, err := types.TimestampFromProto(pending.LastAttempt)
+	if err != nil {
+		return true
+	}
+	return now.Sub(lastAttempt) >= notificationRetryInterval<<uint(pending.Attempts-1)
+}
+
+// deliverNotification sends a single pending notification to its target
+func (a *apiServer) deliverNotification(pachClient *client.APIClient, pending *pps.PendingNotification) error {
+	ctx, cancel := context.WithTimeout(pachClient.Ctx(), notificationTimeout)
+	defer cancel()
+	body, err := (&jsonpb.Marshaler{OrigName: true}).MarshalToString(pending.Event)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if webhook := pending.Notification.Webhook; webhook != nil {
+		var key []byte
+		if webhook.Secret != nil {
+			secret, err := a.env.GetKubeClient().CoreV1().Secrets(a.namespace).Get(webhook.Secret.Name, metav1.GetOptions{})
+			if err != nil {
+				return errors.Wrapf(err, "could not get webhook secret %q", webhook.Secret.Name)
+			}
+			var ok bool
+			if key, ok = secret.Data[webhook.Secret.Key]; !ok {
+				return errors.Errorf("webhook secret %q has no key %q", webhook.Secret.Name, webhook.Secret.Key)
+			}
+		}
+		return sendWebhook(ctx, webhook.URL, key, pending.Event.Type, []byte(body))
+	}
+	return a.sudo(pachClient.WithCtx(ctx), func(superUserClient *client.APIClient) error {
+		_, err := superUserClient.PutFile(pending.Notification.Repo, "master",
+			notificationPath(pending.Event), strings.NewReader(body))
+		return err
+	})
+}
+
+// sendWebhook POSTs 'body' to 'url'. If 'key' is set, the body's HMAC-SHA256
+// signature is sent in the Pach-Signature header.
+func sendWebhook(ctx context.Context, url string, key []byte, eventType pps.NotificationEventType, body []byte) error {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(notificationEventHeader, eventType.String())
+	if key != nil {
+		req.Header.Set(notificationSignatureHeader, "sha256="+signNotification(key, body))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// signNotification returns the hex-encoded HMAC-SHA256 signature of 'body'
+func signNotification(key, body []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// notificationPath is the path at which 'event' is written into a repo
+// notification target
+func notificationPath(event *pps.NotificationEvent) string {
+	timestamp := "unknown"
+	if t, err := types.TimestampFromProto(event.Time); err == nil {
+		timestamp = t.UTC().Format("20060102T150405Z")
+	}
+	return path.Join("/", event.Pipeline.Name, fmt.Sprintf("%s-%s.json", timestamp, event.ID))
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestSendWebhook(t *testing.T) {
+	body := []byte(`{"type":"NOTIFY_JOB_FAILURE"}`)
+	key := []byte("hunter2")
+	var received *http.Request
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	require.NoError(t, sendWebhook(context.Background(), server.URL, key, pps.NotificationEventType_NOTIFY_JOB_FAILURE, body))
+	require.Equal(t, http.MethodPost, received.Method)
+	require.Equal(t, body, receivedBody)
+	require.Equal(t, "NOTIFY_JOB_FAILURE", received.Header.Get(notificationEventHeader))
+	require.Equal(t, "sha256="+signNotification(key, body), received.Header.Get(notificationSignatureHeader))
+
+	// Without a key, requests aren't signed
+	require.NoError(t, sendWebhook(context.Background(), server.URL, nil, pps.NotificationEventType_NOTIFY_JOB_FAILURE, body))
+	require.Equal(t, "", received.Header.Get(notificationSignatureHeader))
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	require.YesError(t, sendWebhook(context.Background(), failing.URL, nil, pps.NotificationEventType_NOTIFY_JOB_FAILURE, body))
+}
+
+func TestSignNotification(t *testing.T) {
+	// Test vector from RFC 4231, test case 2
+	require.Equal(t,
+		"5bdcc146bf60754e6a042426089575c75a003f089d2739839dec58b964ec3843",
+		signNotification([]byte("Jefe"), []byte("what do ya want for nothing?")))
+}
+
+func TestNotificationDue(t *testing.T) {
+	now := time.Now()
+	require.True(t, notificationDue(&pps.PendingNotification{}, now))
+
+	lastAttempt, err := types.TimestampProto(now.Add(-15 * time.Second))
+	require.NoError(t, err)
+	pending := &pps.PendingNotification{Attempts: 1, LastAttempt: lastAttempt}
+	require.True(t, notificationDue(pending, now))
+	// The delay doubles with each attempt
+	pending.Attempts = 2
+	require.False(t, notificationDue(pending, now))
+}
@@ -82,3 +82,38 @@ func TestGetExpectedNumWorkers(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, parellelism)
 }
+
+func TestAutoscaledWorkers(t *testing.T) {
+	spec := &pps.AutoscalingSpec{MinWorkers: 2, MaxWorkers: 10}
+	targetSpec := &pps.AutoscalingSpec{MinWorkers: 2, MaxWorkers: 10, TargetDatumsPerWorker: 50}
+	for _, c := range []struct {
+		name     string
+		subtasks int64
+		datums   int64
+		spec     *pps.AutoscalingSpec
+		expected int
+	}{
+		{name: "never shrinks below the minimum", subtasks: 0, datums: 0, spec: spec, expected: 2},
+		{name: "one worker per outstanding subtask", subtasks: 7, datums: 700, spec: spec, expected: 7},
+		{name: "never grows beyond the maximum", subtasks: 100, datums: 10000, spec: spec, expected: 10},
+		{name: "target above the datums per subtask", subtasks: 7, datums: 700, spec: targetSpec, expected: 7},
+		{name: "one worker per target outstanding datums", subtasks: 7, datums: 101, spec: targetSpec, expected: 3},
+		{name: "target never goes below the minimum", subtasks: 7, datums: 10, spec: targetSpec, expected: 2},
+		// At least one worker is always kept, to run the worker master
+		{name: "keeps one worker", subtasks: 0, datums: 0, spec: &pps.AutoscalingSpec{MaxWorkers: 10}, expected: 1},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			require.Equal(t, c.expected, autoscaledWorkers(c.subtasks, c.datums, c.spec))
+		})
+	}
+}
+
+func TestGetExpectedNumWorkersAutoscaling(t *testing.T) {
+	// Autoscaled pipelines split their work for their maximum number of
+	// workers, without asking kubernetes how many nodes there are
+	pipelineInfo := wrap(t, &pps.ParallelismSpec{Coefficient: 2})
+	pipelineInfo.Autoscaling = &pps.AutoscalingSpec{MinWorkers: 1, MaxWorkers: 6}
+	workers, err := getExpectedNumWorkers(nil, pipelineInfo)
+	require.NoError(t, err)
+	require.Equal(t, 6, workers)
+}
@@ -584,6 +584,17 @@ func (op *pipelineOp) scaleUpPipeline() (retErr error) {
 
 	// compute target pipeline parallelism
 	parallelism := int(op.ptr.Parallelism)
+	if autoscaling := op.pipelineInfo.Autoscaling; autoscaling != nil {
+		// Autoscaled pipelines start with their minimum number of workers.
+		// Their pool is resized by the pipeline's monitor after that, so
+		// leave it alone if it's already been sized.
+		parallelism = autoscaledWorkers(0, 0, autoscaling)
+		if op.rc.Spec.Replicas != nil {
+			if current := int(*op.rc.Spec.Replicas); current >= parallelism && current <= int(autoscaling.MaxWorkers) {
+				parallelism = current
+			}
+		}
+	}
 	if parallelism == 0 {
 		log.Errorf("PPS master: error getting number of workers (defaulting to 1 worker)")
 		parallelism = 1
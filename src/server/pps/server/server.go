@@ -1,10 +1,12 @@
 package server
 
 import (
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	ppsclient "github.com/pachyderm/pachyderm/src/client/pps"
 	"github.com/pachyderm/pachyderm/src/server/pkg/log"
 	"github.com/pachyderm/pachyderm/src/server/pkg/metrics"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsdb"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/serviceenv"
 	txnenv "github.com/pachyderm/pachyderm/src/server/pkg/transactionenv"
 )
@@ -38,6 +40,14 @@ func NewAPIServer(
 	peerPort uint16,
 	gcPercent int,
 ) (APIServer, error) {
+	queueLimits, err := ppsutil.ParseQueueLimits(env.PPSQueueLimits)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid PPS_QUEUE_LIMITS")
+	}
+	priorityClasses, err := ppsutil.ParsePriorityClasses(env.PPSPriorityClasses)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid PPS_PRIORITY_CLASSES")
+	}
 	apiServer := &apiServer{
 		Logger:                 log.NewLogger("pps.API"),
 		env:                    env,
@@ -57,6 +67,10 @@ func NewAPIServer(
 		workerUsesRoot:         workerUsesRoot,
 		pipelines:              ppsdb.Pipelines(env.GetEtcdClient(), etcdPrefix),
 		jobs:                   ppsdb.Jobs(env.GetEtcdClient(), etcdPrefix),
+		templates:              ppsdb.Templates(env.GetEtcdClient(), etcdPrefix),
+		jobQueues:              ppsdb.JobQueues(env.GetEtcdClient(), etcdPrefix),
+		queueLimits:            queueLimits,
+		priorityClasses:        priorityClasses,
 		monitorCancels:         make(map[string]func()),
 		crashingMonitorCancels: make(map[string]func()),
 		workerGrpcPort:         workerGrpcPort,
@@ -518,6 +518,34 @@ func (a *apiServer) getWorkerOptions(ptr *pps.EtcdPipelineInfo, pipelineInfo *pp
 		}
 	}
 
+	if secret := egressSecret(pipelineInfo.Egress); secret != nil {
+		workerEnv = append(workerEnv, v1.EnvVar{
+			Name: client.PPSEgressSecretEnv,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: secret.Key,
+				},
+			},
+		})
+	}
+
+	if secret := pipelineInfo.Spout.GetHTTP().GetSecret(); secret != nil {
+		workerEnv = append(workerEnv, v1.EnvVar{
+			Name: client.PPSSpoutSecretEnv,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: secret.Key,
+				},
+			},
+		})
+	}
+
 	volumes = append(volumes, v1.Volume{
 		Name: "pach-bin",
 		VolumeSource: v1.VolumeSource{
@@ -583,6 +611,18 @@ func (a *apiServer) getWorkerOptions(ptr *pps.EtcdPipelineInfo, pipelineInfo *pp
 	} else {
 		service = pipelineInfo.Service
 	}
+	// Schedule the pipeline's workers with the PriorityClass that its priority
+	// maps to, unless its scheduling spec sets one
+	schedulingSpec := pipelineInfo.SchedulingSpec
+	if schedulingSpec == nil || schedulingSpec.PriorityClassName == "" {
+		if class := ppsutil.PriorityClassName(a.priorityClasses, pipelineInfo.Priority); class != "" {
+			schedulingSpec = &pps.SchedulingSpec{PriorityClassName: class}
+			if pipelineInfo.SchedulingSpec != nil {
+				schedulingSpec.NodeSelector = pipelineInfo.SchedulingSpec.NodeSelector
+			}
+		}
+	}
+
 	var s3GatewayPort int32
 	if ppsutil.ContainsS3Inputs(pipelineInfo.Input) || pipelineInfo.S3Out {
 		s3GatewayPort = int32(a.env.S3GatewayPort)
@@ -606,7 +646,7 @@ func (a *apiServer) getWorkerOptions(ptr *pps.EtcdPipelineInfo, pipelineInfo *pp
 		imagePullSecrets:      imagePullSecrets,
 		cacheSize:             pipelineInfo.CacheSize,
 		service:               service,
-		schedulingSpec:        pipelineInfo.SchedulingSpec,
+		schedulingSpec:        schedulingSpec,
 		podSpec:               pipelineInfo.PodSpec,
 		podPatch:              pipelineInfo.PodPatch,
 	}, nil
@@ -787,3 +827,15 @@ func getGithookService(kubeClient *kube.Clientset, namespace string) (*v1.Servic
 	}
 	return &serviceList.Items[0], nil
 }
+
+// egressSecret returns the secret holding the credentials for a pipeline's
+// egress target, if any
+func egressSecret(egress *pps.Egress) *pps.EgressSecret {
+	switch {
+	case egress.GetSQL() != nil:
+		return egress.SQL.Secret
+	case egress.GetHTTP() != nil:
+		return egress.HTTP.Secret
+	}
+	return nil
+}
@@ -111,6 +111,8 @@ func sprintUpdateJobState(request *pps.UpdateJobStateRequest) string {
 		switch request.State {
 		case pps.JobState_JOB_STARTING:
 			return "STARTING"
+		case pps.JobState_JOB_QUEUED:
+			return "QUEUED"
 		case pps.JobState_JOB_RUNNING:
 			return "RUNNING"
 		case pps.JobState_JOB_FAILURE:
@@ -4,7 +4,13 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/binary"
 	"encoding/hex"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pps"
@@ -20,6 +26,25 @@ func IsDone(ctx context.Context) bool {
 	}
 }
 
+// InputRoot returns the directory, relative to the root of a datum, under
+// which 'input's files are placed. That's the input's name, plus the
+// input's WindowDir if the input is part of a window.
+func InputRoot(input *Input) string {
+	if input.Window {
+		return filepath.Join(input.Name, WindowDir(input))
+	}
+	return input.Name
+}
+
+// WindowDir returns the name of the directory of a window's input, which is
+// the position of the input's commit in the window, counting from the oldest
+// commit and padded so that the directories sort in order, followed by the
+// commit's ID, e.g. "07-<commit id>"
+func WindowDir(input *Input) string {
+	width := len(strconv.FormatInt(input.WindowSize-1, 10))
+	return fmt.Sprintf("%0*d-%s", width, input.WindowIndex, input.FileInfo.File.Commit.ID)
+}
+
 // DatumID computes the id for a datum, this value is used in ListDatum and
 // InspectDatum.
 func DatumID(inputs []*Input) string {
@@ -27,6 +52,11 @@ func DatumID(inputs []*Input) string {
 	for _, input := range inputs {
 		hash.Write([]byte(input.FileInfo.File.Path))
 		hash.Write(input.FileInfo.Hash)
+		if input.Window {
+			// The same file may appear in several commits of a window, and
+			// each commit has its own directory in the datum
+			hash.Write([]byte(WindowDir(input)))
+		}
 	}
 	// InputFileID is a single string id for the data from this input, it's used in logs and in
 	// the statsTree
@@ -41,6 +71,9 @@ func HashDatum(pipelineName string, pipelineSalt string, inputs []*Input) string
 		hash.Write([]byte(input.Name))
 		hash.Write([]byte(input.FileInfo.File.Path))
 		hash.Write(input.FileInfo.Hash)
+		if input.Window {
+			hash.Write([]byte(WindowDir(input)))
+		}
 	}
 
 	hash.Write([]byte(pipelineName))
@@ -49,6 +82,137 @@ func HashDatum(pipelineName string, pipelineSalt string, inputs []*Input) string
 	return client.DatumTagPrefix(pipelineSalt) + hex.EncodeToString(hash.Sum(nil))
 }
 
+// ImageDigest returns the digest of an image reference that's pinned to one,
+// e.g. "sha256:abc" for "ubuntu:20.04@sha256:abc", or "" if it isn't pinned
+func ImageDigest(image string) string {
+	if i := strings.LastIndex(image, "@"); i >= 0 {
+		return image[i+1:]
+	}
+	return ""
+}
+
+// GlobalDatumSpec holds everything besides a datum's inputs that can affect
+// its output, and so is part of its key in the global datum cache
+type GlobalDatumSpec struct {
+	// Owner is the pipeline's owner. Outputs are only shared under the owner
+	// of the pipeline that computed them.
+	Owner     string
+	Transform *pps.Transform
+	PodSpec   string
+	PodPatch  string
+	// Env is the user code's environment, as filtered by GlobalDatumEnv
+	Env []string
+	// Secrets identifies the contents of the secrets mounted in the user
+	// container. The secrets in Env are covered by Env.
+	Secrets []string
+}
+
+// globalDatumEnvExcluded are the variables in the user code's environment
+// that identify the pipeline, its pod or its job. They aren't part of a
+// datum's key in the global datum cache, or else no two pipelines would ever
+// share outputs.
+var globalDatumEnvExcluded = map[string]bool{
+	"HOSTNAME":                true,
+	client.PPSPipelineNameEnv: true,
+	client.PPSSpecCommitEnv:   true,
+	client.PPSPodNameEnv:      true,
+	client.PPSWorkerIPEnv:     true,
+	client.PPSJobIDEnv:        true,
+	client.JobIDEnv:           true,
+	client.OutputCommitIDEnv:  true,
+}
+
+// GlobalDatumEnv returns the variables in 'environ' (as returned by
+// os.Environ) that are part of a datum's key in the global datum cache,
+// sorted. This excludes globalDatumEnvExcluded and the variables that
+// Kubernetes sets for each service in the namespace, which change as
+// pipelines are created and deleted.
+func GlobalDatumEnv(environ []string) []string {
+	var services []string
+	for _, kv := range environ {
+		if name := strings.SplitN(kv, "=", 2)[0]; strings.HasSuffix(name, "_SERVICE_HOST") {
+			services = append(services, strings.TrimSuffix(name, "_SERVICE_HOST"))
+		}
+	}
+	isServiceEnv := func(name string) bool {
+		for _, service := range services {
+			if strings.HasPrefix(name, service+"_SERVICE_") || name == service+"_PORT" || strings.HasPrefix(name, service+"_PORT_") {
+				return true
+			}
+		}
+		return false
+	}
+	var result []string
+	for _, kv := range environ {
+		name := strings.SplitN(kv, "=", 2)[0]
+		if globalDatumEnvExcluded[name] || isServiceEnv(name) {
+			continue
+		}
+		result = append(result, kv)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// HashGlobalDatum computes and returns the key of a datum in the global datum
+// cache, which is the hash of the datum's inputs and of everything in 'spec'.
+// Unlike HashDatum, it doesn't depend on the pipeline, so that pipelines with
+// the same owner that run the same transform over the same files share
+// outputs. The image is identified by its digest rather than its tag, so
+// 'spec.Transform.Image' must be pinned to one (see ImageDigest).
+func HashGlobalDatum(spec *GlobalDatumSpec, inputs []*Input) string {
+	hash := sha256.New()
+	// Each string is terminated by a NUL so that, e.g., the commands
+	// ["ab", "c"] and ["a", "bc"] hash differently
+	write := func(ss ...string) {
+		for _, s := range ss {
+			hash.Write([]byte(s))
+			hash.Write([]byte{0})
+		}
+		hash.Write([]byte{0})
+	}
+	write(spec.Owner)
+	for _, input := range inputs {
+		write(input.Name, input.FileInfo.File.Path, string(input.FileInfo.Hash))
+		if input.Window {
+			write(WindowDir(input))
+		}
+	}
+
+	transform := spec.Transform
+	write(ImageDigest(transform.Image), transform.User, transform.WorkingDir)
+	write(transform.Cmd...)
+	write(transform.Stdin...)
+	var env []string
+	for key, value := range transform.Env {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+	write(env...)
+	for _, secret := range transform.Secrets {
+		write(secret.Name, secret.Key, secret.MountPath, secret.EnvVar)
+	}
+	for _, returnCode := range transform.AcceptReturnCode {
+		write(strconv.FormatInt(returnCode, 10))
+	}
+	write(spec.PodSpec, spec.PodPatch)
+	write(spec.Env...)
+	write(spec.Secrets...)
+
+	return client.GlobalDatumTagPrefix + hex.EncodeToString(hash.Sum(nil))
+}
+
+// InCanarySample returns true if the datum with the ID datumID is one of the
+// samplePercent percent of datums that a canary pipeline processes. Every
+// datum is in the sample if samplePercent is 0 (or at least 100).
+func InCanarySample(datumID string, samplePercent int64) bool {
+	if samplePercent <= 0 || samplePercent >= 100 {
+		return true
+	}
+	h := sha256.Sum256([]byte(datumID))
+	return int64(binary.BigEndian.Uint64(h[:8])%100) < samplePercent
+}
+
 // MatchDatum checks if a datum matches a filter.  To match each string in
 // filter must correspond match at least 1 datum's Path or Hash. Order of
 // filter and inputs is irrelevant.
@@ -25,19 +25,27 @@ var _ = math.Inf
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type Input struct {
-	FileInfo             *pfs.FileInfo `protobuf:"bytes,1,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
-	ParentCommit         *pfs.Commit   `protobuf:"bytes,5,opt,name=parent_commit,json=parentCommit,proto3" json:"parent_commit,omitempty"`
-	Name                 string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	JoinOn               string        `protobuf:"bytes,8,opt,name=join_on,json=joinOn,proto3" json:"join_on,omitempty"`
-	GroupBy              string        `protobuf:"bytes,10,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
-	Lazy                 bool          `protobuf:"varint,3,opt,name=lazy,proto3" json:"lazy,omitempty"`
-	Branch               string        `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
-	GitURL               string        `protobuf:"bytes,6,opt,name=git_url,json=gitUrl,proto3" json:"git_url,omitempty"`
-	EmptyFiles           bool          `protobuf:"varint,7,opt,name=empty_files,json=emptyFiles,proto3" json:"empty_files,omitempty"`
-	S3                   bool          `protobuf:"varint,9,opt,name=s3,proto3" json:"s3,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	FileInfo     *pfs.FileInfo `protobuf:"bytes,1,opt,name=file_info,json=fileInfo,proto3" json:"file_info,omitempty"`
+	ParentCommit *pfs.Commit   `protobuf:"bytes,5,opt,name=parent_commit,json=parentCommit,proto3" json:"parent_commit,omitempty"`
+	Name         string        `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	JoinOn       string        `protobuf:"bytes,8,opt,name=join_on,json=joinOn,proto3" json:"join_on,omitempty"`
+	GroupBy      string        `protobuf:"bytes,10,opt,name=group_by,json=groupBy,proto3" json:"group_by,omitempty"`
+	Lazy         bool          `protobuf:"varint,3,opt,name=lazy,proto3" json:"lazy,omitempty"`
+	Branch       string        `protobuf:"bytes,4,opt,name=branch,proto3" json:"branch,omitempty"`
+	GitURL       string        `protobuf:"bytes,6,opt,name=git_url,json=gitUrl,proto3" json:"git_url,omitempty"`
+	EmptyFiles   bool          `protobuf:"varint,7,opt,name=empty_files,json=emptyFiles,proto3" json:"empty_files,omitempty"`
+	S3           bool          `protobuf:"varint,9,opt,name=s3,proto3" json:"s3,omitempty"`
+	// If set, this input is one commit of a windowed PFS input, and its files
+	// are placed under a directory named for the commit's position in the
+	// window and the commit's ID
+	Window bool `protobuf:"varint,11,opt,name=window,proto3" json:"window,omitempty"`
+	// The position of the input's commit in its window, counting from the
+	// oldest commit, and the number of commits in the window
+	WindowIndex          int64    `protobuf:"varint,12,opt,name=window_index,json=windowIndex,proto3" json:"window_index,omitempty"`
+	WindowSize           int64    `protobuf:"varint,13,opt,name=window_size,json=windowSize,proto3" json:"window_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Input) Reset()         { *m = Input{} }
@@ -143,6 +151,27 @@ func (m *Input) GetS3() bool {
 	return false
 }
 
+func (m *Input) GetWindow() bool {
+	if m != nil {
+		return m.Window
+	}
+	return false
+}
+
+func (m *Input) GetWindowIndex() int64 {
+	if m != nil {
+		return m.WindowIndex
+	}
+	return 0
+}
+
+func (m *Input) GetWindowSize() int64 {
+	if m != nil {
+		return m.WindowSize
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*Input)(nil), "common.Input")
 }
@@ -150,29 +179,32 @@ func init() {
 func init() { proto.RegisterFile("server/worker/common/common.proto", fileDescriptor_91fb6c79ddd9db74) }
 
 var fileDescriptor_91fb6c79ddd9db74 = []byte{
-	// 352 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xb1, 0x4e, 0xeb, 0x30,
-	0x14, 0x86, 0x95, 0xdc, 0x36, 0x49, 0x4f, 0x6f, 0xef, 0x60, 0x55, 0x17, 0xd3, 0xa1, 0x2d, 0xb0,
-	0x54, 0x0c, 0x0d, 0xa2, 0x03, 0x7b, 0x11, 0xa0, 0x4a, 0x48, 0x48, 0x91, 0xba, 0xb0, 0x44, 0x49,
-	0x70, 0x52, 0x43, 0x62, 0x47, 0xb6, 0x03, 0x0a, 0x2f, 0xc5, 0x6b, 0x30, 0xf2, 0x04, 0x08, 0xe5,
-	0x49, 0x90, 0xed, 0x0e, 0x0c, 0x0c, 0x96, 0xbf, 0xff, 0x3f, 0xc7, 0xbf, 0x75, 0x6c, 0x38, 0x92,
-	0x44, 0x3c, 0x13, 0x11, 0xbe, 0x70, 0xf1, 0x44, 0x44, 0x98, 0xf1, 0xaa, 0xe2, 0x6c, 0xbf, 0x2d,
-	0x6b, 0xc1, 0x15, 0x47, 0x9e, 0x55, 0x93, 0x71, 0x56, 0x52, 0xc2, 0x54, 0x58, 0xe7, 0x52, 0x2f,
-	0x5b, 0x9d, 0x8c, 0x0b, 0x5e, 0x70, 0x83, 0xa1, 0x26, 0xeb, 0x1e, 0xbf, 0xb9, 0xd0, 0xdf, 0xb0,
-	0xba, 0x51, 0xe8, 0x14, 0x06, 0x39, 0x2d, 0x49, 0x4c, 0x59, 0xce, 0xb1, 0x33, 0x77, 0x16, 0xc3,
-	0xf3, 0xd1, 0x52, 0x1f, 0xbf, 0xa6, 0x25, 0xd9, 0xb0, 0x9c, 0x47, 0x41, 0xbe, 0x27, 0x74, 0x06,
-	0xa3, 0x3a, 0x11, 0x84, 0xa9, 0x58, 0x5f, 0x49, 0x15, 0xee, 0x9b, 0xfe, 0xa1, 0xe9, 0xbf, 0x34,
-	0x56, 0xf4, 0xd7, 0x76, 0x58, 0x85, 0x10, 0xf4, 0x58, 0x52, 0x11, 0xec, 0xce, 0x9d, 0xc5, 0x20,
-	0x32, 0x8c, 0x0e, 0xc0, 0x7f, 0xe4, 0x94, 0xc5, 0x9c, 0xe1, 0xc0, 0xd8, 0x9e, 0x96, 0x77, 0x0c,
-	0x1d, 0x42, 0x50, 0x08, 0xde, 0xd4, 0x71, 0xda, 0x62, 0x30, 0x15, 0xdf, 0xe8, 0x75, 0xab, 0x73,
-	0xca, 0xe4, 0xb5, 0xc5, 0x7f, 0xe6, 0xce, 0x22, 0x88, 0x0c, 0xa3, 0xff, 0xe0, 0xa5, 0x22, 0x61,
-	0xd9, 0x0e, 0xf7, 0x6c, 0x8c, 0x55, 0xe8, 0x04, 0xfc, 0x82, 0xaa, 0xb8, 0x11, 0x25, 0xf6, 0x74,
-	0x61, 0x0d, 0xdd, 0xe7, 0xcc, 0xbb, 0xa1, 0x6a, 0x1b, 0xdd, 0x46, 0x5e, 0x41, 0xd5, 0x56, 0x94,
-	0x68, 0x06, 0x43, 0x52, 0xd5, 0xaa, 0x8d, 0xf5, 0x70, 0x12, 0xfb, 0x26, 0x17, 0x8c, 0xa5, 0x07,
-	0x97, 0xe8, 0x1f, 0xb8, 0x72, 0x85, 0x07, 0xc6, 0x77, 0xe5, 0x6a, 0x7d, 0xf5, 0xde, 0x4d, 0x9d,
-	0x8f, 0x6e, 0xea, 0x7c, 0x75, 0x53, 0xe7, 0xfe, 0xa2, 0xa0, 0x6a, 0xd7, 0xa4, 0xcb, 0x8c, 0x57,
-	0x61, 0x9d, 0x64, 0xbb, 0xf6, 0x81, 0x88, 0x9f, 0x24, 0x45, 0x16, 0xfe, 0xf6, 0x73, 0xa9, 0x67,
-	0xde, 0x7f, 0xf5, 0x1d, 0x00, 0x00, 0xff, 0xff, 0xe7, 0x9e, 0xf6, 0x08, 0xd8, 0x01, 0x00, 0x00,
+	// 398 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x92, 0xb1, 0xae, 0xd3, 0x30,
+	0x14, 0x86, 0xe5, 0xf6, 0xde, 0x24, 0x3d, 0x69, 0x19, 0xac, 0x2b, 0x30, 0x77, 0xe8, 0x4d, 0x61,
+	0x89, 0x18, 0x1a, 0x44, 0x07, 0xf6, 0x22, 0x40, 0x95, 0x90, 0x90, 0x82, 0xba, 0xb0, 0x44, 0x49,
+	0xea, 0xa4, 0x86, 0xc4, 0x8e, 0x6c, 0x87, 0x92, 0xbe, 0x02, 0x2f, 0xc6, 0xc8, 0x13, 0x20, 0x94,
+	0x27, 0x41, 0xb6, 0x33, 0x30, 0x30, 0x44, 0xf9, 0xff, 0xef, 0xfc, 0x3e, 0x47, 0x47, 0x36, 0x6c,
+	0x14, 0x95, 0xdf, 0xa8, 0x4c, 0x2e, 0x42, 0x7e, 0xa5, 0x32, 0x29, 0x45, 0xdb, 0x0a, 0x3e, 0xfd,
+	0xb6, 0x9d, 0x14, 0x5a, 0x60, 0xcf, 0xb9, 0xfb, 0xbb, 0xb2, 0x61, 0x94, 0xeb, 0xa4, 0xab, 0x94,
+	0xf9, 0x5c, 0xf5, 0xfe, 0xae, 0x16, 0xb5, 0xb0, 0x32, 0x31, 0xca, 0xd1, 0x67, 0x3f, 0xe6, 0x70,
+	0x7b, 0xe0, 0x5d, 0xaf, 0xf1, 0x0b, 0x58, 0x54, 0xac, 0xa1, 0x19, 0xe3, 0x95, 0x20, 0x28, 0x42,
+	0x71, 0xf8, 0x6a, 0xb5, 0x35, 0xc7, 0xdf, 0xb1, 0x86, 0x1e, 0x78, 0x25, 0xd2, 0xa0, 0x9a, 0x14,
+	0x7e, 0x09, 0xab, 0x2e, 0x97, 0x94, 0xeb, 0xcc, 0x8c, 0x64, 0x9a, 0xdc, 0xda, 0x7c, 0x68, 0xf3,
+	0x6f, 0x2c, 0x4a, 0x97, 0x2e, 0xe1, 0x1c, 0xc6, 0x70, 0xc3, 0xf3, 0x96, 0x92, 0x59, 0x84, 0xe2,
+	0x45, 0x6a, 0x35, 0x7e, 0x02, 0xfe, 0x17, 0xc1, 0x78, 0x26, 0x38, 0x09, 0x2c, 0xf6, 0x8c, 0xfd,
+	0xc8, 0xf1, 0x53, 0x08, 0x6a, 0x29, 0xfa, 0x2e, 0x2b, 0x06, 0x02, 0xb6, 0xe2, 0x5b, 0xbf, 0x1f,
+	0x4c, 0x9f, 0x26, 0xbf, 0x0e, 0x64, 0x1e, 0xa1, 0x38, 0x48, 0xad, 0xc6, 0x8f, 0xc1, 0x2b, 0x64,
+	0xce, 0xcb, 0x33, 0xb9, 0x71, 0x6d, 0x9c, 0xc3, 0xcf, 0xc1, 0xaf, 0x99, 0xce, 0x7a, 0xd9, 0x10,
+	0xcf, 0x14, 0xf6, 0x30, 0xfe, 0x7e, 0xf0, 0xde, 0x33, 0x7d, 0x4c, 0x3f, 0xa4, 0x5e, 0xcd, 0xf4,
+	0x51, 0x36, 0xf8, 0x01, 0x42, 0xda, 0x76, 0x7a, 0xc8, 0xcc, 0x72, 0x8a, 0xf8, 0xb6, 0x2f, 0x58,
+	0x64, 0x16, 0x57, 0xf8, 0x11, 0xcc, 0xd4, 0x8e, 0x2c, 0x2c, 0x9f, 0xa9, 0x9d, 0x99, 0x76, 0x61,
+	0xfc, 0x24, 0x2e, 0x24, 0xb4, 0x6c, 0x72, 0x78, 0x03, 0x4b, 0xa7, 0x32, 0xc6, 0x4f, 0xf4, 0x3b,
+	0x59, 0x46, 0x28, 0x9e, 0xa7, 0xa1, 0x63, 0x07, 0x83, 0xcc, 0xac, 0x29, 0xa2, 0xd8, 0x95, 0x92,
+	0x95, 0x4d, 0x80, 0x43, 0x9f, 0xd8, 0x95, 0xee, 0xdf, 0xfe, 0x1c, 0xd7, 0xe8, 0xd7, 0xb8, 0x46,
+	0x7f, 0xc6, 0x35, 0xfa, 0xfc, 0xba, 0x66, 0xfa, 0xdc, 0x17, 0xdb, 0x52, 0xb4, 0x49, 0x97, 0x97,
+	0xe7, 0xe1, 0x44, 0xe5, 0xbf, 0x4a, 0xc9, 0x32, 0xf9, 0xdf, 0xab, 0x28, 0x3c, 0x7b, 0xb7, 0xbb,
+	0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0x9b, 0x6a, 0xc8, 0x6d, 0x34, 0x02, 0x00, 0x00,
 }
 
 func (m *Input) Marshal() (dAtA []byte, err error) {
@@ -199,6 +231,26 @@ func (m *Input) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.WindowSize != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.WindowSize))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.WindowIndex != 0 {
+		i = encodeVarintCommon(dAtA, i, uint64(m.WindowIndex))
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.Window {
+		i--
+		if m.Window {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x58
+	}
 	if len(m.GroupBy) > 0 {
 		i -= len(m.GroupBy)
 		copy(dAtA[i:], m.GroupBy)
@@ -345,6 +397,15 @@ func (m *Input) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovCommon(uint64(l))
 	}
+	if m.Window {
+		n += 2
+	}
+	if m.WindowIndex != 0 {
+		n += 1 + sovCommon(uint64(m.WindowIndex))
+	}
+	if m.WindowSize != 0 {
+		n += 1 + sovCommon(uint64(m.WindowSize))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -678,6 +739,64 @@ func (m *Input) Unmarshal(dAtA []byte) error {
 			}
 			m.GroupBy = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Window", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Window = bool(v != 0)
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WindowIndex", wireType)
+			}
+			m.WindowIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WindowIndex |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WindowSize", wireType)
+			}
+			m.WindowSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCommon
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WindowSize |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCommon(dAtA[iNdEx:])
@@ -0,0 +1,121 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+func TestHashGlobalDatum(t *testing.T) {
+	input := func(name, path, hash string) *Input {
+		return &Input{
+			Name: name,
+			FileInfo: &pfs.FileInfo{
+				File: client.NewFile(name, "master", path),
+				Hash: []byte(hash),
+			},
+		}
+	}
+	newSpec := func() *GlobalDatumSpec {
+		return &GlobalDatumSpec{
+			Owner: "alice",
+			Transform: &pps.Transform{
+				Image: "image:1.0@sha256:abc",
+				Cmd:   []string{"sh"},
+				Env:   map[string]string{"A": "1", "B": "2"},
+			},
+			Env: []string{"A=1", "B=2"},
+		}
+	}
+	inputs := []*Input{input("images", "/a.png", "abc")}
+	tag := HashGlobalDatum(newSpec(), inputs)
+	require.True(t, strings.HasPrefix(tag, client.GlobalDatumTagPrefix))
+
+	// The key doesn't depend on the pipeline, the order of the env or the
+	// image's name and tag, only on its digest
+	spec := newSpec()
+	spec.Transform.Image = "registry/image:latest@sha256:abc"
+	spec.Transform.Env = map[string]string{"B": "2", "A": "1"}
+	require.Equal(t, tag, HashGlobalDatum(spec, inputs))
+
+	// But does depend on the owner, the image's digest, the transform's
+	// command, the pod spec and patch, the environment, the secrets and the
+	// input's files
+	for _, modify := range []func(*GlobalDatumSpec){
+		func(spec *GlobalDatumSpec) { spec.Owner = "bob" },
+		func(spec *GlobalDatumSpec) { spec.Transform.Image = "image:1.0@sha256:abd" },
+		func(spec *GlobalDatumSpec) { spec.Transform.Cmd = []string{"s", "h"} },
+		func(spec *GlobalDatumSpec) { spec.PodSpec = `{"hostNetwork": true}` },
+		func(spec *GlobalDatumSpec) { spec.PodPatch = `[{"op": "add", "path": "/hostNetwork", "value": true}]` },
+		func(spec *GlobalDatumSpec) { spec.Env = append(spec.Env, "C=3") },
+		func(spec *GlobalDatumSpec) { spec.Secrets = []string{"/secret/password=abc"} },
+	} {
+		spec := newSpec()
+		modify(spec)
+		require.NotEqual(t, tag, HashGlobalDatum(spec, inputs))
+	}
+	require.NotEqual(t, tag, HashGlobalDatum(newSpec(), []*Input{input("images", "/a.png", "abd")}))
+	require.NotEqual(t, tag, HashGlobalDatum(newSpec(), []*Input{input("pictures", "/a.png", "abc")}))
+}
+
+func TestGlobalDatumEnv(t *testing.T) {
+	env := GlobalDatumEnv([]string{
+		"B=2",
+		"HOSTNAME=pipeline-foo-v1-abcde",
+		client.PPSPipelineNameEnv + "=foo",
+		"PIPELINE_BAR_V1_SERVICE_HOST=10.0.0.1",
+		"PIPELINE_BAR_V1_SERVICE_PORT=80",
+		"PIPELINE_BAR_V1_SERVICE_PORT_GRPC_PORT=80",
+		"PIPELINE_BAR_V1_PORT=tcp://10.0.0.1:80",
+		"PIPELINE_BAR_V1_PORT_80_TCP_ADDR=10.0.0.1",
+		"DB_PORT=5432",
+		"A=1",
+	})
+	require.Equal(t, []string{"A=1", "B=2", "DB_PORT=5432"}, env)
+}
+
+func TestWindowDir(t *testing.T) {
+	input := func(index, size int64) *Input {
+		return &Input{
+			FileInfo:    &pfs.FileInfo{File: client.NewFile("sensors", "abc", "/readings.csv")},
+			Window:      true,
+			WindowIndex: index,
+			WindowSize:  size,
+		}
+	}
+	require.Equal(t, "0-abc", WindowDir(input(0, 1)))
+	require.Equal(t, "3-abc", WindowDir(input(3, 10)))
+	// Positions are padded so that the directories sort in window order
+	require.Equal(t, "03-abc", WindowDir(input(3, 11)))
+	require.Equal(t, "10-abc", WindowDir(input(10, 11)))
+}
+
+func TestInCanarySample(t *testing.T) {
+	var sampled int
+	for i := 0; i < 1000; i++ {
+		id := DatumID([]*Input{{
+			FileInfo: &pfs.FileInfo{
+				File: client.NewFile("images", "master", fmt.Sprintf("/%d.png", i)),
+			},
+		}})
+		require.True(t, InCanarySample(id, 0))
+		require.True(t, InCanarySample(id, 100))
+		if InCanarySample(id, 10) {
+			sampled++
+			// Datums in a sample are also in every larger sample
+			require.True(t, InCanarySample(id, 50))
+		}
+	}
+	require.True(t, sampled > 50 && sampled < 150, "%d of 1000 datums were sampled", sampled)
+}
+
+func TestImageDigest(t *testing.T) {
+	require.Equal(t, "sha256:abc", ImageDigest("ubuntu:20.04@sha256:abc"))
+	require.Equal(t, "sha256:abc", ImageDigest("docker-pullable://ubuntu@sha256:abc"))
+	require.Equal(t, "", ImageDigest("ubuntu:20.04"))
+}
@@ -3,13 +3,16 @@ package datum
 import (
 	"io"
 	"sort"
+	"time"
 
+	"github.com/gogo/protobuf/types"
 	glob "github.com/pachyderm/ohmyglob"
 
 	"github.com/pachyderm/pachyderm/src/client"
 	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
 	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/path"
 	"github.com/pachyderm/pachyderm/src/server/worker/common"
 
@@ -42,6 +45,9 @@ func newPFSIterator(pachClient *client.APIClient, input *pps.PFSInput) (Iterator
 		// before all commits have inputs
 		return result, nil
 	}
+	if input.Window != nil {
+		return newWindowIterator(pachClient, input)
+	}
 	fs, err := pachClient.GlobFileStream(pachClient.Ctx(), &pfs.GlobFileRequest{
 		Commit:  client.NewCommit(input.Repo, input.Commit),
 		Pattern: input.Glob,
@@ -110,6 +116,146 @@ func (d *pfsIterator) Next() bool {
 	return d.location < len(d.inputs)
 }
 
+type windowIterator struct {
+	datums   [][]*common.Input
+	location int
+}
+
+// newWindowIterator returns an iterator over the commits in 'input's window,
+// which has one datum for each path matched by the glob pattern in any of the
+// commits. Each datum has one input per commit in which its path matched,
+// ordered from the oldest commit to the newest.
+func newWindowIterator(pachClient *client.APIClient, input *pps.PFSInput) (Iterator, error) {
+	result := &windowIterator{}
+	defer result.Reset()
+	commitInfos, err := windowCommits(pachClient, input)
+	if err != nil {
+		return nil, err
+	}
+	inputMap := make(map[string][]*common.Input)
+	// commitInfos are ordered from newest to oldest
+	for i := len(commitInfos) - 1; i >= 0; i-- {
+		fileInfos, err := pachClient.GlobFile(input.Repo, commitInfos[i].Commit.ID, input.Glob)
+		if err != nil {
+			return nil, err
+		}
+		for _, fileInfo := range fileInfos {
+			inputMap[fileInfo.File.Path] = append(inputMap[fileInfo.File.Path], &common.Input{
+				FileInfo:    fileInfo,
+				Name:        input.Name,
+				Lazy:        input.Lazy,
+				Branch:      input.Branch,
+				EmptyFiles:  input.EmptyFiles,
+				Window:      true,
+				WindowIndex: int64(len(commitInfos) - 1 - i),
+				WindowSize:  int64(len(commitInfos)),
+			})
+		}
+	}
+	paths := make([]string, 0, len(inputMap))
+	for p := range inputMap {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	for _, p := range paths {
+		result.datums = append(result.datums, inputMap[p])
+	}
+	return result, nil
+}
+
+// WindowProvenance returns the commits in the windows of 'input's PFS inputs,
+// other than the input commits themselves, which are already in the
+// provenance of the job's output commit. Workers add them to the output
+// commit's provenance when they finish the job.
+func WindowProvenance(pachClient *client.APIClient, input *pps.Input) ([]*pfs.CommitProvenance, error) {
+	var result []*pfs.CommitProvenance
+	var err error
+	pps.VisitInput(input, func(input *pps.Input) {
+		if err != nil || input.Pfs == nil || input.Pfs.Window == nil || input.Pfs.Commit == "" {
+			return
+		}
+		var commitInfos []*pfs.CommitInfo
+		if commitInfos, err = windowCommits(pachClient, input.Pfs); err != nil {
+			return
+		}
+		for _, ci := range commitInfos {
+			if ci.Commit.ID == input.Pfs.Commit {
+				continue
+			}
+			result = append(result, client.NewCommitProvenance(ci.Commit.Repo.Name, ci.Commit.ID, ci.Commit.ID))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// windowCommits returns the commits in 'input's window, from newest (the
+// input commit) to oldest
+func windowCommits(pachClient *client.APIClient, input *pps.PFSInput) ([]*pfs.CommitInfo, error) {
+	if input.Window.Commits > 0 {
+		return pachClient.ListCommit(input.Repo, input.Commit, "", uint64(input.Window.Commits))
+	}
+	duration, err := types.DurationFromProto(input.Window.Duration)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	var result []*pfs.CommitInfo
+	var start time.Time
+	if err := pachClient.ListCommitF(input.Repo, input.Commit, "", 0, false, func(ci *pfs.CommitInfo) error {
+		t, err := commitTime(ci)
+		if err != nil {
+			return err
+		}
+		if len(result) == 0 {
+			start = t.Add(-duration)
+		} else if t.Before(start) {
+			return errutil.ErrBreak
+		}
+		result = append(result, ci)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// commitTime is the time at which 'ci' finished, or at which it started if
+// it's not finished yet
+func commitTime(ci *pfs.CommitInfo) (time.Time, error) {
+	t := ci.Finished
+	if t == nil {
+		t = ci.Started
+	}
+	result, err := types.TimestampFromProto(t)
+	return result, errors.EnsureStack(err)
+}
+
+func (d *windowIterator) Reset() {
+	d.location = -1
+}
+
+func (d *windowIterator) Len() int {
+	return len(d.datums)
+}
+
+func (d *windowIterator) Next() bool {
+	if d.location < len(d.datums) {
+		d.location++
+	}
+	return d.location < len(d.datums)
+}
+
+func (d *windowIterator) Datum() []*common.Input {
+	return d.datums[d.location]
+}
+
+func (d *windowIterator) DatumN(n int) []*common.Input {
+	d.location = n
+	return d.Datum()
+}
+
 type listIterator struct {
 	inputs   []*common.Input
 	location int
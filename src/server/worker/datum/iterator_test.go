@@ -4,12 +4,17 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
 
 	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
 	"github.com/pachyderm/pachyderm/src/client/pkg/require"
 	"github.com/pachyderm/pachyderm/src/client/pps"
 	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
 	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
 )
 
 func TestIterators(t *testing.T) {
@@ -408,6 +413,119 @@ func TestJoinTrailingSlash(t *testing.T) {
 	}))
 }
 
+func TestWindow(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		repo := tu.UniqueString(t.Name())
+		require.NoError(t, c.CreateRepo(repo))
+
+		// commit 0 has a and b, commit 1 changes a and adds c, and commit 2
+		// deletes b and adds d
+		var commits []string
+		for i, files := range [][]string{{"a", "b"}, {"a", "c"}, {"d"}} {
+			commit, err := c.StartCommit(repo, "master")
+			require.NoError(t, err)
+			for _, file := range files {
+				_, err = c.PutFile(repo, commit.ID, file, strings.NewReader(fmt.Sprintf("%d", i)))
+				require.NoError(t, err)
+			}
+			if i == 2 {
+				require.NoError(t, c.DeleteFile(repo, commit.ID, "b"))
+			}
+			require.NoError(t, c.FinishCommit(repo, commit.ID))
+			commits = append(commits, commit.ID)
+		}
+		datumCommits := func(it Iterator) [][]string {
+			var result [][]string
+			for it.Next() {
+				var ids []string
+				for _, input := range it.Datum() {
+					require.True(t, input.Window)
+					ids = append(ids, input.FileInfo.File.Commit.ID)
+				}
+				result = append(result, ids)
+			}
+			it.Reset()
+			return result
+		}
+
+		input := client.NewPFSInput(repo, "/*")
+		input.Pfs.Commit = commits[2]
+		input.Pfs.Window = &pps.PFSWindow{Commits: 2}
+		t.Run("Commits", func(t *testing.T) {
+			it, err := NewIterator(c, input)
+			require.NoError(t, err)
+			require.Equal(t, [][]string{
+				{commits[1], commits[2]},
+				{commits[1]},
+				{commits[1], commits[2]},
+				{commits[2]},
+			}, datumCommits(it))
+			validateDI(t, it, "/a/a", "/b", "/c/c", "/d")
+		})
+
+		t.Run("Duration", func(t *testing.T) {
+			input.Pfs.Window = &pps.PFSWindow{Duration: types.DurationProto(time.Hour)}
+			it, err := NewIterator(c, input)
+			require.NoError(t, err)
+			require.Equal(t, [][]string{
+				{commits[0], commits[1], commits[2]},
+				{commits[0], commits[1]},
+				{commits[1], commits[2]},
+				{commits[2]},
+			}, datumCommits(it))
+		})
+
+		t.Run("Provenance", func(t *testing.T) {
+			// A job's window is determined by the input commit, so it doesn't
+			// change when the branch moves, and the older commits in it are
+			// added to the output commit's provenance
+			input.Pfs.Window = &pps.PFSWindow{Commits: 2}
+			provenance, err := WindowProvenance(c, input)
+			require.NoError(t, err)
+			require.Equal(t, []*pfs.CommitProvenance{
+				client.NewCommitProvenance(repo, commits[1], commits[1]),
+			}, provenance)
+			before, err := NewIterator(c, input)
+			require.NoError(t, err)
+			_, err = c.PutFile(repo, "master", "a", strings.NewReader("3"))
+			require.NoError(t, err)
+			after, err := NewIterator(c, input)
+			require.NoError(t, err)
+			require.Equal(t, datumCommits(before), datumCommits(after))
+			require.Equal(t, [][]string{
+				{commits[1], commits[2]},
+				{commits[1]},
+				{commits[1], commits[2]},
+				{commits[2]},
+			}, datumCommits(after))
+		})
+
+		t.Run("Directories", func(t *testing.T) {
+			// Each commit's directory is prefixed with its position in the
+			// window, so they sort from oldest to newest
+			input.Pfs.Window = &pps.PFSWindow{Commits: 2}
+			it, err := NewIterator(c, input)
+			require.NoError(t, err)
+			require.True(t, it.Next())
+			var dirs []string
+			for _, input := range it.Datum() {
+				dirs = append(dirs, common.WindowDir(input))
+			}
+			require.Equal(t, []string{"0-" + commits[1], "1-" + commits[2]}, dirs)
+		})
+
+		t.Run("ShortHistory", func(t *testing.T) {
+			input.Pfs.Commit = commits[0]
+			input.Pfs.Window = &pps.PFSWindow{Commits: 5}
+			it, err := NewIterator(c, input)
+			require.NoError(t, err)
+			validateDI(t, it, "/a", "/b")
+		})
+		return nil
+	}))
+}
+
 func validateDI(t testing.TB, dit Iterator, datums ...string) {
 	t.Helper()
 	i := 0
@@ -46,6 +46,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/pkg/work"
 	"github.com/pachyderm/pachyderm/src/server/worker/cache"
 	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/egress"
 	"github.com/pachyderm/pachyderm/src/server/worker/logs"
 	"github.com/pachyderm/pachyderm/src/server/worker/stats"
 )
@@ -53,14 +54,52 @@ import (
 const (
 	// The maximum number of concurrent download/upload operations
 	concurrency = 100
+	// The amount of the user code's stderr that is kept for UserCodeError
+	stderrTailSize = 4 * 1024
 )
 
 var (
 	errSpecialFile = errors.New("cannot upload special file")
 )
 
-func workNamespace(pipelineInfo *pps.PipelineInfo) string {
-	return fmt.Sprintf("/pipeline-%s/v%d", pipelineInfo.Pipeline.Name, pipelineInfo.Version)
+// UserCodeError is returned by RunUserCode when the user code exits with an
+// unaccepted return code. It carries the return code and the end of the user
+// code's stderr.
+type UserCodeError struct {
+	Err        error
+	ReturnCode int
+	Stderr     []byte
+}
+
+func (e *UserCodeError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *UserCodeError) Unwrap() error {
+	return e.Err
+}
+
+// tailWriter keeps the last `size` bytes written to it
+type tailWriter struct {
+	mu   sync.Mutex
+	size int
+	buf  []byte
+}
+
+func (w *tailWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf = append(w.buf, p...)
+	if len(w.buf) > w.size {
+		w.buf = append([]byte{}, w.buf[len(w.buf)-w.size:]...)
+	}
+	return len(p), nil
+}
+
+func (w *tailWriter) Bytes() []byte {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]byte{}, w.buf...)
 }
 
 // Driver provides an interface for common functions needed by worker code, and
@@ -71,6 +110,7 @@ func workNamespace(pipelineInfo *pps.PipelineInfo) string {
 type Driver interface {
 	Jobs() col.Collection
 	Pipelines() col.Collection
+	JobQueues() col.Collection
 
 	NewTaskWorker() *work.Worker
 	NewTaskQueue() (*work.TaskQueue, error)
@@ -147,7 +187,11 @@ type Driver interface {
 	// bound to the callback, and any resources will be cleaned up upon return.
 	WithDatumCache(func(*hashtree.MergeCache, *hashtree.MergeCache) error) error
 
-	Egress(commit *pfs.Commit, egressURL string) error
+	// Egress pushes the contents of an output commit to the pipeline's egress
+	// target, calling progress as files are pushed (if the target supports it).
+	// resume is the progress of an earlier, failed egress of the commit, if
+	// there was one, which the egress resumes from if the target allows it.
+	Egress(commit *pfs.Commit, egress *pps.Egress, resume *pps.EgressProgress, progress func(*pps.EgressProgress) error) error
 }
 
 type driver struct {
@@ -161,6 +205,8 @@ type driver struct {
 
 	pipelines col.Collection
 
+	jobQueues col.Collection
+
 	numShards int64
 
 	namespace string
@@ -239,6 +285,7 @@ func NewDriver(
 		activeDataMutex:  &sync.Mutex{},
 		jobs:             ppsdb.Jobs(etcdClient, etcdPrefix),
 		pipelines:        ppsdb.Pipelines(etcdClient, etcdPrefix),
+		jobQueues:        ppsdb.JobQueues(etcdClient, etcdPrefix),
 		numShards:        numShards,
 		rootDir:          rootPath,
 		inputDir:         pfsPath,
@@ -371,12 +418,16 @@ func (d *driver) Pipelines() col.Collection {
 	return d.pipelines
 }
 
+func (d *driver) JobQueues() col.Collection {
+	return d.jobQueues
+}
+
 func (d *driver) NewTaskWorker() *work.Worker {
-	return work.NewWorker(d.etcdClient, d.etcdPrefix, workNamespace(d.pipelineInfo))
+	return work.NewWorker(d.etcdClient, d.etcdPrefix, ppsutil.WorkNamespace(d.pipelineInfo))
 }
 
 func (d *driver) NewTaskQueue() (*work.TaskQueue, error) {
-	return work.NewTaskQueue(d.PachClient().Ctx(), d.etcdClient, d.etcdPrefix, workNamespace(d.pipelineInfo))
+	return work.NewTaskQueue(d.PachClient().Ctx(), d.etcdClient, d.etcdPrefix, ppsutil.WorkNamespace(d.pipelineInfo))
 }
 
 func (d *driver) ExpectedNumWorkers() (int64, error) {
@@ -609,10 +660,10 @@ func (d *driver) downloadData(
 			continue // don't download any data
 		}
 		file := input.FileInfo.File
-		fullInputPath := filepath.Join(scratchPath, input.Name, file.Path)
+		fullInputPath := filepath.Join(scratchPath, common.InputRoot(input), file.Path)
 		var statsRoot string
 		if statsTree != nil {
-			statsRoot = filepath.Join(input.Name, file.Path)
+			statsRoot = filepath.Join(common.InputRoot(input), file.Path)
 			parent, _ := filepath.Split(statsRoot)
 			statsTree.MkdirAll(parent)
 		}
@@ -734,8 +785,9 @@ func (d *driver) RunUserCode(
 	if d.pipelineInfo.Transform.Stdin != nil {
 		cmd.Stdin = strings.NewReader(strings.Join(d.pipelineInfo.Transform.Stdin, "\n") + "\n")
 	}
+	stderr := &tailWriter{size: stderrTailSize}
 	cmd.Stdout = logger.WithUserCode()
-	cmd.Stderr = logger.WithUserCode()
+	cmd.Stderr = io.MultiWriter(logger.WithUserCode(), stderr)
 	cmd.Env = environ
 	if d.uid != nil && d.gid != nil {
 		cmd.SysProcAttr = makeCmdCredentials(*d.uid, *d.gid)
@@ -751,6 +803,7 @@ func (d *driver) RunUserCode(
 	if err != nil {
 		return errors.EnsureStack(err)
 	}
+	d.recordResourceUsage(state, procStats, logger)
 	if common.IsDone(ctx) {
 		if err = ctx.Err(); err != nil {
 			return errors.EnsureStack(err)
@@ -769,6 +822,7 @@ func (d *driver) RunUserCode(
 	// broken pipe errors.
 	if err != nil && !strings.Contains(err.Error(), "broken pipe") {
 		// (if err is an acceptable return code, don't return err)
+		userCodeErr := &UserCodeError{Err: errors.EnsureStack(err), ReturnCode: -1, Stderr: stderr.Bytes()}
 		exiterr := &exec.ExitError{}
 		if errors.As(err, &exiterr) {
 			if status, ok := exiterr.Sys().(syscall.WaitStatus); ok {
@@ -777,9 +831,10 @@ func (d *driver) RunUserCode(
 						return nil
 					}
 				}
+				userCodeErr.ReturnCode = status.ExitStatus()
 			}
 		}
-		return errors.EnsureStack(err)
+		return userCodeErr
 	}
 	return nil
 }
@@ -913,6 +968,56 @@ func (d *driver) updateHistogram(
 	}
 }
 
+// recordResourceUsage records the resources used by the user code, which ran
+// as 'state', in 'procStats'
+func (d *driver) recordResourceUsage(state *os.ProcessState, procStats *pps.ProcessStats, logger logs.TaggedLogger) {
+	procStats.CpuTime = types.DurationProto(state.UserTime() + state.SystemTime())
+	procStats.PeakMemoryBytes = peakMemoryBytes(state)
+	diskBytes, err := d.diskUsage()
+	if err != nil {
+		logger.Logf("failed to measure disk usage of datum: %v", err)
+	}
+	procStats.DiskBytes = diskBytes
+}
+
+// diskUsage returns the size of the files in the active data directory. The
+// datum's inputs and outputs are symlinked into it (see WithActiveData), so
+// each of its entries is resolved before walking it.
+func (d *driver) diskUsage() (uint64, error) {
+	entries, err := ioutil.ReadDir(d.InputDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, errors.EnsureStack(err)
+	}
+	var size uint64
+	for _, entry := range entries {
+		if entry.Name() == client.PPSScratchSpace {
+			continue // other datums' data
+		}
+		root, err := filepath.EvalSymlinks(filepath.Join(d.InputDir(), entry.Name()))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return 0, errors.EnsureStack(err)
+		}
+		if err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.Mode().IsRegular() {
+				size += uint64(info.Size())
+			}
+			return nil
+		}); err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+	}
+	return size, nil
+}
+
 func (d *driver) reportUserCodeStats(logger logs.TaggedLogger) {
 	if d.exportStats {
 		d.updateCounter(stats.DatumCount, logger, "started", func(counter prometheus.Counter) {
@@ -945,6 +1050,17 @@ func (d *driver) reportDeferredUserCodeStats(
 		d.updateCounter(stats.DatumProcSecondsCount, logger, "", func(counter prometheus.Counter) {
 			counter.Add(duration.Seconds())
 		})
+		if cpuTime, err := types.DurationFromProto(procStats.CpuTime); err == nil {
+			d.updateCounter(stats.DatumCPUSecondsCount, logger, "", func(counter prometheus.Counter) {
+				counter.Add(cpuTime.Seconds())
+			})
+		}
+		d.updateHistogram(stats.DatumPeakMemory, logger, "", func(hist prometheus.Observer) {
+			hist.Observe(float64(procStats.PeakMemoryBytes))
+		})
+		d.updateHistogram(stats.DatumDiskSize, logger, "", func(hist prometheus.Observer) {
+			hist.Observe(float64(procStats.DiskBytes))
+		})
 	}
 }
 
@@ -1105,12 +1221,19 @@ func (d *driver) UploadOutput(
 			if strings.HasPrefix(realPath, d.InputDir()) {
 				if pathWithInput, err := filepath.Rel(dir, realPath); err == nil {
 					// The name of the input
-					inputName := strings.Split(pathWithInput, string(os.PathSeparator))[0]
+					pathFields := strings.Split(pathWithInput, string(os.PathSeparator))
+					inputName := pathFields[0]
 					var input *common.Input
 					for _, i := range inputs {
-						if i.Name == inputName {
-							input = i
+						if i.Name != inputName {
+							continue
 						}
+						// Windowed inputs are further distinguished by the
+						// commit directory that the link points into
+						if i.Window && (len(pathFields) < 2 || pathFields[1] != common.WindowDir(i)) {
+							continue
+						}
+						input = i
 					}
 					// this changes realPath from `/pfs/input/...` to `/scratch/<id>/input/...`
 					realPath = filepath.Join(dir, pathWithInput)
@@ -1125,7 +1248,7 @@ func (d *driver) UploadOutput(
 							}
 							subRelPath := filepath.Join(relPath, rel)
 							// The path of the input file
-							pfsPath, err := filepath.Rel(filepath.Join(dir, input.Name), filePath)
+							pfsPath, err := filepath.Rel(filepath.Join(dir, common.InputRoot(input)), filePath)
 							if err != nil {
 								return errors.EnsureStack(err)
 							}
@@ -1242,17 +1365,31 @@ func (d *driver) UploadOutput(
 	return b.Bytes(), nil
 }
 
+// InputEnv returns the environment variables that tell user code where the
+// files of each of 'inputs' are, if 'inputDir' is the directory that they
+// were downloaded into (typically /pfs)
+func InputEnv(inputDir string, inputs []*common.Input) []string {
+	var result []string
+	for _, input := range inputs {
+		if input.Window {
+			// A window's inputs share a directory, and are ordered from the
+			// oldest commit to the newest, so the newest commit wins
+			result = append(result, fmt.Sprintf("%s=%s", input.Name, filepath.Join(inputDir, input.Name)))
+		} else {
+			result = append(result, fmt.Sprintf("%s=%s", input.Name, filepath.Join(inputDir, input.Name, input.FileInfo.File.Path)))
+		}
+		result = append(result, fmt.Sprintf("%s_COMMIT=%s", input.Name, input.FileInfo.File.Commit.ID))
+	}
+	return result
+}
+
 func (d *driver) UserCodeEnv(
 	jobID string,
 	outputCommit *pfs.Commit,
 	inputs []*common.Input,
 ) []string {
 	result := os.Environ()
-
-	for _, input := range inputs {
-		result = append(result, fmt.Sprintf("%s=%s", input.Name, filepath.Join(d.InputDir(), input.Name, input.FileInfo.File.Path)))
-		result = append(result, fmt.Sprintf("%s_COMMIT=%s", input.Name, input.FileInfo.File.Commit.ID))
-	}
+	result = append(result, InputEnv(d.InputDir(), inputs)...)
 
 	if jobID != "" {
 		result = append(result, fmt.Sprintf("%s=%s", client.JobIDEnv, jobID))
@@ -1283,13 +1420,21 @@ func (d *driver) UserCodeEnv(
 	return result
 }
 
-func (d *driver) Egress(commit *pfs.Commit, egressURL string) error {
+func (d *driver) Egress(commit *pfs.Commit, egressSpec *pps.Egress, resume *pps.EgressProgress, progress func(*pps.EgressProgress) error) error {
 	// copy the pach client (preserving auth info) so we can set a different
 	// number of concurrent streams
 	pachClient := d.PachClient().WithCtx(d.PachClient().Ctx())
 	pachClient.SetMaxConcurrentStreams(100)
 
-	url, err := obj.ParseURL(egressURL)
+	if egressSpec.URL == "" {
+		sink, err := egress.NewSink(egressSpec)
+		if err != nil {
+			return err
+		}
+		return egress.Egress(pachClient, commit, sink, resume, progress)
+	}
+
+	url, err := obj.ParseURL(egressSpec.URL)
 	if err != nil {
 		return err
 	}
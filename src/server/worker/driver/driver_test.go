@@ -703,6 +703,54 @@ func TestRunUserCodeError(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// Test that the end of the user code's stderr is attached to its error
+func TestRunUserCodeStderr(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		require.NoError(t, os.MkdirAll(env.driver.InputDir(), 0700))
+		env.driver.pipelineInfo.Transform.WorkingDir = ""
+		env.driver.pipelineInfo.Transform.Cmd = []string{"bash", "-c", "echo this is a user code error >&2; exit 1"}
+		requireLogs(t, []string{"exit status 1"}, func(logger logs.TaggedLogger) {
+			err := env.driver.RunUserCode(logger, []string{}, &pps.ProcessStats{}, nil)
+			require.YesError(t, err)
+			userCodeErr := &UserCodeError{}
+			require.True(t, errors.As(err, &userCodeErr))
+			require.Equal(t, 1, userCodeErr.ReturnCode)
+			require.Equal(t, "this is a user code error\n", string(userCodeErr.Stderr))
+		})
+	})
+	require.NoError(t, err)
+}
+
+// Test that the resources used by user code are recorded in its stats
+func TestRunUserCodeResources(t *testing.T) {
+	t.Parallel()
+	err := withTestEnv(func(env *testEnv) {
+		require.NoError(t, os.MkdirAll(env.driver.InputDir(), 0700))
+		env.driver.pipelineInfo.Transform.WorkingDir = ""
+		output := filepath.Join(env.driver.InputDir(), "resources")
+		env.driver.pipelineInfo.Transform.Cmd = []string{"bash", "-c", "printf 0123456789 > " + output}
+		requireLogs(t, []string{"finished running user code"}, func(logger logs.TaggedLogger) {
+			stats := &pps.ProcessStats{}
+			require.NoError(t, env.driver.RunUserCode(logger, []string{}, stats, nil))
+			require.NotNil(t, stats.CpuTime)
+			require.True(t, stats.PeakMemoryBytes > 0)
+			require.Equal(t, uint64(10), stats.DiskBytes)
+		})
+	})
+	require.NoError(t, err)
+}
+
+func TestTailWriter(t *testing.T) {
+	w := &tailWriter{size: 8}
+	_, err := w.Write([]byte("abc"))
+	require.NoError(t, err)
+	require.Equal(t, "abc", string(w.Bytes()))
+	_, err = w.Write([]byte("defghijkl"))
+	require.NoError(t, err)
+	require.Equal(t, "efghijkl", string(w.Bytes()))
+}
+
 func TestRunUserCodeNoCommand(t *testing.T) {
 	t.Parallel()
 	err := withTestEnv(func(env *testEnv) {
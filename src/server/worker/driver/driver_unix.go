@@ -18,6 +18,15 @@ func createSpoutFifo(path string) error {
 	return syscall.Mkfifo(path, 0666)
 }
 
+// peakMemoryBytes returns the peak resident set size of the finished process
+// 'state'. Linux reports maxrss in kilobytes.
+func peakMemoryBytes(state *os.ProcessState) uint64 {
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok && rusage.Maxrss > 0 {
+		return uint64(rusage.Maxrss) * 1024
+	}
+	return 0
+}
+
 func makeCmdCredentials(uid uint32, gid uint32) *syscall.SysProcAttr {
 	return &syscall.SysProcAttr{
 		Credential: &syscall.Credential{
@@ -107,7 +116,14 @@ func (d *driver) linkData(inputs []*common.Input, dir string) error {
 		return err
 	}
 
+	// Several inputs may share a name (e.g. the commits of a window), in
+	// which case they share a directory
+	seen := make(map[string]bool)
 	for _, input := range inputs {
+		if seen[input.Name] {
+			continue
+		}
+		seen[input.Name] = true
 		if input.S3 {
 			continue // S3 data is not downloaded
 		}
@@ -19,6 +19,10 @@ func makeCmdCredentials(uid uint32, gid uint32) *syscall.SysProcAttr {
 	return nil
 }
 
+func peakMemoryBytes(state *os.ProcessState) uint64 {
+	return 0
+}
+
 // Note: this function only exists for tests, the real system uses a fifo for
 // this (which does not exist in the normal filesystem on Windows)
 func createSpoutFifo(path string) error {
@@ -55,7 +59,14 @@ func (d *driver) moveData(inputs []*common.Input, dir string) error {
 		return err
 	}
 
+	// Several inputs may share a name (e.g. the commits of a window), in
+	// which case they share a directory
+	seen := make(map[string]bool)
 	for _, input := range inputs {
+		if seen[input.Name] {
+			continue
+		}
+		seen[input.Name] = true
 		if input.S3 {
 			continue
 		}
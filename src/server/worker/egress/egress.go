@@ -0,0 +1,112 @@
+// Package egress pushes the contents of a job's output commit to the egress
+// targets that aren't object stores: SQL tables, HTTP endpoints and message
+// queues. Object store egress is handled by the sync package.
+package egress
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+// Sink is an egress target, which receives an output commit one file at a
+// time.
+type Sink interface {
+	// Write egresses a single file. Targets that can deduplicate deliveries
+	// are given the file's IdempotencyKey.
+	Write(ctx context.Context, file *pfs.File, r io.Reader) error
+	// Flush is called once every file has been written, and makes the
+	// egressed data visible (e.g. by committing a database transaction).
+	Flush(ctx context.Context) error
+	// Close releases the sink's resources. Data that hasn't been flushed is
+	// discarded where the target allows it.
+	Close() error
+	// Transactional is true if data that hasn't been flushed is discarded
+	// when an egress fails, in which case retries start over rather than
+	// resuming after the last file written.
+	Transactional() bool
+}
+
+// IdempotencyKey identifies a file of an output commit across retries of its
+// egress, so that targets can drop files that they've already received.
+func IdempotencyKey(file *pfs.File) string {
+	return file.Commit.ID + ":" + file.Path
+}
+
+// NewSink returns the Sink for an egress target. Credentials referenced by
+// the target are read from the environment of the worker, where they're
+// placed by the pipeline controller.
+func NewSink(egress *pps.Egress) (Sink, error) {
+	secret := os.Getenv(client.PPSEgressSecretEnv)
+	switch {
+	case egress.SQL != nil:
+		return newSQLSink(egress.SQL, secret)
+	case egress.HTTP != nil:
+		return newHTTPSink(egress.HTTP, secret)
+	case egress.Queue != nil:
+		return newQueueSink(egress.Queue)
+	default:
+		return nil, errors.Errorf("egress has no target")
+	}
+}
+
+// Egress writes every file in commit to sink. progress is called after each
+// file is written, and once before the first. If resume is set, it's the
+// progress of an earlier egress of commit that failed, and, unless sink is
+// transactional, the files it had written are skipped.
+func Egress(pachClient *client.APIClient, commit *pfs.Commit, sink Sink, resume *pps.EgressProgress, progress func(*pps.EgressProgress) error) (retErr error) {
+	defer func() {
+		if err := sink.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	var files []*pfs.FileInfo
+	if err := pachClient.Walk(commit.Repo.Name, commit.ID, "", func(fileInfo *pfs.FileInfo) error {
+		if fileInfo.FileType == pfs.FileType_FILE {
+			files = append(files, fileInfo)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	p := &pps.EgressProgress{FilesTotal: int64(len(files))}
+	if resume != nil && resume.LastPath != "" && !sink.Transactional() {
+		for i, fileInfo := range files {
+			if fileInfo.File.Path == resume.LastPath {
+				for _, written := range files[:i+1] {
+					p.FilesEgressed++
+					p.BytesEgressed += written.SizeBytes
+				}
+				p.LastPath = resume.LastPath
+				files = files[i+1:]
+				break
+			}
+		}
+	}
+	if err := progress(p); err != nil {
+		return err
+	}
+	for _, fileInfo := range files {
+		r, err := pachClient.GetFileReader(commit.Repo.Name, commit.ID, fileInfo.File.Path, 0, 0)
+		if err != nil {
+			return err
+		}
+		if err := sink.Write(pachClient.Ctx(), fileInfo.File, r); err != nil {
+			return errors.Wrapf(err, "could not egress %s", fileInfo.File.Path)
+		}
+		p.FilesEgressed++
+		p.BytesEgressed += fileInfo.SizeBytes
+		p.LastPath = fileInfo.File.Path
+		if err := progress(p); err != nil {
+			return err
+		}
+	}
+	return sink.Flush(pachClient.Ctx())
+}
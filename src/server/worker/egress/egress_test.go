@@ -0,0 +1,118 @@
+package egress
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+)
+
+func TestHTTPSink(t *testing.T) {
+	received := make(map[string]string)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "PUT", r.Method)
+		require.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		require.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+		require.Equal(t, "commit:"+r.Header.Get(filePathHeader), r.Header.Get(idempotencyKeyHeader))
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		if r.Header.Get(filePathHeader) == "/fail" {
+			http.Error(w, "no thanks", http.StatusBadRequest)
+			return
+		}
+		received[r.Header.Get(filePathHeader)] = string(body)
+	}))
+	defer server.Close()
+
+	sink, err := newHTTPSink(&pps.HTTPEgress{
+		URL:     server.URL,
+		Method:  "PUT",
+		Headers: map[string]string{"Content-Type": "text/plain"},
+	}, "Bearer secret")
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, sink.Write(ctx, client.NewFile("repo", "commit", "/a"), strings.NewReader("foo")))
+	require.NoError(t, sink.Write(ctx, client.NewFile("repo", "commit", "/dir/b"), strings.NewReader("bar")))
+	err = sink.Write(ctx, client.NewFile("repo", "commit", "/fail"), strings.NewReader("baz"))
+	require.YesError(t, err)
+	require.True(t, strings.Contains(err.Error(), "no thanks"))
+	require.NoError(t, sink.Flush(ctx))
+	require.NoError(t, sink.Close())
+	require.Equal(t, map[string]string{"/a": "foo", "/dir/b": "bar"}, received)
+}
+
+func TestNewSinkNoTarget(t *testing.T) {
+	_, err := NewSink(&pps.Egress{})
+	require.YesError(t, err)
+	_, err = NewSink(&pps.Egress{Queue: &pps.QueueEgress{Kind: "carrier-pigeon"}})
+	require.YesError(t, err)
+}
+
+// testSink records the paths written to it, and fails to write failPath
+type testSink struct {
+	written       []string
+	failPath      string
+	transactional bool
+}
+
+func (s *testSink) Write(ctx context.Context, file *pfs.File, r io.Reader) error {
+	if file.Path == s.failPath {
+		return errors.Errorf("could not write %s", file.Path)
+	}
+	s.written = append(s.written, file.Path)
+	return nil
+}
+
+func (s *testSink) Flush(ctx context.Context) error { return nil }
+
+func (s *testSink) Close() error { return nil }
+
+func (s *testSink) Transactional() bool { return s.transactional }
+
+func TestEgressResume(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		repo := tu.UniqueString("TestEgressResume")
+		require.NoError(t, c.CreateRepo(repo))
+		commit, err := c.StartCommit(repo, "master")
+		require.NoError(t, err)
+		for _, path := range []string{"/a", "/b", "/c"} {
+			_, err := c.PutFile(repo, commit.ID, path, strings.NewReader(path))
+			require.NoError(t, err)
+		}
+		require.NoError(t, c.FinishCommit(repo, commit.ID))
+
+		var progress *pps.EgressProgress
+		record := func(p *pps.EgressProgress) error {
+			progress = p
+			return nil
+		}
+		sink := &testSink{failPath: "/b"}
+		require.YesError(t, Egress(c, commit, sink, nil, record))
+		require.Equal(t, []string{"/a"}, sink.written)
+		require.Equal(t, "/a", progress.LastPath)
+
+		// retries resume after the last file written
+		sink = &testSink{}
+		require.NoError(t, Egress(c, commit, sink, progress, record))
+		require.Equal(t, []string{"/b", "/c"}, sink.written)
+		require.Equal(t, int64(3), progress.FilesEgressed)
+
+		// unless the sink is transactional
+		sink = &testSink{transactional: true}
+		require.NoError(t, Egress(c, commit, sink, &pps.EgressProgress{LastPath: "/a"}, record))
+		require.Equal(t, []string{"/a", "/b", "/c"}, sink.written)
+		return nil
+	}))
+}
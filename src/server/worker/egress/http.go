@@ -0,0 +1,83 @@
+package egress
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+)
+
+const (
+	// filePathHeader is the header that carries the path of the file in each
+	// request made by the http sink
+	filePathHeader = "Pach-File-Path"
+	// idempotencyKeyHeader is the header that carries the IdempotencyKey of
+	// the file in each request made by the http sink
+	idempotencyKeyHeader = "Idempotency-Key"
+)
+
+// httpSink sends each file to an HTTP endpoint as the body of a request.
+type httpSink struct {
+	client        *http.Client
+	url           string
+	method        string
+	headers       map[string]string
+	authorization string
+}
+
+func newHTTPSink(egress *pps.HTTPEgress, secret string) (*httpSink, error) {
+	method := egress.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+	return &httpSink{
+		client:        &http.Client{},
+		url:           egress.URL,
+		method:        method,
+		headers:       egress.Headers,
+		authorization: secret,
+	}, nil
+}
+
+func (s *httpSink) Write(ctx context.Context, file *pfs.File, r io.Reader) error {
+	req, err := http.NewRequest(s.method, s.url, r)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	req = req.WithContext(ctx)
+	for k, v := range s.headers {
+		req.Header.Set(k, v)
+	}
+	if s.authorization != "" {
+		req.Header.Set("Authorization", s.authorization)
+	}
+	req.Header.Set(filePathHeader, file.Path)
+	req.Header.Set(idempotencyKeyHeader, IdempotencyKey(file))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("%s %s returned %s: %s", s.method, s.url, resp.Status, body)
+	}
+	return nil
+}
+
+func (s *httpSink) Flush(ctx context.Context) error {
+	return nil
+}
+
+func (s *httpSink) Close() error {
+	return nil
+}
+
+func (s *httpSink) Transactional() bool {
+	return false
+}
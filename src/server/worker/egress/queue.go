@@ -0,0 +1,63 @@
+package egress
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// queueSink publishes each file as a message, keyed by the file's path, with
+// the file's IdempotencyKey in the message's idempotencyKeyHeader header.
+// Only kafka is supported.
+type queueSink struct {
+	writer *kafka.Writer
+}
+
+func newQueueSink(egress *pps.QueueEgress) (*queueSink, error) {
+	if egress.Kind != "kafka" {
+		return nil, errors.Errorf("unsupported queue kind %q", egress.Kind)
+	}
+	return &queueSink{
+		writer: kafka.NewWriter(kafka.WriterConfig{
+			Brokers: egress.Brokers,
+			Topic:   egress.Topic,
+			// messages for the same file always land on the same partition
+			Balancer: &kafka.Hash{},
+			// files are written one at a time, so don't wait for a batch to
+			// fill up before sending each of them
+			BatchSize: 1,
+		}),
+	}, nil
+}
+
+func (s *queueSink) Write(ctx context.Context, file *pfs.File, r io.Reader) error {
+	value, err := ioutil.ReadAll(r)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	return errors.EnsureStack(s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(file.Path),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: idempotencyKeyHeader, Value: []byte(IdempotencyKey(file))},
+		},
+	}))
+}
+
+func (s *queueSink) Flush(ctx context.Context) error {
+	return nil
+}
+
+func (s *queueSink) Close() error {
+	return errors.EnsureStack(s.writer.Close())
+}
+
+func (s *queueSink) Transactional() bool {
+	return false
+}
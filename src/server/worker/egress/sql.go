@@ -0,0 +1,178 @@
+package egress
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+
+	"github.com/lib/pq"
+)
+
+// sqlSink loads files into a table. All files are loaded in a single
+// transaction, so a failed egress leaves the table untouched.
+type sqlSink struct {
+	db     *sql.DB
+	tx     *sql.Tx
+	schema string
+	table  string
+	format string
+}
+
+func newSQLSink(egress *pps.SQLEgress, secret string) (*sqlSink, error) {
+	url := egress.URL
+	if secret != "" {
+		url = secret
+	}
+	db, err := sql.Open(egress.Driver, url)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	tx, err := db.Begin()
+	if err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "could not connect to database")
+	}
+	s := &sqlSink{db: db, tx: tx, table: egress.Table, format: egress.FileFormat}
+	// tables may be qualified by their schema
+	if i := strings.Index(s.table, "."); i >= 0 {
+		s.schema, s.table = s.table[:i], s.table[i+1:]
+	}
+	return s, nil
+}
+
+func (s *sqlSink) Write(ctx context.Context, file *pfs.File, r io.Reader) error {
+	switch s.format {
+	case "csv":
+		return s.writeCSV(ctx, r)
+	case "json":
+		return s.writeJSON(ctx, r)
+	default:
+		return errors.Errorf("unsupported file format %q", s.format)
+	}
+}
+
+// writeCSV bulk loads a CSV file, whose header names the columns that each
+// row's values are loaded into
+func (s *sqlSink) writeCSV(ctx context.Context, r io.Reader) (retErr error) {
+	records := csv.NewReader(r)
+	columns, err := records.Read()
+	if errors.Is(err, io.EOF) {
+		return nil
+	} else if err != nil {
+		return errors.Wrapf(err, "could not read csv header")
+	}
+
+	var stmt *sql.Stmt
+	if s.schema != "" {
+		stmt, err = s.tx.PrepareContext(ctx, pq.CopyInSchema(s.schema, s.table, columns...))
+	} else {
+		stmt, err = s.tx.PrepareContext(ctx, pq.CopyIn(s.table, columns...))
+	}
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer func() {
+		if err := stmt.Close(); err != nil && retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+	}()
+	for {
+		record, err := records.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return errors.Wrapf(err, "could not read csv record")
+		}
+		values := make([]interface{}, len(record))
+		for i, v := range record {
+			values[i] = v
+		}
+		if _, err := stmt.ExecContext(ctx, values...); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	// executing the statement with no arguments flushes the copy
+	_, err = stmt.ExecContext(ctx)
+	return errors.EnsureStack(err)
+}
+
+// writeJSON inserts each object in a stream of JSON objects as a row. Nested
+// objects and arrays are inserted as JSON text.
+func (s *sqlSink) writeJSON(ctx context.Context, r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	for {
+		var row map[string]interface{}
+		if err := decoder.Decode(&row); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return errors.Wrapf(err, "could not decode json object")
+		}
+		if len(row) == 0 {
+			continue
+		}
+
+		columns := make([]string, 0, len(row))
+		for column := range row {
+			columns = append(columns, column)
+		}
+		sort.Strings(columns)
+		quoted := make([]string, len(columns))
+		placeholders := make([]string, len(columns))
+		values := make([]interface{}, len(columns))
+		for i, column := range columns {
+			quoted[i] = pq.QuoteIdentifier(column)
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+			switch v := row[column].(type) {
+			case map[string]interface{}, []interface{}:
+				b, err := json.Marshal(v)
+				if err != nil {
+					return errors.EnsureStack(err)
+				}
+				values[i] = string(b)
+			case json.Number:
+				values[i] = v.String()
+			default:
+				values[i] = v
+			}
+		}
+		query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			s.qualifiedTable(), strings.Join(quoted, ", "), strings.Join(placeholders, ", "))
+		if _, err := s.tx.ExecContext(ctx, query, values...); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+}
+
+func (s *sqlSink) qualifiedTable() string {
+	if s.schema != "" {
+		return pq.QuoteIdentifier(s.schema) + "." + pq.QuoteIdentifier(s.table)
+	}
+	return pq.QuoteIdentifier(s.table)
+}
+
+func (s *sqlSink) Flush(ctx context.Context) error {
+	return errors.EnsureStack(s.tx.Commit())
+}
+
+func (s *sqlSink) Close() error {
+	// rolling back a committed transaction is a no-op
+	if err := s.tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		s.db.Close()
+		return errors.EnsureStack(err)
+	}
+	return errors.EnsureStack(s.db.Close())
+}
+
+func (s *sqlSink) Transactional() bool {
+	return true
+}
@@ -0,0 +1,95 @@
+package local
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/datum"
+	"github.com/pachyderm/pachyderm/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// FindDatum returns the inputs of the datum 'datumID' of the job 'jobInfo',
+// computed from the job's input commits as the job's workers computed them.
+// 'datumID' may be either the ID that 'inspect datum' uses, or the ID that
+// 'list datum' reports for pipelines without stats.
+func FindDatum(pachClient *client.APIClient, jobInfo *pps.JobInfo, datumID string) ([]*common.Input, error) {
+	dit, err := datum.NewIterator(pachClient, jobInfo.Input)
+	if err != nil {
+		return nil, err
+	}
+	for dit.Next() {
+		inputs := dit.Datum()
+		if common.DatumID(inputs) == datumID ||
+			common.HashDatum(jobInfo.Pipeline.Name, jobInfo.Salt, inputs) == datumID {
+			return inputs, nil
+		}
+	}
+	return nil, errors.Errorf("datum %s not found in job %s", datumID, jobInfo.Job.ID)
+}
+
+// DownloadDatum recreates the input directory that a worker of 'jobInfo'
+// presents to the datum 'inputs', at <root>/pfs, including an empty output
+// directory at <root>/pfs/out. Lazy inputs are downloaded eagerly, as their
+// pipes wouldn't outlive this call.
+func DownloadDatum(pachClient *client.APIClient, jobInfo *pps.JobInfo, inputs []*common.Input, root string) error {
+	pipelineInfo := &pps.PipelineInfo{
+		Pipeline:  jobInfo.Pipeline,
+		Transform: localTransform(jobInfo.Transform),
+		Input:     jobInfo.Input,
+	}
+	d, cleanup, err := newDriver(pachClient, pipelineInfo, root)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+	var eagerInputs []*common.Input
+	for _, input := range inputs {
+		eagerInput := *input
+		eagerInput.Lazy = false
+		eagerInputs = append(eagerInputs, &eagerInput)
+	}
+	_, err = d.WithData(eagerInputs, nil, logs.NewMockLogger(), func(dir string, _ *pps.ProcessStats) error {
+		// Move the data out of the scratch directory, which WithData removes
+		entries, err := ioutil.ReadDir(dir)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		for _, entry := range entries {
+			if err := os.Rename(filepath.Join(dir, entry.Name()), filepath.Join(d.InputDir(), entry.Name())); err != nil {
+				return errors.EnsureStack(err)
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// DatumEnv returns the environment variables, in addition to the worker's
+// own environment, that the user code of 'jobInfo' is run with for the datum
+// 'inputs', if the datum's input directory is at 'inputDir'. Variables set
+// from secrets aren't included.
+func DatumEnv(jobInfo *pps.JobInfo, inputs []*common.Input, inputDir string) []string {
+	var result []string
+	var keys []string
+	for key := range jobInfo.Transform.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		result = append(result, fmt.Sprintf("%s=%s", key, jobInfo.Transform.Env[key]))
+	}
+	result = append(result, driver.InputEnv(inputDir, inputs)...)
+	result = append(result, fmt.Sprintf("%s=%s", client.JobIDEnv, jobInfo.Job.ID))
+	if jobInfo.OutputCommit != nil {
+		result = append(result, fmt.Sprintf("%s=%s", client.OutputCommitIDEnv, jobInfo.OutputCommit.ID))
+	}
+	return result
+}
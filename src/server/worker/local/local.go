@@ -0,0 +1,467 @@
+// Package local runs a pipeline's transform on the local machine, without
+// Kubernetes. It computes the pipeline's datums from the current heads of its
+// input branches, downloads each datum into a /pfs-shaped directory with the
+// same layout that workers use, runs the transform's command as a local
+// process, and merges each datum's output into a local directory, which can
+// then be diffed against or uploaded to a branch.
+package local
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/datum"
+	"github.com/pachyderm/pachyderm/src/server/worker/driver"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+// Options configures a local run
+type Options struct {
+	// Root is the directory in which the pfs directory is created, so inputs
+	// are presented under <Root>/pfs/<input> and the output directory is
+	// <Root>/pfs/out. Setting it to "/" matches the paths in a worker, but
+	// requires write access to /pfs. If it's empty, a temporary directory is
+	// used.
+	Root string
+	// Out is the directory into which the output of every datum is merged.
+	// Files written by more than one datum are concatenated, as they are in
+	// an output commit. It must be empty or not exist yet, so that the
+	// output of an earlier run isn't merged with this run's.
+	Out string
+	// DatumLimit, if positive, is the number of datums to process
+	DatumLimit int64
+	// Stdout and Stderr receive the output of the transform. Progress is also
+	// written to Stderr.
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// Run runs 'request's transform locally on each of its datums, and returns
+// the number of datums processed
+func Run(pachClient *client.APIClient, request *pps.CreatePipelineRequest, opts *Options) (_ int64, retErr error) {
+	pipelineInfo, err := localPipelineInfo(request)
+	if err != nil {
+		return 0, err
+	}
+	if err := resolveInput(pachClient, pipelineInfo.Pipeline.Name, pipelineInfo.Input); err != nil {
+		return 0, err
+	}
+	dit, err := datum.NewIterator(pachClient, pipelineInfo.Input)
+	if err != nil {
+		return 0, err
+	}
+	root := opts.Root
+	if root == "" {
+		if root, err = ioutil.TempDir("", "pachyderm-local-"); err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+		defer func() {
+			if err := os.RemoveAll(root); err != nil && retErr == nil {
+				retErr = errors.EnsureStack(err)
+			}
+		}()
+	}
+	if err := makeEmptyDir(opts.Out); err != nil {
+		return 0, err
+	}
+	d, cleanup, err := newDriver(pachClient, pipelineInfo, root)
+	if err != nil {
+		return 0, err
+	}
+	defer cleanup()
+	var datumTimeout time.Duration
+	if pipelineInfo.DatumTimeout != nil {
+		if datumTimeout, err = types.DurationFromProto(pipelineInfo.DatumTimeout); err != nil {
+			return 0, errors.EnsureStack(err)
+		}
+	}
+	n := int64(dit.Len())
+	if opts.DatumLimit > 0 && opts.DatumLimit < n {
+		n = opts.DatumLimit
+	}
+	// The transform's output goes to opts.Stdout and opts.Stderr, and the
+	// driver's own log statements are discarded
+	logger := logs.NewMockLogger()
+	for i := int64(0); i < n; i++ {
+		inputs := dit.DatumN(int(i))
+		fmt.Fprintf(opts.Stderr, "datum %d/%d: %s\n", i+1, n, datumPaths(inputs))
+		if _, err := d.WithData(inputs, nil, logger, func(dir string, _ *pps.ProcessStats) error {
+			env := d.UserCodeEnv("", nil, inputs)
+			if err := d.WithActiveData(inputs, dir, func() error {
+				return runTransform(pachClient.Ctx(), pipelineInfo.Transform, env, datumTimeout, opts)
+			}); err != nil {
+				return err
+			}
+			return mergeOutput(filepath.Join(dir, "out"), opts.Out)
+		}); err != nil {
+			return i, errors.Wrapf(err, "datum %s failed", datumPaths(inputs))
+		}
+	}
+	return n, nil
+}
+
+// newDriver returns a driver that downloads data into <root>/pfs. The
+// returned function removes the driver's temporary files.
+func newDriver(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, root string) (driver.Driver, func(), error) {
+	hashtreePath, err := ioutil.TempDir("", "pachyderm-local-hashtrees-")
+	if err != nil {
+		return nil, nil, errors.EnsureStack(err)
+	}
+	cleanup := func() { os.RemoveAll(hashtreePath) }
+	// Local drivers don't use etcd, which the driver only needs for the job
+	// and pipeline collections
+	d, err := driver.NewDriver(pipelineInfo, pachClient, nil, "", hashtreePath, root, "")
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	return d, cleanup, nil
+}
+
+// localTransform returns a copy of 'transform' that runs on the local
+// machine. The transform's user and working directory refer to its image, so
+// the command runs as the current user, in the current directory.
+func localTransform(transform *pps.Transform) *pps.Transform {
+	result := *transform
+	result.User = ""
+	result.WorkingDir = ""
+	return &result
+}
+
+// localPipelineInfo checks that 'request' can be run locally, and returns the
+// PipelineInfo that the local driver uses
+func localPipelineInfo(request *pps.CreatePipelineRequest) (*pps.PipelineInfo, error) {
+	switch {
+	case request.Pipeline == nil || request.Pipeline.Name == "":
+		return nil, errors.Errorf("pipeline spec must specify a name")
+	case request.Transform == nil || len(request.Transform.Cmd) == 0:
+		return nil, errors.Errorf("pipeline spec must specify a transform with a cmd")
+	case request.Input == nil:
+		return nil, errors.Errorf("pipeline spec must specify an input")
+	case request.Spout != nil || request.Service != nil:
+		return nil, errors.Errorf("spouts and services can't be run locally")
+	case request.S3Out || ppsutil.ContainsS3Inputs(request.Input):
+		return nil, errors.Errorf("pipelines that use the S3 gateway can't be run locally")
+	}
+	return &pps.PipelineInfo{
+		Pipeline:     request.Pipeline,
+		Transform:    localTransform(request.Transform),
+		Input:        request.Input,
+		OutputBranch: request.OutputBranch,
+		DatumTimeout: request.DatumTimeout,
+	}, nil
+}
+
+// resolveInput fills in the defaults that pachd would set for 'input', and
+// sets each of its commits to the current head of its branch. Branches
+// without a head contribute no datums, as they would in a pipeline's first
+// job.
+func resolveInput(pachClient *client.APIClient, pipelineName string, input *pps.Input) error {
+	headCommit := func(repo, branch string) (string, error) {
+		ci, err := pachClient.InspectCommit(repo, branch)
+		if err != nil {
+			if errutil.IsNotFoundError(err) {
+				return "", nil
+			}
+			return "", err
+		}
+		return ci.Commit.ID, nil
+	}
+	var visitErr error
+	pps.VisitInput(input, func(input *pps.Input) {
+		if visitErr != nil {
+			return
+		}
+		switch {
+		case input.Pfs != nil:
+			if input.Pfs.Branch == "" {
+				input.Pfs.Branch = "master"
+			}
+			if input.Pfs.Name == "" {
+				input.Pfs.Name = input.Pfs.Repo
+			}
+			input.Pfs.Commit, visitErr = headCommit(input.Pfs.Repo, input.Pfs.Branch)
+		case input.Cron != nil:
+			if input.Cron.Repo == "" {
+				input.Cron.Repo = fmt.Sprintf("%s_%s", pipelineName, input.Cron.Name)
+			}
+			input.Cron.Commit, visitErr = headCommit(input.Cron.Repo, "master")
+		case input.Git != nil:
+			if input.Git.Branch == "" {
+				input.Git.Branch = "master"
+			}
+			if input.Git.Name == "" {
+				input.Git.Name = strings.Split(path.Base(input.Git.URL), ".")[0]
+			}
+			input.Git.Commit, visitErr = headCommit(input.Git.Name, input.Git.Branch)
+		}
+	})
+	if visitErr != nil {
+		return visitErr
+	}
+	pps.SortInput(input) // Match the order of the created pipeline's datums
+	return nil
+}
+
+// runTransform runs the transform's command with the environment 'env'
+func runTransform(ctx context.Context, transform *pps.Transform, env []string, timeout time.Duration, opts *Options) error {
+	if timeout > 0 {
+		var cancel func()
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	cmd := exec.CommandContext(ctx, transform.Cmd[0], transform.Cmd[1:]...)
+	if transform.Stdin != nil {
+		cmd.Stdin = strings.NewReader(strings.Join(transform.Stdin, "\n") + "\n")
+	}
+	cmd.Stdout = opts.Stdout
+	cmd.Stderr = opts.Stderr
+	cmd.Env = env
+	err := cmd.Run()
+	exitErr := &exec.ExitError{}
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok {
+			for _, returnCode := range transform.AcceptReturnCode {
+				if int(returnCode) == status.ExitStatus() {
+					return nil
+				}
+			}
+		}
+	}
+	return errors.EnsureStack(err)
+}
+
+// mergeOutput appends each file in a datum's output directory to the file
+// with the same path in 'out'. Symlinks (e.g. to input files) are followed.
+func mergeOutput(datumOut, out string) error {
+	return filepath.Walk(datumOut, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		relPath, err := filepath.Rel(datumOut, filePath)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			if info, err = os.Stat(filePath); err != nil {
+				return errors.EnsureStack(err)
+			}
+			if info.IsDir() {
+				return mergeOutput(filePath, filepath.Join(out, relPath))
+			}
+		}
+		if info.IsDir() {
+			return errors.EnsureStack(os.MkdirAll(filepath.Join(out, relPath), 0777))
+		}
+		return appendFile(filePath, filepath.Join(out, relPath))
+	})
+}
+
+// makeEmptyDir creates the directory 'dir', or checks that it's empty if it
+// already exists
+func makeEmptyDir(dir string) error {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return errors.EnsureStack(err)
+	}
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	if len(entries) > 0 {
+		return errors.Errorf("output directory %s isn't empty; remove its contents or choose another one", dir)
+	}
+	return nil
+}
+
+func appendFile(src, dst string) (retErr error) {
+	r, err := os.Open(src)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer r.Close()
+	w, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0666)
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer func() {
+		if err := w.Close(); err != nil && retErr == nil {
+			retErr = errors.EnsureStack(err)
+		}
+	}()
+	_, err = io.Copy(w, r)
+	return errors.EnsureStack(err)
+}
+
+func datumPaths(inputs []*common.Input) string {
+	var paths []string
+	for _, input := range inputs {
+		paths = append(paths, path.Join(input.Name, input.FileInfo.File.Path))
+	}
+	return strings.Join(paths, ", ")
+}
+
+// FileDiff is a file that differs between a local output directory and a
+// branch. Local is false if the file is only in the branch, and Remote is
+// false if it's only in the local directory.
+type FileDiff struct {
+	Path   string
+	Local  bool
+	Remote bool
+}
+
+func (d *FileDiff) String() string {
+	switch {
+	case !d.Remote:
+		return fmt.Sprintf("+ %s", d.Path)
+	case !d.Local:
+		return fmt.Sprintf("- %s", d.Path)
+	default:
+		return fmt.Sprintf("~ %s", d.Path)
+	}
+}
+
+// Diff returns the files that differ between the directory 'out' and the
+// head of 'repo'@'branch', sorted by path. If the branch has no head, every
+// local file is returned.
+func Diff(pachClient *client.APIClient, out, repo, branch string) ([]*FileDiff, error) {
+	localFiles, err := localFiles(out)
+	if err != nil {
+		return nil, err
+	}
+	var result []*FileDiff
+	remoteFiles := make(map[string]bool)
+	if err := pachClient.Walk(repo, branch, "/", func(fi *pfs.FileInfo) error {
+		if fi.FileType != pfs.FileType_FILE {
+			return nil
+		}
+		remoteFiles[fi.File.Path] = true
+		localPath, ok := localFiles[fi.File.Path]
+		if !ok {
+			result = append(result, &FileDiff{Path: fi.File.Path, Remote: true})
+			return nil
+		}
+		same, err := sameContent(pachClient, fi, localPath)
+		if err != nil {
+			return err
+		}
+		if !same {
+			result = append(result, &FileDiff{Path: fi.File.Path, Local: true, Remote: true})
+		}
+		return nil
+	}); err != nil && !errutil.IsNotFoundError(err) {
+		return nil, err
+	}
+	for p := range localFiles {
+		if !remoteFiles[p] {
+			result = append(result, &FileDiff{Path: p, Local: true})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result, nil
+}
+
+// localFiles maps the PFS path of each file in 'out' to its local path
+func localFiles(out string) (map[string]string, error) {
+	result := make(map[string]string)
+	if err := filepath.Walk(out, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(out, filePath)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		result[path.Join("/", filepath.ToSlash(relPath))] = filePath
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func sameContent(pachClient *client.APIClient, fi *pfs.FileInfo, localPath string) (bool, error) {
+	local, err := ioutil.ReadFile(localPath)
+	if err != nil {
+		return false, errors.EnsureStack(err)
+	}
+	if uint64(len(local)) != fi.SizeBytes {
+		return false, nil
+	}
+	var remote bytes.Buffer
+	if err := pachClient.GetFile(fi.File.Commit.Repo.Name, fi.File.Commit.ID, fi.File.Path, 0, 0, &remote); err != nil {
+		return false, err
+	}
+	return bytes.Equal(local, remote.Bytes()), nil
+}
+
+// Upload writes the contents of the directory 'out' to a new commit on
+// 'repo'@'branch', replacing the contents of the branch's previous head. The
+// repo is created if it doesn't exist.
+func Upload(pachClient *client.APIClient, out, repo, branch string) (_ *pfs.Commit, retErr error) {
+	localFiles, err := localFiles(out)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := pachClient.InspectRepo(repo); err != nil {
+		if !errutil.IsNotFoundError(err) {
+			return nil, err
+		}
+		if err := pachClient.CreateRepo(repo); err != nil {
+			return nil, err
+		}
+	}
+	commit, err := pachClient.StartCommit(repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			pachClient.DeleteCommit(repo, commit.ID)
+		}
+	}()
+	if err := pachClient.DeleteFile(repo, commit.ID, "/"); err != nil {
+		return nil, err
+	}
+	for p, localPath := range localFiles {
+		if err := func() error {
+			f, err := os.Open(localPath)
+			if err != nil {
+				return errors.EnsureStack(err)
+			}
+			defer f.Close()
+			_, err = pachClient.PutFile(repo, commit.ID, p, f)
+			return err
+		}(); err != nil {
+			return nil, err
+		}
+	}
+	if err := pachClient.FinishCommit(repo, commit.ID); err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
@@ -0,0 +1,184 @@
+package local
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/datum"
+)
+
+func diffStrings(diffs []*FileDiff) []string {
+	var result []string
+	for _, diff := range diffs {
+		result = append(result, diff.String())
+	}
+	return result
+}
+
+func TestRun(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		dataRepo := tu.UniqueString(t.Name() + "_data")
+		require.NoError(t, c.CreateRepo(dataRepo))
+		commit, err := c.StartCommit(dataRepo, "master")
+		require.NoError(t, err)
+		_, err = c.PutFile(dataRepo, commit.ID, "a", strings.NewReader("foo\n"))
+		require.NoError(t, err)
+		_, err = c.PutFile(dataRepo, commit.ID, "b", strings.NewReader("bar\n"))
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+		root, err := ioutil.TempDir("", "local-root")
+		require.NoError(t, err)
+		defer os.RemoveAll(root)
+		out, err := ioutil.TempDir("", "local-out")
+		require.NoError(t, err)
+		defer os.RemoveAll(out)
+		outDir := filepath.Join(root, "pfs", "out")
+		request := &pps.CreatePipelineRequest{
+			Pipeline: client.NewPipeline("local"),
+			Transform: &pps.Transform{
+				Cmd: []string{"sh"},
+				Stdin: []string{
+					"cp \"$data\" " + outDir + "/",
+					"cat \"$data\" >> " + outDir + "/all",
+				},
+			},
+			Input: client.NewPFSInputOpts("data", dataRepo, "", "/*", "", "", false, false),
+		}
+		var stdout bytes.Buffer
+		n, err := Run(c, request, &Options{Root: root, Out: out, Stdout: &stdout, Stderr: &stdout})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), n)
+		for name, content := range map[string]string{"a": "foo\n", "b": "bar\n", "all": "foo\nbar\n"} {
+			actual, err := ioutil.ReadFile(filepath.Join(out, name))
+			require.NoError(t, err)
+			require.Equal(t, content, string(actual))
+		}
+
+		// Nothing has been uploaded yet, so every file is new
+		outRepo := tu.UniqueString(t.Name() + "_out")
+		require.NoError(t, c.CreateRepo(outRepo))
+		diffs, err := Diff(c, out, outRepo, "master")
+		require.NoError(t, err)
+		require.Equal(t, []string{"+ /a", "+ /all", "+ /b"}, diffStrings(diffs))
+
+		_, err = Upload(c, out, outRepo, "master")
+		require.NoError(t, err)
+		diffs, err = Diff(c, out, outRepo, "master")
+		require.NoError(t, err)
+		require.Equal(t, 0, len(diffs))
+
+		require.NoError(t, ioutil.WriteFile(filepath.Join(out, "a"), []byte("baz\n"), 0666))
+		require.NoError(t, os.Remove(filepath.Join(out, "b")))
+		diffs, err = Diff(c, out, outRepo, "master")
+		require.NoError(t, err)
+		require.Equal(t, []string{"~ /a", "- /b"}, diffStrings(diffs))
+
+		// Uploading replaces the branch's previous contents
+		_, err = Upload(c, out, outRepo, "master")
+		require.NoError(t, err)
+		diffs, err = Diff(c, out, outRepo, "master")
+		require.NoError(t, err)
+		require.Equal(t, 0, len(diffs))
+
+		// Running again into the same output directory would append to the
+		// previous run's files, so it's rejected
+		n, err = Run(c, request, &Options{Root: root, Out: out, Stdout: &stdout, Stderr: &stdout})
+		require.YesError(t, err)
+		require.Matches(t, "isn't empty", err.Error())
+		require.Equal(t, int64(0), n)
+		actual, err := ioutil.ReadFile(filepath.Join(out, "a"))
+		require.NoError(t, err)
+		require.Equal(t, "baz\n", string(actual))
+
+		// A failing datum stops the run
+		request.Transform.Stdin = []string{"exit 1"}
+		n, err = Run(c, request, &Options{Root: root, Out: filepath.Join(out, "failing"), Stdout: &stdout, Stderr: &stdout})
+		require.YesError(t, err)
+		require.Equal(t, int64(0), n)
+		request.Transform.AcceptReturnCode = []int64{1}
+		n, err = Run(c, request, &Options{Root: root, Out: filepath.Join(out, "accepted"), DatumLimit: 1, Stdout: &stdout, Stderr: &stdout})
+		require.NoError(t, err)
+		require.Equal(t, int64(1), n)
+		return nil
+	}))
+}
+
+func TestDebugDatum(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		dataRepo := tu.UniqueString(t.Name() + "_data")
+		require.NoError(t, c.CreateRepo(dataRepo))
+		commit, err := c.StartCommit(dataRepo, "master")
+		require.NoError(t, err)
+		_, err = c.PutFile(dataRepo, commit.ID, "dir/a", strings.NewReader("foo\n"))
+		require.NoError(t, err)
+		_, err = c.PutFile(dataRepo, commit.ID, "b", strings.NewReader("bar\n"))
+		require.NoError(t, err)
+		require.NoError(t, c.FinishCommit(dataRepo, commit.ID))
+
+		input := client.NewPFSInputOpts("data", dataRepo, "", "/*", "", "", false, false)
+		input.Pfs.Commit = commit.ID
+		jobInfo := &pps.JobInfo{
+			Job:      client.NewJob("job"),
+			Pipeline: client.NewPipeline("debug"),
+			Salt:     "salt",
+			Transform: &pps.Transform{
+				Cmd: []string{"sh"},
+				Env: map[string]string{"FOO": "bar"},
+			},
+			Input: input,
+		}
+		_, err = FindDatum(c, jobInfo, "nonexistent")
+		require.YesError(t, err)
+
+		// Find the datum by both kinds of ID
+		dit, err := datum.NewIterator(c, input)
+		require.NoError(t, err)
+		var dirDatum []*common.Input
+		for dit.Next() {
+			if dit.Datum()[0].FileInfo.File.Path == "/dir" {
+				dirDatum = dit.Datum()
+			}
+		}
+		require.NotNil(t, dirDatum)
+		inputs, err := FindDatum(c, jobInfo, common.DatumID(dirDatum))
+		require.NoError(t, err)
+		require.Equal(t, "/dir", inputs[0].FileInfo.File.Path)
+		inputs, err = FindDatum(c, jobInfo, common.HashDatum("debug", "salt", dirDatum))
+		require.NoError(t, err)
+		require.Equal(t, "/dir", inputs[0].FileInfo.File.Path)
+
+		root, err := ioutil.TempDir("", "debug-datum")
+		require.NoError(t, err)
+		defer os.RemoveAll(root)
+		require.NoError(t, DownloadDatum(c, jobInfo, inputs, root))
+		content, err := ioutil.ReadFile(filepath.Join(root, "pfs", "data", "dir", "a"))
+		require.NoError(t, err)
+		require.Equal(t, "foo\n", string(content))
+		_, err = os.Stat(filepath.Join(root, "pfs", "data", "b"))
+		require.YesError(t, err)
+		info, err := os.Stat(filepath.Join(root, "pfs", "out"))
+		require.NoError(t, err)
+		require.True(t, info.IsDir())
+
+		require.Equal(t, []string{
+			"FOO=bar",
+			"data=/pfs/data/dir",
+			"data_COMMIT=" + commit.ID,
+			client.JobIDEnv + "=job",
+		}, DatumEnv(jobInfo, inputs, "/pfs"))
+		return nil
+	}))
+}
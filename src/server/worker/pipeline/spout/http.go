@@ -0,0 +1,219 @@
+package spout
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+const (
+	defaultHTTPBatchBytes    = 64 * 1024 * 1024
+	defaultHTTPBatchInterval = time.Second
+)
+
+// runHTTP runs a built-in HTTP spout until the pachClient's context is
+// canceled
+func runHTTP(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, logger logs.TaggedLogger) error {
+	b, err := newBatcher(pachClient, pipelineInfo)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{
+		Addr:    fmt.Sprintf(":%d", pipelineInfo.Spout.Service.InternalPort),
+		Handler: newHTTPHandler(b, logger, os.Getenv(client.PPSSpoutSecretEnv)),
+	}
+	go func() {
+		<-pachClient.Ctx().Done()
+		server.Close()
+	}()
+	logger.Logf("http spout listening on %s", server.Addr)
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.EnsureStack(err)
+	}
+	return pachClient.Ctx().Err()
+}
+
+// batcher groups the files received by an HTTP spout into commits of the
+// spout's output branch
+type batcher struct {
+	pachClient   *client.APIClient
+	pipelineInfo *pps.PipelineInfo
+	maxBytes     int64
+	maxDelay     time.Duration
+
+	mu    sync.Mutex
+	batch *batch
+}
+
+// batch is an open commit that's receiving files. done is closed once the
+// commit has finished, or has been deleted, in which case err is set.
+type batch struct {
+	commit *pfs.Commit
+	size   int64
+	timer  *time.Timer
+	done   chan struct{}
+	err    error
+}
+
+func newBatcher(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo) (*batcher, error) {
+	b := &batcher{
+		pachClient:   pachClient,
+		pipelineInfo: pipelineInfo,
+		maxBytes:     defaultHTTPBatchBytes,
+		maxDelay:     defaultHTTPBatchInterval,
+	}
+	spec := pipelineInfo.Spout.HTTP
+	if spec.BatchBytes > 0 {
+		b.maxBytes = spec.BatchBytes
+	}
+	if spec.BatchInterval != nil {
+		interval, err := types.DurationFromProto(spec.BatchInterval)
+		if err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+		b.maxDelay = interval
+	}
+	return b, nil
+}
+
+// put adds a file to the current batch, starting a new batch if there isn't
+// one, and returns the batch that the file was added to
+func (b *batcher) put(filePath string, r io.Reader) (*batch, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	repo := b.pipelineInfo.Pipeline.Name
+	if b.batch == nil {
+		commit, err := b.pachClient.StartCommit(repo, b.pipelineInfo.OutputBranch)
+		if err != nil {
+			return nil, err
+		}
+		bt := &batch{commit: commit, done: make(chan struct{})}
+		bt.timer = time.AfterFunc(b.maxDelay, func() { b.finish(bt) })
+		b.batch = bt
+	}
+	bt := b.batch
+	var n int
+	var err error
+	if b.pipelineInfo.Spout.Overwrite {
+		n, err = b.pachClient.PutFileOverwrite(repo, bt.commit.ID, filePath, r, 0)
+	} else {
+		n, err = b.pachClient.PutFile(repo, bt.commit.ID, filePath, r)
+	}
+	if err != nil {
+		// The commit may hold part of the file, so none of its files are
+		// committed
+		b.closeLocked(errors.Wrapf(err, "could not put file %q", filePath))
+		return nil, err
+	}
+	bt.size += int64(n)
+	if bt.size >= b.maxBytes {
+		b.closeLocked(nil)
+	}
+	return bt, nil
+}
+
+// finish finishes 'bt', if it's still the current batch
+func (b *batcher) finish(bt *batch) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.batch == bt {
+		b.closeLocked(nil)
+	}
+}
+
+// closeLocked finishes the commit of the current batch, or deletes it if err
+// is set or the commit can't be finished. b.mu must be held.
+func (b *batcher) closeLocked(err error) {
+	bt := b.batch
+	b.batch = nil
+	bt.timer.Stop()
+	repo := b.pipelineInfo.Pipeline.Name
+	if err == nil {
+		err = b.pachClient.FinishCommit(repo, bt.commit.ID)
+	}
+	if err != nil {
+		b.pachClient.DeleteCommit(repo, bt.commit.ID)
+	}
+	bt.err = err
+	close(bt.done)
+}
+
+// newHTTPHandler returns the handler of an HTTP spout's requests. Each POST
+// or PUT request's body is committed to the path of the request. If secret
+// isn't empty, requests must carry it as a bearer token.
+func newHTTPHandler(b *batcher, logger logs.TaggedLogger, secret string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost && r.Method != http.MethodPut {
+			w.Header().Set("Allow", "POST, PUT")
+			http.Error(w, "only POST and PUT are supported", http.StatusMethodNotAllowed)
+			return
+		}
+		if secret != "" {
+			token := []byte(r.Header.Get("Authorization"))
+			if subtle.ConstantTimeCompare(token, []byte("Bearer "+secret)) != 1 {
+				http.Error(w, "invalid or missing bearer token", http.StatusUnauthorized)
+				return
+			}
+		}
+		filePath := path.Clean("/" + r.URL.Path)
+		if filePath == "/" {
+			http.Error(w, "the request's path must be the path of the file to commit", http.StatusBadRequest)
+			return
+		}
+		bt, err := putBody(b, filePath, r.Body)
+		if err != nil {
+			logger.Logf("http spout could not put file %q: %v", filePath, err)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		select {
+		case <-bt.done:
+		case <-r.Context().Done():
+			return
+		}
+		if bt.err != nil {
+			logger.Logf("http spout could not commit file %q: %v", filePath, bt.err)
+			http.Error(w, bt.err.Error(), http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintln(w, bt.commit.ID)
+	})
+}
+
+// putBody buffers a request's body in a temporary file, so that slow clients
+// don't hold up the batch while it's uploaded, and then adds it to a batch
+func putBody(b *batcher, filePath string, body io.Reader) (_ *batch, retErr error) {
+	f, err := ioutil.TempFile(os.TempDir(), "pachyderm_spout_http")
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	defer func() {
+		if err := os.Remove(f.Name()); retErr == nil {
+			retErr = err
+		}
+		if err := f.Close(); retErr == nil {
+			retErr = err
+		}
+	}()
+	if _, err := io.Copy(f, body); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	return b.put(filePath, f)
+}
@@ -28,6 +28,14 @@ func Run(driver driver.Driver, logger logs.TaggedLogger) error {
 		return pachClient.DeleteCommit(pipelineInfo.Pipeline.Name, c.Commit.ID)
 	})
 
+	// Built-in spouts don't run user code
+	switch {
+	case pipelineInfo.Spout.HTTP != nil:
+		return runHTTP(pachClient, pipelineInfo, logger)
+	case pipelineInfo.Spout.Watch != nil:
+		return runWatch(pachClient, pipelineInfo, logger)
+	}
+
 	// TODO: do something with stats?
 	_, err := driver.WithData(nil, nil, logger, func(dir string, stats *pps.ProcessStats) error {
 		inputs := []*common.Input{} // Spouts take no inputs
@@ -0,0 +1,158 @@
+package spout
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+	"github.com/pachyderm/pachyderm/src/server/pkg/testpachd"
+	tu "github.com/pachyderm/pachyderm/src/server/pkg/testutil"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+func newSpoutPipelineInfo(t *testing.T, c *client.APIClient, spout *pps.Spout) *pps.PipelineInfo {
+	repo := tu.UniqueString(strings.Replace(t.Name(), "/", "_", -1))
+	require.NoError(t, c.CreateRepo(repo))
+	require.NoError(t, c.CreateBranch(repo, "master", "", nil))
+	require.NoError(t, c.CreateBranch(repo, ppsconsts.SpoutMarkerBranch, "", nil))
+	return &pps.PipelineInfo{
+		Pipeline:     client.NewPipeline(repo),
+		OutputBranch: "master",
+		Spout:        spout,
+	}
+}
+
+func getFile(t *testing.T, c *client.APIClient, repo, branch, file string) string {
+	var buf bytes.Buffer
+	require.NoError(t, c.GetFile(repo, branch, file, 0, 0, &buf))
+	return buf.String()
+}
+
+func TestWatchSpout(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		pipelineInfo := newSpoutPipelineInfo(t, c, &pps.Spout{
+			Marker: "keys",
+			Watch:  &pps.WatchSpout{URL: "local://incoming"},
+		})
+		repo := pipelineInfo.Pipeline.Name
+		root, err := ioutil.TempDir("", "watch_spout")
+		require.NoError(t, err)
+		defer os.RemoveAll(root)
+		objClient, err := obj.NewLocalClient(root)
+		require.NoError(t, err)
+		put := func(name, contents string) {
+			require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(root, name)), 0755))
+			require.NoError(t, ioutil.WriteFile(filepath.Join(root, name), []byte(contents), 0644))
+		}
+		commits := func() int {
+			commitInfos, err := c.ListCommit(repo, "master", "", 0)
+			require.NoError(t, err)
+			return len(commitInfos)
+		}
+
+		put("incoming/a", "foo")
+		put("incoming/dir/b", "bar")
+		put("other/c", "baz")
+		w := newWatcher(c, pipelineInfo, objClient, "incoming")
+		require.NoError(t, w.loadMarker())
+		require.NoError(t, w.poll())
+		require.Equal(t, 1, commits())
+		require.Equal(t, "foo", getFile(t, c, repo, "master", "/a"))
+		require.Equal(t, "bar", getFile(t, c, repo, "master", "/dir/b"))
+		require.Equal(t, "incoming/a\nincoming/dir/b\n", getFile(t, c, repo, ppsconsts.SpoutMarkerBranch, "keys"))
+
+		// Objects are only committed once, and nothing is committed if there
+		// are no new objects
+		require.NoError(t, w.poll())
+		require.Equal(t, 1, commits())
+		put("incoming/d", "qux")
+		require.NoError(t, w.poll())
+		require.Equal(t, 2, commits())
+		require.Equal(t, "foo", getFile(t, c, repo, "master", "/a"))
+		require.Equal(t, "qux", getFile(t, c, repo, "master", "/d"))
+
+		// An object that was committed, but not added to the marker before the
+		// spout restarted, isn't committed again
+		put("incoming/e", "quux")
+		_, err = c.PutFile(repo, "master", "/e", strings.NewReader("quux"))
+		require.NoError(t, err)
+		w = newWatcher(c, pipelineInfo, objClient, "incoming")
+		require.NoError(t, w.loadMarker())
+		require.Equal(t, 3, len(w.seen))
+		require.NoError(t, w.poll())
+		require.Equal(t, 3, commits())
+		require.Equal(t, "quux", getFile(t, c, repo, "master", "/e"))
+		require.True(t, w.seen["incoming/e"])
+		return nil
+	}))
+}
+
+func TestHTTPSpout(t *testing.T) {
+	require.NoError(t, testpachd.WithRealEnv(func(env *testpachd.RealEnv) error {
+		c := env.PachClient
+		pipelineInfo := newSpoutPipelineInfo(t, c, &pps.Spout{
+			HTTP: &pps.HTTPSpout{BatchInterval: types.DurationProto(500 * time.Millisecond)},
+		})
+		repo := pipelineInfo.Pipeline.Name
+		b, err := newBatcher(c, pipelineInfo)
+		require.NoError(t, err)
+		server := httptest.NewServer(newHTTPHandler(b, logs.NewMockLogger(), "secret"))
+		defer server.Close()
+		post := func(path, token, body string) *http.Response {
+			req, err := http.NewRequest(http.MethodPost, server.URL+path, strings.NewReader(body))
+			require.NoError(t, err)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			return resp
+		}
+
+		require.Equal(t, http.StatusUnauthorized, post("/a", "", "foo").StatusCode)
+		require.Equal(t, http.StatusUnauthorized, post("/a", "wrong", "foo").StatusCode)
+		require.Equal(t, http.StatusBadRequest, post("/", "secret", "foo").StatusCode)
+
+		// Files received together are committed together, and requests
+		// return once their commit has finished
+		var wg sync.WaitGroup
+		for _, path := range []string{"/a", "/dir/b"} {
+			path := path
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				require.Equal(t, http.StatusOK, post(path, "secret", "foo").StatusCode)
+			}()
+		}
+		wg.Wait()
+		commitInfos, err := c.ListCommit(repo, "master", "", 0)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(commitInfos))
+		require.NotNil(t, commitInfos[0].Finished)
+		require.Equal(t, "foo", getFile(t, c, repo, "master", "/a"))
+		require.Equal(t, "foo", getFile(t, c, repo, "master", "/dir/b"))
+
+		// Batches are finished early once they're big enough
+		b.maxBytes = 3
+		b.maxDelay = time.Hour
+		require.Equal(t, http.StatusOK, post("/c", "secret", "bar").StatusCode)
+		require.Equal(t, "bar", getFile(t, c, repo, "master", "/c"))
+		return nil
+	}))
+}
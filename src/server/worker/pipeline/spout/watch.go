@@ -0,0 +1,240 @@
+package spout
+
+import (
+	"bufio"
+	"bytes"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
+	"github.com/pachyderm/pachyderm/src/server/pkg/obj"
+	"github.com/pachyderm/pachyderm/src/server/pkg/ppsconsts"
+	"github.com/pachyderm/pachyderm/src/server/worker/logs"
+)
+
+const defaultWatchPollInterval = 10 * time.Second
+
+// runWatch runs a built-in watch spout until the pachClient's context is
+// canceled
+func runWatch(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, logger logs.TaggedLogger) error {
+	spec := pipelineInfo.Spout.Watch
+	url, err := obj.ParseURL(spec.URL)
+	if err != nil {
+		return err
+	}
+	objClient, err := obj.NewClientFromURLAndSecret(url, false)
+	if err != nil {
+		return err
+	}
+	interval := defaultWatchPollInterval
+	if spec.PollInterval != nil {
+		if interval, err = types.DurationFromProto(spec.PollInterval); err != nil {
+			return errors.EnsureStack(err)
+		}
+	}
+	w := newWatcher(pachClient, pipelineInfo, objClient, url.Object)
+	ctx := pachClient.Ctx()
+	return backoff.RetryUntilCancel(ctx, func() error {
+		if err := w.loadMarker(); err != nil {
+			return err
+		}
+		for {
+			if err := w.poll(); err != nil {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(interval):
+			}
+		}
+	}, backoff.NewInfiniteBackOff(), func(err error, d time.Duration) error {
+		logger.Logf("error in watch spout: %+v, retrying in: %+v", err, d)
+		return nil
+	})
+}
+
+// watcher commits each new object under a prefix of an object store to the
+// output branch of a spout, exactly once. The keys of the objects that it's
+// committed are appended to the spout's marker, one per line, after the
+// commit that they're in has finished.
+type watcher struct {
+	pachClient   *client.APIClient
+	pipelineInfo *pps.PipelineInfo
+	objClient    obj.Client
+	prefix       string
+
+	// seen holds the keys in the marker
+	seen map[string]bool
+	// reconciled is set once the first poll since the marker was loaded has
+	// finished
+	reconciled bool
+}
+
+func newWatcher(pachClient *client.APIClient, pipelineInfo *pps.PipelineInfo, objClient obj.Client, prefix string) *watcher {
+	prefix = strings.Trim(prefix, "/")
+	if prefix != "" {
+		// The prefix is a directory, so that the objects' paths relative to it
+		// are well defined
+		prefix += "/"
+	}
+	return &watcher{
+		pachClient:   pachClient,
+		pipelineInfo: pipelineInfo,
+		objClient:    objClient,
+		prefix:       prefix,
+	}
+}
+
+// loadMarker reads the keys of the objects that have already been committed
+// from the spout's marker
+func (w *watcher) loadMarker() error {
+	w.seen = make(map[string]bool)
+	w.reconciled = false
+	repo := w.pipelineInfo.Pipeline.Name
+	branchInfo, err := w.pachClient.InspectBranch(repo, ppsconsts.SpoutMarkerBranch)
+	if err != nil {
+		if errutil.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	if branchInfo.Head == nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := w.pachClient.GetFile(repo, ppsconsts.SpoutMarkerBranch, w.pipelineInfo.Spout.Marker, 0, 0, &buf); err != nil {
+		if errutil.IsNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		if key := scanner.Text(); key != "" {
+			w.seen[key] = true
+		}
+	}
+	return errors.EnsureStack(scanner.Err())
+}
+
+// objectPath returns the path that the object 'key' is committed to
+func (w *watcher) objectPath(key string) string {
+	return path.Join("/", strings.TrimPrefix(key, w.prefix))
+}
+
+// poll commits the objects under the prefix that aren't in the marker, in a
+// single commit, and then adds them to the marker
+func (w *watcher) poll() error {
+	ctx := w.pachClient.Ctx()
+	repo := w.pipelineInfo.Pipeline.Name
+	var keys []string
+	if err := w.objClient.Walk(ctx, w.prefix, func(key string) error {
+		if strings.HasPrefix(key, w.prefix) && !w.seen[key] {
+			keys = append(keys, key)
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+	sort.Strings(keys)
+
+	var committed []string
+	if !w.reconciled {
+		// If the spout was restarted after its last commit finished but before
+		// the marker was updated, the objects in that commit aren't in the
+		// marker, but they're in the head of the output branch
+		var err error
+		if keys, committed, err = w.reconcile(keys); err != nil {
+			return err
+		}
+	}
+	if len(keys) > 0 {
+		commit, err := w.pachClient.StartCommit(repo, w.pipelineInfo.OutputBranch)
+		if err != nil {
+			return err
+		}
+		if err := w.putObjects(commit.ID, keys); err != nil {
+			w.pachClient.DeleteCommit(repo, commit.ID)
+			return err
+		}
+		if err := w.pachClient.FinishCommit(repo, commit.ID); err != nil {
+			return err
+		}
+		committed = append(committed, keys...)
+	}
+	if len(committed) > 0 {
+		var buf bytes.Buffer
+		for _, key := range committed {
+			buf.WriteString(key + "\n")
+		}
+		if _, err := w.pachClient.PutFile(repo, ppsconsts.SpoutMarkerBranch, w.pipelineInfo.Spout.Marker, &buf); err != nil {
+			return err
+		}
+		for _, key := range committed {
+			w.seen[key] = true
+		}
+	}
+	w.reconciled = true
+	return nil
+}
+
+// reconcile splits 'keys' into the keys whose objects aren't in the head of
+// the output branch, and the keys whose objects are
+func (w *watcher) reconcile(keys []string) (uncommitted, committed []string, _ error) {
+	repo := w.pipelineInfo.Pipeline.Name
+	branchInfo, err := w.pachClient.InspectBranch(repo, w.pipelineInfo.OutputBranch)
+	if err != nil {
+		return nil, nil, err
+	}
+	if branchInfo.Head == nil {
+		return keys, nil, nil
+	}
+	for _, key := range keys {
+		if _, err := w.pachClient.InspectFile(repo, branchInfo.Head.ID, w.objectPath(key)); err != nil {
+			if errutil.IsNotFoundError(err) {
+				uncommitted = append(uncommitted, key)
+				continue
+			}
+			return nil, nil, err
+		}
+		committed = append(committed, key)
+	}
+	return uncommitted, committed, nil
+}
+
+// putObjects copies the objects 'keys' into the open commit 'commitID'
+func (w *watcher) putObjects(commitID string, keys []string) error {
+	ctx := w.pachClient.Ctx()
+	repo := w.pipelineInfo.Pipeline.Name
+	for _, key := range keys {
+		if err := func() (retErr error) {
+			r, err := w.objClient.Reader(ctx, key, 0, 0)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				if err := r.Close(); retErr == nil {
+					retErr = err
+				}
+			}()
+			if w.pipelineInfo.Spout.Overwrite {
+				_, err = w.pachClient.PutFileOverwrite(repo, commitID, w.objectPath(key), r, 0)
+			} else {
+				_, err = w.pachClient.PutFile(repo, commitID, w.objectPath(key), r)
+			}
+			return err
+		}(); err != nil {
+			return errors.Wrapf(err, "could not commit object %q", key)
+		}
+	}
+	return nil
+}
@@ -69,6 +69,9 @@ func (td *testDriver) Jobs() col.Collection {
 func (td *testDriver) Pipelines() col.Collection {
 	return td.inner.Pipelines()
 }
+func (td *testDriver) JobQueues() col.Collection {
+	return td.inner.JobQueues()
+}
 func (td *testDriver) NewTaskWorker() *work.Worker {
 	return td.inner.NewTaskWorker()
 }
@@ -136,7 +139,7 @@ func (td *testDriver) WithDatumCache(cb func(*hashtree.MergeCache, *hashtree.Mer
 	return td.inner.WithDatumCache(cb)
 }
 
-func (td *testDriver) Egress(commit *pfs.Commit, egressURL string) error {
+func (td *testDriver) Egress(commit *pfs.Commit, egress *pps.Egress, resume *pps.EgressProgress, progress func(*pps.EgressProgress) error) error {
 	return nil
 }
 
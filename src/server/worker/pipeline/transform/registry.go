@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/gogo/protobuf/types"
 	"golang.org/x/sync/errgroup"
 
@@ -35,6 +36,10 @@ import (
 	"github.com/pachyderm/pachyderm/src/server/worker/pipeline/transform/chain"
 )
 
+// queuePollInterval is how often a job that's waiting in its pipeline's queue
+// retries admission
+const queuePollInterval = 5 * time.Second
+
 func jobArtifactPrefix(jobID string) string {
 	return path.Join("artifacts", fmt.Sprintf("job-%s", jobID))
 }
@@ -129,6 +134,10 @@ func finishJob(
 	jobInfo.State = state
 	jobInfo.Reason = reason
 
+	windowProvenance, err := datum.WindowProvenance(pachClient, jobInfo.Input)
+	if err != nil {
+		return err
+	}
 	if _, err := pachClient.RunBatchInTransaction(func(builder *client.TransactionBuilder) error {
 		if pipelineInfo.S3Out {
 			if err := builder.FinishCommit(jobInfo.OutputCommit.Repo.Name, jobInfo.OutputCommit.ID); err != nil {
@@ -147,11 +156,12 @@ func finishJob(
 			}
 
 			if _, err := builder.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
-				Commit:    jobInfo.OutputCommit,
-				Empty:     trees == nil,
-				Datums:    datums,
-				Trees:     trees,
-				SizeBytes: size,
+				Commit:     jobInfo.OutputCommit,
+				Empty:      trees == nil,
+				Datums:     datums,
+				Trees:      trees,
+				SizeBytes:  size,
+				Provenance: windowProvenance,
 			}); err != nil {
 				return err
 			}
@@ -163,13 +173,32 @@ func finishJob(
 			// For certain types of errors, we want to reattempt these operations
 			// outside of a transaction (in case the job or commits were affected by
 			// some non-transactional code elsewhere, we can attempt to recover)
-			return recoverFinishedJob(pipelineInfo, pachClient, jobInfo, state, reason, datums, trees, size, statsTrees, statsSize)
+			if err := recoverFinishedJob(pipelineInfo, pachClient, jobInfo, state, reason, datums, trees, size, statsTrees, statsSize); err != nil {
+				return err
+			}
+			return finishJobNamedOutputs(pipelineInfo, pachClient, jobInfo)
 		}
 		// For other types of errors, we want to fail the job supervision and let it
 		// reattempt later
 		return err
 	}
-	return nil
+	return finishJobNamedOutputs(pipelineInfo, pachClient, jobInfo)
+}
+
+// finishJobNamedOutputs finishes the commits of the pipeline's named outputs
+// once the job's output commit is finished
+func finishJobNamedOutputs(pipelineInfo *pps.PipelineInfo, pachClient *client.APIClient, jobInfo *pps.JobInfo) error {
+	if len(pipelineInfo.Outputs) == 0 {
+		return nil
+	}
+	commitInfo, err := pachClient.InspectCommit(jobInfo.OutputCommit.Repo.Name, jobInfo.OutputCommit.ID)
+	if err != nil {
+		if pfsserver.IsCommitNotFoundErr(err) || pfsserver.IsCommitDeletedErr(err) {
+			return nil
+		}
+		return err
+	}
+	return finishNamedOutputCommits(pipelineInfo, pachClient, commitInfo)
 }
 
 // recoverFinishedJob performs job and output commit updates outside of a
@@ -205,12 +234,17 @@ func recoverFinishedJob(
 			}
 		}
 
+		windowProvenance, err := datum.WindowProvenance(pachClient, jobInfo.Input)
+		if err != nil {
+			return err
+		}
 		if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
-			Commit:    jobInfo.OutputCommit,
-			Empty:     trees == nil,
-			Datums:    datums,
-			Trees:     trees,
-			SizeBytes: size,
+			Commit:     jobInfo.OutputCommit,
+			Empty:      trees == nil,
+			Datums:     datums,
+			Trees:      trees,
+			SizeBytes:  size,
+			Provenance: windowProvenance,
 		}); err != nil {
 			if !pfsserver.IsCommitFinishedErr(err) && !pfsserver.IsCommitNotFoundErr(err) && !pfsserver.IsCommitDeletedErr(err) {
 				return err
@@ -314,16 +348,17 @@ func (reg *registry) cleanJobArtifacts(job *pps.Job) error {
 
 func writeJobInfo(pachClient *client.APIClient, jobInfo *pps.JobInfo) error {
 	_, err := pachClient.PpsAPIClient.UpdateJobState(pachClient.Ctx(), &pps.UpdateJobStateRequest{
-		Job:           jobInfo.Job,
-		State:         jobInfo.State,
-		Reason:        jobInfo.Reason,
-		Restart:       jobInfo.Restart,
-		DataProcessed: jobInfo.DataProcessed,
-		DataSkipped:   jobInfo.DataSkipped,
-		DataTotal:     jobInfo.DataTotal,
-		DataFailed:    jobInfo.DataFailed,
-		DataRecovered: jobInfo.DataRecovered,
-		Stats:         jobInfo.Stats,
+		Job:            jobInfo.Job,
+		State:          jobInfo.State,
+		Reason:         jobInfo.Reason,
+		Restart:        jobInfo.Restart,
+		DataProcessed:  jobInfo.DataProcessed,
+		DataSkipped:    jobInfo.DataSkipped,
+		DataTotal:      jobInfo.DataTotal,
+		DataFailed:     jobInfo.DataFailed,
+		DataRecovered:  jobInfo.DataRecovered,
+		Stats:          jobInfo.Stats,
+		EgressProgress: jobInfo.EgressProgress,
 	})
 	return err
 }
@@ -682,10 +717,22 @@ func (reg *registry) startJob(commitInfo *pfs.CommitInfo, statsCommit *pfs.Commi
 			return err
 		}
 	}
+	if pj.ji.State == pps.JobState_JOB_QUEUED {
+		if err := pj.logger.LogStep("waiting for a slot in the pipeline's queue", func() error {
+			return reg.processJobQueued(pj)
+		}); err != nil {
+			return err
+		}
+		if ppsutil.IsTerminal(pj.ji.State) {
+			// The job was killed while it was queued
+			reg.releaseQueueSlot(pj)
+			return recoverFinishedJob(pj.driver.PipelineInfo(), pj.driver.PachClient(), pj.ji, pj.ji.State, "", nil, nil, 0, nil, 0)
+		}
+	}
 
 	var afterTime time.Duration
 	if pj.ji.JobTimeout != nil {
-		startTime, err := types.TimestampFromProto(pj.ji.Started)
+		startTime, err := reg.jobTimeoutStart(pj)
 		if err != nil {
 			return err
 		}
@@ -814,6 +861,7 @@ func (reg *registry) startJob(commitInfo *pfs.CommitInfo, statsCommit *pfs.Commi
 
 	go func() {
 		defer reg.limiter.Release()
+		defer reg.releaseQueueSlot(pj)
 
 		// Make sure the job has been removed from the job chain, ignore any errors
 		defer reg.jobChain.Fail(pj)
@@ -927,10 +975,96 @@ func (reg *registry) processJobStarting(pj *pendingJob) error {
 		}
 	}
 
+	if pj.driver.PipelineInfo().Queue != "" {
+		pj.ji.State = pps.JobState_JOB_QUEUED
+		return nil
+	}
 	pj.ji.State = pps.JobState_JOB_RUNNING
 	return nil
 }
 
+// processJobQueued blocks until the job is admitted to its pipeline's queue,
+// and then moves it to the RUNNING state. While the job waits, it's kept in
+// the QUEUED state, with its position in the queue as its reason.
+func (reg *registry) processJobQueued(pj *pendingJob) error {
+	queue := pj.driver.PipelineInfo().Queue
+	entry := &pps.JobQueueEntry{
+		Job:      pj.ji.Job,
+		Pipeline: pj.ji.Pipeline,
+		Priority: pj.driver.PipelineInfo().Priority,
+	}
+	for {
+		var admitted bool
+		var position int
+		if _, err := pj.driver.NewSTM(func(stm col.STM) error {
+			var err error
+			admitted, position, err = ppsutil.AcquireQueueSlot(
+				pj.driver.JobQueues().ReadWrite(stm), pj.driver.Jobs().ReadWrite(stm), queue, entry)
+			return err
+		}); err != nil {
+			return err
+		}
+		if admitted {
+			pj.ji.State = pps.JobState_JOB_RUNNING
+			pj.ji.Reason = ""
+			return nil
+		}
+		if reason := ppsutil.QueuedReason(queue, position); reason != pj.ji.Reason {
+			pj.ji.Reason = reason
+			if err := pj.writeJobInfo(); err != nil && !ppsserver.IsJobFinishedErr(err) {
+				return err
+			}
+		}
+		select {
+		case <-time.After(queuePollInterval):
+		case <-pj.driver.PachClient().Ctx().Done():
+			return errors.EnsureStack(pj.driver.PachClient().Ctx().Err())
+		}
+		// Stop waiting if the job was killed while it was queued
+		jobInfo, err := pj.driver.PachClient().InspectJob(pj.ji.Job.ID, false)
+		if err != nil {
+			return err
+		}
+		if ppsutil.IsTerminal(jobInfo.State) {
+			pj.ji = jobInfo
+			return nil
+		}
+	}
+}
+
+// jobTimeoutStart returns when the job's timeout started, which is when it was
+// admitted to its pipeline's queue if it's in one, and otherwise when it
+// started, so that the time a job spends waiting in a queue doesn't count
+// towards its timeout
+func (reg *registry) jobTimeoutStart(pj *pendingJob) (time.Time, error) {
+	started := pj.ji.Started
+	if queue := reg.driver.PipelineInfo().Queue; queue != "" {
+		admitted, err := ppsutil.QueueAdmitted(reg.driver.JobQueues().ReadOnly(pj.driver.PachClient().Ctx()), queue, pj.ji.Job)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if admitted != nil {
+			started = admitted
+		}
+	}
+	return types.TimestampFromProto(started)
+}
+
+// releaseQueueSlot gives up the job's slot in its pipeline's queue, if it's in
+// one, so that the next waiting job doesn't have to wait for the slot to be
+// reclaimed
+func (reg *registry) releaseQueueSlot(pj *pendingJob) {
+	queue := reg.driver.PipelineInfo().Queue
+	if queue == "" {
+		return
+	}
+	if _, err := reg.driver.NewSTM(func(stm col.STM) error {
+		return ppsutil.ReleaseQueueSlot(reg.driver.JobQueues().ReadWrite(stm), queue, pj.ji.Job)
+	}); err != nil {
+		pj.logger.Logf("error releasing slot in queue %q: %v", queue, err)
+	}
+}
+
 // Iterator fulfills the chain.JobData interface for pendingJob
 func (pj *pendingJob) Iterator() (datum.Iterator, error) {
 	var dit datum.Iterator
@@ -972,6 +1106,7 @@ func (reg *registry) processJobRunning(pj *pendingJob) error {
 	chunkHashtrees := []*HashtreeInfo{}
 	statsHashtrees := []*HashtreeInfo{}
 	recoveredObjects := []string{}
+	failedObjects := []string{}
 
 	// Run subtasks until we are done
 	eg.Go(func() error {
@@ -1002,6 +1137,9 @@ func (reg *registry) processJobRunning(pj *pendingJob) error {
 					if data.RecoveredDatumsObject != "" {
 						recoveredObjects = append(recoveredObjects, data.RecoveredDatumsObject)
 					}
+					if data.FailedDatumsObject != "" {
+						failedObjects = append(failedObjects, data.FailedDatumsObject)
+					}
 					// propagate the stats to etcd
 					pj.saveJobStats(stats)
 					return pj.writeJobInfo()
@@ -1016,7 +1154,11 @@ func (reg *registry) processJobRunning(pj *pendingJob) error {
 		return errors.Wrap(err, "process datum error")
 	}
 
-	if stats.FailedDatumID != "" {
+	if pj.driver.PipelineInfo().FailedDatumBranch != "" {
+		if err := reg.commitFailedDatums(pj, failedObjects); err != nil {
+			return err
+		}
+	} else if stats.FailedDatumID != "" {
 		// A datum failed, but we still may need to merge stats - discard chunk hashtrees
 		chunkHashtrees = []*HashtreeInfo{}
 	}
@@ -1024,7 +1166,7 @@ func (reg *registry) processJobRunning(pj *pendingJob) error {
 	// S3Out pipelines don't use hashtrees, so skip over the MERGING state - this
 	// will go to EGRESSING, if applicable.
 	if pj.driver.PipelineInfo().S3Out {
-		if stats.FailedDatumID != "" {
+		if stats.FailedDatumID != "" && pj.driver.PipelineInfo().FailedDatumBranch == "" {
 			return reg.failJob(pj, "datum failed", nil, 0)
 		}
 		pj.logger.Logf("processJobRunning succeeding s3out job, total stats: %v", stats)
@@ -1045,6 +1187,92 @@ func (reg *registry) processJobRunning(pj *pendingJob) error {
 	return pj.writeJobInfo()
 }
 
+// commitFailedDatums replaces the contents of the pipeline's failed datum
+// branch with a record of each of the job's failed datums, read from the given
+// objects. Each datum gets a directory containing its error, the end of its
+// stderr, the job that failed it and a copy of its inputs, laid out as they
+// were in /pfs.
+func (reg *registry) commitFailedDatums(pj *pendingJob, failedObjects []string) (retErr error) {
+	pachClient := pj.driver.PachClient()
+	repo := pj.ji.OutputCommit.Repo.Name
+	branch := pj.driver.PipelineInfo().FailedDatumBranch
+
+	var failedDatums []*FailedDatum
+	for _, object := range failedObjects {
+		reader, err := pachClient.DirectObjReader(object)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		message := &FailedDatums{}
+		if err := pbutil.NewReader(reader).Read(message); err != nil {
+			reader.Close()
+			return err
+		}
+		if err := reader.Close(); err != nil {
+			return errors.EnsureStack(err)
+		}
+		failedDatums = append(failedDatums, message.Datums...)
+	}
+
+	if len(failedDatums) == 0 {
+		// Only clear out the failed datums of a previous job
+		commitInfo, err := pachClient.InspectCommit(repo, branch)
+		if err != nil {
+			if errutil.IsNotFoundError(err) {
+				return nil
+			}
+			return err
+		}
+		if commitInfo.SizeBytes == 0 {
+			return nil
+		}
+	}
+
+	pj.logger.Logf("committing %d failed datums to branch %q", len(failedDatums), branch)
+	commit, err := pachClient.PfsAPIClient.StartCommit(pachClient.Ctx(), &pfs.StartCommitRequest{
+		Parent:      client.NewCommit(repo, ""),
+		Branch:      branch,
+		Description: fmt.Sprintf("failed datums from job %s", pj.ji.Job.ID),
+	})
+	if err != nil {
+		return errors.EnsureStack(err)
+	}
+	defer func() {
+		if retErr != nil {
+			pachClient.DeleteCommit(repo, commit.ID)
+			return
+		}
+		retErr = pachClient.FinishCommit(repo, commit.ID)
+	}()
+
+	if err := pachClient.DeleteFile(repo, commit.ID, "/"); err != nil && !errutil.IsNotFoundError(err) {
+		return err
+	}
+	for _, failedDatum := range failedDatums {
+		dir := path.Join("/", failedDatum.DatumID)
+		for name, content := range map[string]string{
+			"error":  failedDatum.Error,
+			"stderr": string(failedDatum.Stderr),
+			"job":    pj.ji.Job.ID,
+		} {
+			if _, err := pachClient.PutFile(repo, commit.ID, path.Join(dir, name), strings.NewReader(content)); err != nil {
+				return err
+			}
+		}
+		for _, input := range failedDatum.Inputs {
+			file := input.FileInfo.File
+			if err := pachClient.CopyFile(
+				file.Commit.Repo.Name, file.Commit.ID, file.Path,
+				repo, commit.ID, path.Join(dir, "pfs", input.Name, file.Path),
+				true,
+			); err != nil {
+				return errors.Wrapf(err, "could not copy input %s of failed datum %s", input.Name, failedDatum.DatumID)
+			}
+		}
+	}
+	return nil
+}
+
 func (pj *pendingJob) saveJobStats(stats *DatumStats) {
 	// Any unaccounted-for datums were skipped in the job datum iterator
 	pj.ji.DataSkipped = stats.DatumsSkipped
@@ -1263,7 +1491,9 @@ func (reg *registry) processJobMerging(pj *pendingJob) error {
 	mutex := &sync.Mutex{}
 	mergeSubtasks := []*work.Task{}
 
-	if pj.ji.DataFailed == 0 {
+	// Failed datums only fail the job if the pipeline has nowhere to put them
+	failed := pj.ji.DataFailed > 0 && pj.driver.PipelineInfo().FailedDatumBranch == ""
+	if !failed {
 		chunkMergeSubtasks, err := reg.makeMergeSubtasks(pj, pj.commitInfo, false)
 		if err != nil {
 			return err
@@ -1322,7 +1552,7 @@ func (reg *registry) processJobMerging(pj *pendingJob) error {
 
 	pj.logger.Logf("merge results: %v trees (%d bytes), %v stats trees (%d bytes)", trees, size, statsTrees, statsSize)
 
-	if pj.ji.DataFailed == 0 {
+	if !failed {
 		if err := reg.succeedJob(pj, trees, size, statsTrees, statsSize); err != nil {
 			return err
 		}
@@ -1400,12 +1630,26 @@ func failedInputs(pachClient *client.APIClient, jobInfo *pps.JobInfo) ([]string,
 	return failed, vistErr
 }
 
+// egressProgressInterval is how often a job's egress progress is written
+// while egressing
+const egressProgressInterval = 5 * time.Second
+
 func (reg *registry) egress(pj *pendingJob) error {
 	var egressFailureCount int
 	return backoff.RetryNotify(func() (retErr error) {
 		if pj.ji.Egress != nil {
+			var lastUpdate time.Time
 			return pj.logger.LogStep("egress upload", func() error {
-				return pj.driver.Egress(pj.ji.OutputCommit, pj.ji.Egress.URL)
+				// Retries, including those by a new worker after this one
+				// restarts, resume from the progress of the last attempt
+				return pj.driver.Egress(pj.ji.OutputCommit, pj.ji.Egress, pj.ji.EgressProgress, func(progress *pps.EgressProgress) error {
+					pj.ji.EgressProgress = proto.Clone(progress).(*pps.EgressProgress)
+					if time.Since(lastUpdate) < egressProgressInterval && progress.FilesEgressed < progress.FilesTotal {
+						return nil
+					}
+					lastUpdate = time.Now()
+					return pj.writeJobInfo()
+				})
 			})
 		}
 		return nil
@@ -24,6 +24,62 @@ func getStatsCommit(pipelineInfo *pps.PipelineInfo, commitInfo *pfs.CommitInfo)
 	return nil
 }
 
+// getNamedOutputCommits returns the commits of the pipeline's named outputs
+// that correspond to the output commit 'commitInfo', by output name
+func getNamedOutputCommits(pipelineInfo *pps.PipelineInfo, commitInfo *pfs.CommitInfo) map[string]*pfs.Commit {
+	result := make(map[string]*pfs.Commit)
+	for _, output := range pipelineInfo.Outputs {
+		repo := client.OutputRepo(pipelineInfo.Pipeline.Name, output)
+		for _, commitRange := range commitInfo.Subvenance {
+			if commitRange.Lower.Repo.Name == repo && commitRange.Upper.Repo.Name == repo {
+				result[output] = commitRange.Lower
+			}
+		}
+	}
+	return result
+}
+
+// finishNamedOutputCommits finishes the commits of the pipeline's named
+// outputs that correspond to the finished output commit 'commitInfo'. If the
+// output commit has data, each named output's commit gets a copy of the
+// directory of the same name in the output commit.
+func finishNamedOutputCommits(pipelineInfo *pps.PipelineInfo, pachClient *client.APIClient, commitInfo *pfs.CommitInfo) error {
+	empty := commitInfo.Trees == nil && commitInfo.Tree == nil
+	for output, commit := range getNamedOutputCommits(pipelineInfo, commitInfo) {
+		dir := "/" + output
+		ci, err := pachClient.InspectCommit(commit.Repo.Name, commit.ID)
+		if err != nil {
+			if pfsserver.IsCommitNotFoundErr(err) || pfsserver.IsCommitDeletedErr(err) {
+				continue
+			}
+			return err
+		}
+		if ci.Finished != nil {
+			continue
+		}
+		if !empty {
+			if _, err := pachClient.InspectFile(commitInfo.Commit.Repo.Name, commitInfo.Commit.ID, dir); err != nil {
+				if !pfsserver.IsFileNotFoundErr(err) {
+					return err
+				}
+				// The user code didn't write to this output
+			} else if err := pachClient.CopyFile(
+				commitInfo.Commit.Repo.Name, commitInfo.Commit.ID, dir,
+				commit.Repo.Name, commit.ID, "/", true,
+			); err != nil {
+				return errors.Wrapf(err, "could not copy output %q to %q", output, commit.Repo.Name)
+			}
+		}
+		if _, err := pachClient.PfsAPIClient.FinishCommit(pachClient.Ctx(), &pfs.FinishCommitRequest{
+			Commit: commit,
+			Empty:  empty,
+		}); err != nil && !pfsserver.IsCommitFinishedErr(err) {
+			return err
+		}
+	}
+	return nil
+}
+
 // forEachCommit listens for each READY output commit in the pipeline, and calls
 // the given callback once for each such commit, synchronously.
 func forEachCommit(
@@ -59,6 +115,10 @@ func forEachCommit(
 							return err
 						}
 					}
+					// Likewise the commits of the pipeline's named outputs
+					if err := finishNamedOutputCommits(pi, pachClient, ci); err != nil {
+						return err
+					}
 
 					// Make sure that the job has been correctly finished as the commit(s) have.
 					ji, err := pachClient.InspectJobOutputCommit(ci.Commit.Repo.Name, ci.Commit.ID, false)
@@ -283,6 +283,127 @@ func (m *RecoveredDatumObjects) GetObjects() []string {
 	return nil
 }
 
+// FailedDatum is a record of a datum that exhausted its retries, which is
+// committed to the pipeline's failed datum branch.
+type FailedDatum struct {
+	DatumID string          `protobuf:"bytes,1,opt,name=datum_id,json=datumId,proto3" json:"datum_id,omitempty"`
+	Inputs  []*common.Input `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Error   string          `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// The end of the user code's stderr from the datum's last attempt
+	Stderr               []byte   `protobuf:"bytes,4,opt,name=stderr,proto3" json:"stderr,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FailedDatum) Reset()         { *m = FailedDatum{} }
+func (m *FailedDatum) String() string { return proto.CompactTextString(m) }
+func (*FailedDatum) ProtoMessage()    {}
+func (*FailedDatum) Descriptor() ([]byte, []int) {
+	return fileDescriptor_21583a759eb7fa97, []int{5}
+}
+func (m *FailedDatum) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FailedDatum) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FailedDatum.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FailedDatum) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailedDatum.Merge(m, src)
+}
+func (m *FailedDatum) XXX_Size() int {
+	return m.Size()
+}
+func (m *FailedDatum) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailedDatum.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailedDatum proto.InternalMessageInfo
+
+func (m *FailedDatum) GetDatumID() string {
+	if m != nil {
+		return m.DatumID
+	}
+	return ""
+}
+
+func (m *FailedDatum) GetInputs() []*common.Input {
+	if m != nil {
+		return m.Inputs
+	}
+	return nil
+}
+
+func (m *FailedDatum) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *FailedDatum) GetStderr() []byte {
+	if m != nil {
+		return m.Stderr
+	}
+	return nil
+}
+
+type FailedDatums struct {
+	Datums               []*FailedDatum `protobuf:"bytes,1,rep,name=datums,proto3" json:"datums,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *FailedDatums) Reset()         { *m = FailedDatums{} }
+func (m *FailedDatums) String() string { return proto.CompactTextString(m) }
+func (*FailedDatums) ProtoMessage()    {}
+func (*FailedDatums) Descriptor() ([]byte, []int) {
+	return fileDescriptor_21583a759eb7fa97, []int{6}
+}
+func (m *FailedDatums) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FailedDatums) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FailedDatums.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FailedDatums) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FailedDatums.Merge(m, src)
+}
+func (m *FailedDatums) XXX_Size() int {
+	return m.Size()
+}
+func (m *FailedDatums) XXX_DiscardUnknown() {
+	xxx_messageInfo_FailedDatums.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FailedDatums proto.InternalMessageInfo
+
+func (m *FailedDatums) GetDatums() []*FailedDatum {
+	if m != nil {
+		return m.Datums
+	}
+	return nil
+}
+
 type HashtreeInfo struct {
 	// Address used for fetching a cached version directly from the worker
 	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
@@ -300,7 +421,7 @@ func (m *HashtreeInfo) Reset()         { *m = HashtreeInfo{} }
 func (m *HashtreeInfo) String() string { return proto.CompactTextString(m) }
 func (*HashtreeInfo) ProtoMessage()    {}
 func (*HashtreeInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_21583a759eb7fa97, []int{5}
+	return fileDescriptor_21583a759eb7fa97, []int{7}
 }
 func (m *HashtreeInfo) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -366,7 +487,7 @@ func (m *DatumStats) Reset()         { *m = DatumStats{} }
 func (m *DatumStats) String() string { return proto.CompactTextString(m) }
 func (*DatumStats) ProtoMessage()    {}
 func (*DatumStats) Descriptor() ([]byte, []int) {
-	return fileDescriptor_21583a759eb7fa97, []int{6}
+	return fileDescriptor_21583a759eb7fa97, []int{8}
 }
 func (m *DatumStats) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -447,6 +568,7 @@ type DatumData struct {
 	ChunkHashtree         *HashtreeInfo `protobuf:"bytes,5,opt,name=chunk_hashtree,json=chunkHashtree,proto3" json:"chunk_hashtree,omitempty"`
 	StatsHashtree         *HashtreeInfo `protobuf:"bytes,6,opt,name=stats_hashtree,json=statsHashtree,proto3" json:"stats_hashtree,omitempty"`
 	RecoveredDatumsObject string        `protobuf:"bytes,7,opt,name=recovered_datums_object,json=recoveredDatumsObject,proto3" json:"recovered_datums_object,omitempty"`
+	FailedDatumsObject    string        `protobuf:"bytes,9,opt,name=failed_datums_object,json=failedDatumsObject,proto3" json:"failed_datums_object,omitempty"`
 	XXX_NoUnkeyedLiteral  struct{}      `json:"-"`
 	XXX_unrecognized      []byte        `json:"-"`
 	XXX_sizecache         int32         `json:"-"`
@@ -456,7 +578,7 @@ func (m *DatumData) Reset()         { *m = DatumData{} }
 func (m *DatumData) String() string { return proto.CompactTextString(m) }
 func (*DatumData) ProtoMessage()    {}
 func (*DatumData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_21583a759eb7fa97, []int{7}
+	return fileDescriptor_21583a759eb7fa97, []int{9}
 }
 func (m *DatumData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -534,6 +656,13 @@ func (m *DatumData) GetRecoveredDatumsObject() string {
 	return ""
 }
 
+func (m *DatumData) GetFailedDatumsObject() string {
+	if m != nil {
+		return m.FailedDatumsObject
+	}
+	return ""
+}
+
 type MergeData struct {
 	// Inputs
 	JobID     string          `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
@@ -553,7 +682,7 @@ func (m *MergeData) Reset()         { *m = MergeData{} }
 func (m *MergeData) String() string { return proto.CompactTextString(m) }
 func (*MergeData) ProtoMessage()    {}
 func (*MergeData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_21583a759eb7fa97, []int{8}
+	return fileDescriptor_21583a759eb7fa97, []int{10}
 }
 func (m *MergeData) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -637,6 +766,8 @@ func init() {
 	proto.RegisterType((*HashtreeObjects)(nil), "pachyderm.worker.pipeline.transform.HashtreeObjects")
 	proto.RegisterType((*RecoveredDatums)(nil), "pachyderm.worker.pipeline.transform.RecoveredDatums")
 	proto.RegisterType((*RecoveredDatumObjects)(nil), "pachyderm.worker.pipeline.transform.RecoveredDatumObjects")
+	proto.RegisterType((*FailedDatum)(nil), "pachyderm.worker.pipeline.transform.FailedDatum")
+	proto.RegisterType((*FailedDatums)(nil), "pachyderm.worker.pipeline.transform.FailedDatums")
 	proto.RegisterType((*HashtreeInfo)(nil), "pachyderm.worker.pipeline.transform.HashtreeInfo")
 	proto.RegisterType((*DatumStats)(nil), "pachyderm.worker.pipeline.transform.DatumStats")
 	proto.RegisterType((*DatumData)(nil), "pachyderm.worker.pipeline.transform.DatumData")
@@ -648,55 +779,60 @@ func init() {
 }
 
 var fileDescriptor_21583a759eb7fa97 = []byte{
-	// 760 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xd1, 0x4e, 0xdb, 0x4a,
-	0x10, 0x55, 0x12, 0x62, 0xf0, 0x26, 0x26, 0x97, 0x15, 0xdc, 0x1b, 0x71, 0x25, 0xc2, 0x35, 0x42,
-	0x02, 0xe9, 0xca, 0x86, 0x5c, 0x09, 0xe9, 0xbe, 0x42, 0x5a, 0x11, 0xd4, 0x0a, 0xea, 0xbc, 0x54,
-	0xed, 0x83, 0xe5, 0xd8, 0x9b, 0xd8, 0x84, 0x78, 0xad, 0x5d, 0x87, 0xb6, 0xfc, 0x43, 0x3f, 0xa2,
-	0x5f, 0xd1, 0x5f, 0xe8, 0x63, 0xbf, 0x00, 0x55, 0xf9, 0x92, 0x6a, 0x67, 0xd6, 0xc1, 0xe9, 0x4b,
-	0x23, 0x1e, 0x2c, 0xef, 0x9c, 0x3d, 0x73, 0x66, 0x76, 0x66, 0xc7, 0x26, 0x27, 0x92, 0x89, 0x7b,
-	0x26, 0xdc, 0x0f, 0x5c, 0x4c, 0x98, 0x70, 0xb3, 0x24, 0x63, 0x77, 0x49, 0xca, 0xdc, 0x5c, 0x04,
-	0xa9, 0x1c, 0x71, 0x31, 0x7d, 0x5a, 0x39, 0x99, 0xe0, 0x39, 0xa7, 0x07, 0x59, 0x10, 0xc6, 0x9f,
-	0x22, 0x26, 0xa6, 0x0e, 0x3a, 0x39, 0x85, 0x93, 0xb3, 0xa0, 0xee, 0x6e, 0x8f, 0xf9, 0x98, 0x03,
-	0xdf, 0x55, 0x2b, 0x74, 0xdd, 0xdd, 0x0e, 0xef, 0x12, 0x96, 0xe6, 0x6e, 0x36, 0x92, 0xea, 0xf9,
-	0x15, 0xcd, 0xa4, 0x7a, 0x34, 0xfa, 0xcf, 0x72, 0x62, 0x21, 0x9f, 0x4e, 0x79, 0xaa, 0x5f, 0x48,
-	0xb1, 0xaf, 0x48, 0xa3, 0x17, 0xe4, 0xb3, 0x69, 0x3f, 0xcd, 0x66, 0xb9, 0xa4, 0x87, 0xc4, 0x48,
-	0x60, 0xd5, 0xae, 0xec, 0xd7, 0x8e, 0x1a, 0x5d, 0xcb, 0xd1, 0x6c, 0xd8, 0xf7, 0xf4, 0x26, 0xdd,
-	0x26, 0xf5, 0x24, 0x8d, 0xd8, 0xc7, 0x76, 0x75, 0xbf, 0x72, 0x54, 0xf3, 0xd0, 0xb0, 0xdf, 0x93,
-	0x56, 0x49, 0xeb, 0x55, 0x22, 0x73, 0x7a, 0x49, 0x8c, 0x48, 0x41, 0x85, 0xde, 0x89, 0xb3, 0xc2,
-	0xc9, 0x9d, 0x92, 0x8a, 0xa7, 0xfd, 0x95, 0xf8, 0x65, 0x20, 0xe3, 0x5c, 0x30, 0x76, 0x3d, 0xbc,
-	0x65, 0x61, 0x2e, 0xe9, 0x01, 0xb1, 0xc2, 0x78, 0x96, 0x4e, 0x7c, 0x8e, 0x00, 0xc4, 0x30, 0xbd,
-	0x26, 0x80, 0x25, 0x92, 0xcc, 0x83, 0x5c, 0x2e, 0x48, 0x55, 0x24, 0x01, 0xa8, 0x49, 0xf6, 0x31,
-	0x69, 0x79, 0x2c, 0xe4, 0xf7, 0x4c, 0xb0, 0x08, 0x82, 0x4b, 0xfa, 0x27, 0x31, 0xe2, 0x40, 0xc6,
-	0xac, 0x50, 0xd5, 0x96, 0x7d, 0x4a, 0x76, 0x96, 0xa9, 0x45, 0xa0, 0x36, 0x59, 0x5f, 0xce, 0xa3,
-	0x30, 0xed, 0x94, 0x34, 0x8b, 0xd4, 0xfb, 0xe9, 0x88, 0x2b, 0x66, 0x10, 0x45, 0x82, 0x49, 0xc5,
-	0xac, 0x28, 0xa6, 0x36, 0xe9, 0xbf, 0x84, 0xc8, 0xd9, 0x30, 0x0f, 0xe4, 0xc4, 0x4f, 0x22, 0x28,
-	0xae, 0x79, 0x6e, 0xcd, 0x1f, 0x3b, 0xe6, 0x00, 0xd1, 0x7e, 0xcf, 0x33, 0x35, 0xa1, 0x1f, 0xa9,
-	0x14, 0x31, 0x44, 0xbb, 0x06, 0x32, 0xda, 0xb2, 0xbf, 0x54, 0x09, 0x81, 0xd4, 0x06, 0xea, 0x8c,
-	0xf4, 0x8c, 0x58, 0x99, 0xe0, 0x21, 0x93, 0xd2, 0x87, 0x43, 0x43, 0xd0, 0x46, 0x77, 0xcb, 0x51,
-	0x17, 0xe5, 0x06, 0x77, 0x80, 0xe9, 0x35, 0xb3, 0x92, 0x45, 0x8f, 0xc9, 0x1f, 0x58, 0x7b, 0x5f,
-	0xc3, 0x2c, 0xd2, 0xfd, 0x6e, 0x21, 0x7e, 0x53, 0xc0, 0xf4, 0x90, 0x6c, 0x6a, 0xaa, 0x9c, 0x24,
-	0x59, 0xc6, 0x22, 0xc8, 0xa8, 0xe6, 0x59, 0x88, 0x0e, 0x10, 0x54, 0xbd, 0xd0, 0xb4, 0x51, 0x90,
-	0xdc, 0xb1, 0xa8, 0x5d, 0x07, 0x56, 0x13, 0xc1, 0x97, 0x80, 0x95, 0xc2, 0x8a, 0xa2, 0xce, 0x6d,
-	0xa3, 0x1c, 0x76, 0x51, 0x7e, 0xfa, 0x3f, 0x69, 0xa1, 0x90, 0x0f, 0x3b, 0xaa, 0x66, 0x1b, 0x50,
-	0xb3, 0xad, 0xf9, 0x63, 0xc7, 0x42, 0x3d, 0xbc, 0x4b, 0x3d, 0xcf, 0x1a, 0x95, 0xcc, 0xc8, 0xfe,
-	0x5a, 0x23, 0x26, 0xac, 0x7b, 0x41, 0x1e, 0xd0, 0x7d, 0x62, 0xdc, 0xf2, 0xa1, 0xf2, 0x87, 0x86,
-	0x9c, 0x9b, 0xf3, 0xc7, 0x4e, 0xfd, 0x8a, 0x0f, 0xfb, 0x3d, 0xaf, 0x7e, 0xcb, 0x87, 0xfd, 0x72,
-	0xea, 0xba, 0xe4, 0x10, 0xa8, 0x48, 0x1d, 0xef, 0x00, 0x3d, 0x21, 0x16, 0x9f, 0xe5, 0xd9, 0x2c,
-	0xf7, 0xd5, 0xd4, 0x24, 0xd8, 0x97, 0x46, 0xb7, 0xe1, 0xa8, 0x41, 0xbd, 0x00, 0xc8, 0x6b, 0x22,
-	0x03, 0x2d, 0xfa, 0x82, 0xd4, 0xb1, 0x27, 0x6b, 0xc0, 0x74, 0x57, 0x1f, 0x0f, 0xec, 0x18, 0x7a,
-	0xd3, 0xb7, 0x64, 0x13, 0x27, 0x21, 0xd6, 0xf7, 0x0c, 0x2a, 0xdb, 0xe8, 0x9e, 0xae, 0xa4, 0x57,
-	0xbe, 0x9c, 0x1e, 0x8e, 0x54, 0x01, 0x29, 0x65, 0x1c, 0x9f, 0x85, 0xb2, 0xf1, 0x6c, 0x65, 0x10,
-	0x5a, 0x28, 0x9f, 0x91, 0xbf, 0x16, 0x0d, 0xf6, 0x97, 0x6b, 0xbb, 0x0e, 0xb5, 0xdd, 0x11, 0xcb,
-	0x23, 0x89, 0x45, 0xb6, 0x3f, 0x57, 0x89, 0xf9, 0x9a, 0x89, 0x31, 0x5b, 0xb1, 0x73, 0xd7, 0xc4,
-	0x2c, 0x72, 0xc7, 0xe1, 0x7f, 0x56, 0xf2, 0x4f, 0x1a, 0xf4, 0x80, 0x18, 0x59, 0x20, 0x58, 0xba,
-	0xdc, 0x5e, 0xcc, 0xce, 0xd3, 0x5b, 0xea, 0x0b, 0x29, 0xe3, 0x40, 0x44, 0xd0, 0xd8, 0x9a, 0x87,
-	0x06, 0xa0, 0xd0, 0x6e, 0xd5, 0x9e, 0x8d, 0xa2, 0x7b, 0x1d, 0xb2, 0x56, 0xaa, 0xec, 0x92, 0x1c,
-	0x6c, 0xd0, 0xbf, 0x89, 0xa9, 0xde, 0xbe, 0x4c, 0x1e, 0x18, 0x14, 0x67, 0xcd, 0xdb, 0x50, 0xc0,
-	0x20, 0x79, 0x60, 0xe7, 0x6f, 0xbe, 0xcd, 0xf7, 0x2a, 0xdf, 0xe7, 0x7b, 0x95, 0x1f, 0xf3, 0xbd,
-	0xca, 0xbb, 0x8b, 0x71, 0x92, 0xc7, 0xb3, 0xa1, 0xfa, 0x6c, 0xbb, 0x8b, 0x43, 0x96, 0x56, 0x52,
-	0x84, 0xee, 0xef, 0x7e, 0x57, 0x43, 0x03, 0xfe, 0x0d, 0xff, 0xfd, 0x0c, 0x00, 0x00, 0xff, 0xff,
-	0x6f, 0x96, 0xe4, 0xe7, 0xd9, 0x06, 0x00, 0x00,
+	// 843 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x55, 0xc1, 0x6e, 0xdb, 0x46,
+	0x10, 0x05, 0x25, 0x4b, 0x36, 0x47, 0x52, 0xdc, 0x2c, 0x9c, 0x96, 0x48, 0x01, 0x4b, 0xa5, 0x91,
+	0xc2, 0x01, 0x0a, 0xd2, 0x71, 0x81, 0x00, 0xbd, 0x26, 0x6a, 0x11, 0x06, 0x2d, 0x92, 0xae, 0x2f,
+	0x41, 0x7b, 0x20, 0x28, 0x72, 0x65, 0xd2, 0xb6, 0xb8, 0xc4, 0xee, 0x2a, 0x6d, 0xf3, 0x09, 0x05,
+	0xfa, 0x11, 0xfd, 0x9b, 0x1e, 0xfb, 0x05, 0x46, 0xa1, 0x5b, 0xff, 0xa2, 0xd8, 0xd9, 0x25, 0xbd,
+	0xea, 0x25, 0x82, 0x0f, 0x82, 0x76, 0xde, 0xce, 0xbc, 0x9d, 0x99, 0x37, 0x1a, 0xc1, 0x99, 0x64,
+	0xe2, 0x3d, 0x13, 0xf1, 0x2f, 0x5c, 0x5c, 0x33, 0x11, 0x37, 0x55, 0xc3, 0x6e, 0xaa, 0x9a, 0xc5,
+	0x4a, 0x64, 0xb5, 0x5c, 0x72, 0xb1, 0xba, 0x3b, 0x45, 0x8d, 0xe0, 0x8a, 0x93, 0x93, 0x26, 0xcb,
+	0xcb, 0xdf, 0x0a, 0x26, 0x56, 0x91, 0x09, 0x8a, 0xda, 0xa0, 0xa8, 0x73, 0x7d, 0x7c, 0x74, 0xc9,
+	0x2f, 0x39, 0xfa, 0xc7, 0xfa, 0x64, 0x42, 0x1f, 0x1f, 0xe5, 0x37, 0x15, 0xab, 0x55, 0xdc, 0x2c,
+	0xa5, 0xfe, 0xfc, 0x1f, 0x6d, 0xa4, 0xfe, 0x58, 0xf4, 0x8b, 0xed, 0xc4, 0x72, 0xbe, 0x5a, 0xf1,
+	0xda, 0x7e, 0x19, 0x97, 0xf0, 0x35, 0x8c, 0xe6, 0x99, 0x5a, 0xaf, 0x92, 0xba, 0x59, 0x2b, 0x49,
+	0x9e, 0xc0, 0xb0, 0xc2, 0x53, 0xe0, 0xcd, 0xfa, 0xa7, 0xa3, 0xf3, 0x49, 0x64, 0xbd, 0xf1, 0x9e,
+	0xda, 0x4b, 0x72, 0x04, 0x83, 0xaa, 0x2e, 0xd8, 0xaf, 0x41, 0x6f, 0xe6, 0x9d, 0xf6, 0xa9, 0x31,
+	0xc2, 0x9f, 0xe1, 0xd0, 0xe1, 0xfa, 0xbe, 0x92, 0x8a, 0xbc, 0x82, 0x61, 0xa1, 0xa1, 0x96, 0xef,
+	0x2c, 0xda, 0xa1, 0xf2, 0xc8, 0x61, 0xa1, 0x36, 0x5e, 0x93, 0xbf, 0xca, 0x64, 0xa9, 0x04, 0x63,
+	0x6f, 0x16, 0x57, 0x2c, 0x57, 0x92, 0x9c, 0xc0, 0x24, 0x2f, 0xd7, 0xf5, 0x75, 0xca, 0x0d, 0x80,
+	0x6f, 0xf8, 0x74, 0x8c, 0xa0, 0xe3, 0x24, 0x55, 0xa6, 0x64, 0xe7, 0xd4, 0x33, 0x4e, 0x08, 0x5a,
+	0xa7, 0xf0, 0x29, 0x1c, 0x52, 0x96, 0xf3, 0xf7, 0x4c, 0xb0, 0x02, 0x1f, 0x97, 0xe4, 0x53, 0x18,
+	0x96, 0x99, 0x2c, 0x59, 0xcb, 0x6a, 0xad, 0xf0, 0x19, 0x3c, 0xda, 0x76, 0x6d, 0x1f, 0x0a, 0x60,
+	0x7f, 0x3b, 0x8f, 0xd6, 0x0c, 0x7f, 0xf7, 0x60, 0xf4, 0x5d, 0x56, 0xdd, 0xd8, 0x00, 0xf2, 0x25,
+	0x1c, 0x60, 0x51, 0x69, 0x55, 0x04, 0xde, 0xcc, 0x3b, 0xf5, 0x5f, 0x8c, 0x36, 0xb7, 0xd3, 0x7d,
+	0x53, 0xf5, 0x9c, 0xee, 0xe3, 0x65, 0x52, 0x38, 0x62, 0xf4, 0x3e, 0x22, 0x06, 0x13, 0x82, 0x8b,
+	0xa0, 0xaf, 0xb9, 0xa8, 0x31, 0x74, 0xfe, 0x52, 0x15, 0x4c, 0x88, 0x60, 0x6f, 0xe6, 0x9d, 0x8e,
+	0xa9, 0xb5, 0xc2, 0x77, 0x30, 0x76, 0x72, 0x91, 0xf7, 0x54, 0xc8, 0xa1, 0xe8, 0x14, 0xaa, 0x61,
+	0xdc, 0x2a, 0x94, 0xd4, 0x4b, 0xae, 0x1b, 0x92, 0x15, 0x85, 0x60, 0x52, 0x9a, 0x2a, 0x69, 0x6b,
+	0x92, 0xaf, 0x00, 0xe4, 0x7a, 0xa1, 0x32, 0x79, 0xad, 0x5b, 0xd0, 0xc3, 0x16, 0x4c, 0x36, 0xb7,
+	0x53, 0xff, 0xc2, 0xa0, 0xc9, 0x9c, 0xfa, 0xd6, 0x21, 0x29, 0x74, 0x25, 0xa6, 0x93, 0xb6, 0x40,
+	0x6b, 0x85, 0x7f, 0xf6, 0x00, 0x30, 0x83, 0x0b, 0x2d, 0x25, 0x79, 0x0e, 0x93, 0x46, 0xf0, 0x9c,
+	0x49, 0x99, 0xa2, 0xb6, 0xf8, 0xe8, 0xe8, 0xfc, 0x61, 0xa4, 0x7f, 0x0f, 0x6f, 0xcd, 0x0d, 0x7a,
+	0xd2, 0x71, 0xe3, 0x58, 0xe4, 0x29, 0x7c, 0x62, 0x0a, 0x48, 0x2d, 0xcc, 0x0a, 0x3b, 0xd6, 0x87,
+	0x06, 0x7f, 0xdb, 0xc2, 0xe4, 0x09, 0x3c, 0xb0, 0xae, 0xf2, 0xba, 0x6a, 0x1a, 0x56, 0x60, 0x46,
+	0x7d, 0x3a, 0x31, 0xe8, 0x85, 0x01, 0xf5, 0xc8, 0x59, 0xb7, 0x25, 0xb6, 0x29, 0x18, 0xa0, 0xd7,
+	0xd8, 0x80, 0xa6, 0x75, 0xce, 0xb3, 0xa2, 0x1d, 0xa7, 0x60, 0xe8, 0x3e, 0xdb, 0x4d, 0x19, 0xf9,
+	0x06, 0x0e, 0x0d, 0x51, 0xda, 0x8d, 0xcd, 0x01, 0xf6, 0xec, 0xe1, 0xe6, 0x76, 0x3a, 0x71, 0xa4,
+	0x48, 0xe6, 0x74, 0xb2, 0x74, 0xcc, 0x22, 0xfc, 0xb7, 0x0f, 0x3e, 0x9e, 0xe7, 0x99, 0xca, 0xc8,
+	0x0c, 0x86, 0x57, 0x7c, 0x71, 0x37, 0x76, 0xfe, 0xe6, 0x76, 0x3a, 0x78, 0xcd, 0x17, 0xc9, 0x9c,
+	0x0e, 0xae, 0xf8, 0x22, 0x71, 0x53, 0xb7, 0x2d, 0xc7, 0x87, 0xda, 0xd4, 0xcd, 0xa8, 0x93, 0x33,
+	0x98, 0xf0, 0xb5, 0x6a, 0xd6, 0x2a, 0xd5, 0xf3, 0x58, 0x19, 0x5d, 0x46, 0xe7, 0xa3, 0x48, 0xef,
+	0xa3, 0x97, 0x08, 0xd1, 0xb1, 0xf1, 0x30, 0x16, 0xf9, 0x16, 0x06, 0x46, 0x93, 0x3d, 0xf4, 0x8c,
+	0x77, 0xdf, 0x02, 0x46, 0x31, 0x13, 0x4d, 0xde, 0xc1, 0x03, 0xf3, 0x83, 0x2f, 0xed, 0x9c, 0x61,
+	0x67, 0x47, 0xe7, 0xcf, 0x76, 0xe2, 0x73, 0x87, 0x93, 0x9a, 0xcd, 0xd1, 0x42, 0x9a, 0xd9, 0x6c,
+	0x89, 0x8e, 0x79, 0x78, 0x6f, 0x66, 0x24, 0xea, 0x98, 0x9f, 0xc3, 0x67, 0x9d, 0xc0, 0xe9, 0x76,
+	0x6f, 0xf7, 0xb1, 0xb7, 0x8f, 0xc4, 0xf6, 0xe6, 0xe9, 0x9a, 0x7c, 0xe4, 0x8a, 0xde, 0x05, 0xf9,
+	0x18, 0x44, 0x1c, 0x99, 0x6d, 0x44, 0xf8, 0x47, 0x0f, 0xfc, 0x1f, 0x98, 0xb8, 0x64, 0x3b, 0x6a,
+	0xfd, 0x06, 0xfc, 0xb6, 0xda, 0x76, 0xc3, 0xdc, 0xa3, 0xdc, 0x3b, 0x0e, 0x72, 0x02, 0xc3, 0x26,
+	0x13, 0xac, 0xde, 0x1e, 0x08, 0x93, 0x1d, 0xb5, 0x57, 0x7a, 0x5b, 0xc9, 0x32, 0x13, 0x05, 0x8e,
+	0x42, 0x9f, 0x1a, 0x03, 0x51, 0x1c, 0x10, 0x2d, 0xe8, 0x41, 0xab, 0xf7, 0x14, 0xf6, 0x1c, 0x2d,
+	0xb6, 0xe8, 0xf0, 0x82, 0x7c, 0x0e, 0xbe, 0xfe, 0x4e, 0x65, 0xf5, 0x81, 0x61, 0x3b, 0xf7, 0xe8,
+	0x81, 0x06, 0x2e, 0xaa, 0x0f, 0xec, 0xc5, 0x8f, 0x7f, 0x6d, 0x8e, 0xbd, 0xbf, 0x37, 0xc7, 0xde,
+	0x3f, 0x9b, 0x63, 0xef, 0xa7, 0x97, 0x97, 0x95, 0x2a, 0xd7, 0x0b, 0xbd, 0x42, 0xe3, 0xae, 0x48,
+	0xe7, 0x24, 0x45, 0x1e, 0x7f, 0xec, 0x7f, 0x7c, 0x31, 0xc4, 0x3f, 0xcd, 0xaf, 0xff, 0x0b, 0x00,
+	0x00, 0xff, 0xff, 0xef, 0xc5, 0x79, 0xaa, 0xf2, 0x07, 0x00, 0x00,
 }
 
 func (m *DatumInputs) Marshal() (dAtA []byte, err error) {
@@ -903,6 +1039,109 @@ func (m *RecoveredDatumObjects) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *FailedDatum) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FailedDatum) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FailedDatum) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Stderr) > 0 {
+		i -= len(m.Stderr)
+		copy(dAtA[i:], m.Stderr)
+		i = encodeVarintTransform(dAtA, i, uint64(len(m.Stderr)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Error) > 0 {
+		i -= len(m.Error)
+		copy(dAtA[i:], m.Error)
+		i = encodeVarintTransform(dAtA, i, uint64(len(m.Error)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Inputs) > 0 {
+		for iNdEx := len(m.Inputs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Inputs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTransform(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.DatumID) > 0 {
+		i -= len(m.DatumID)
+		copy(dAtA[i:], m.DatumID)
+		i = encodeVarintTransform(dAtA, i, uint64(len(m.DatumID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FailedDatums) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FailedDatums) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FailedDatums) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Datums) > 0 {
+		for iNdEx := len(m.Datums) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Datums[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintTransform(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *HashtreeInfo) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1041,6 +1280,13 @@ func (m *DatumData) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.FailedDatumsObject) > 0 {
+		i -= len(m.FailedDatumsObject)
+		copy(dAtA[i:], m.FailedDatumsObject)
+		i = encodeVarintTransform(dAtA, i, uint64(len(m.FailedDatumsObject)))
+		i--
+		dAtA[i] = 0x4a
+	}
 	if len(m.DatumsObject) > 0 {
 		i -= len(m.DatumsObject)
 		copy(dAtA[i:], m.DatumsObject)
@@ -1315,6 +1561,54 @@ func (m *RecoveredDatumObjects) Size() (n int) {
 	return n
 }
 
+func (m *FailedDatum) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.DatumID)
+	if l > 0 {
+		n += 1 + l + sovTransform(uint64(l))
+	}
+	if len(m.Inputs) > 0 {
+		for _, e := range m.Inputs {
+			l = e.Size()
+			n += 1 + l + sovTransform(uint64(l))
+		}
+	}
+	l = len(m.Error)
+	if l > 0 {
+		n += 1 + l + sovTransform(uint64(l))
+	}
+	l = len(m.Stderr)
+	if l > 0 {
+		n += 1 + l + sovTransform(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *FailedDatums) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Datums) > 0 {
+		for _, e := range m.Datums {
+			l = e.Size()
+			n += 1 + l + sovTransform(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *HashtreeInfo) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1405,6 +1699,10 @@ func (m *DatumData) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTransform(uint64(l))
 	}
+	l = len(m.FailedDatumsObject)
+	if l > 0 {
+		n += 1 + l + sovTransform(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1941,6 +2239,280 @@ func (m *RecoveredDatumObjects) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *FailedDatum) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransform
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FailedDatum: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FailedDatum: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DatumID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.DatumID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inputs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Inputs = append(m.Inputs, &common.Input{})
+			if err := m.Inputs[len(m.Inputs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Error", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Error = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stderr", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Stderr = append(m.Stderr[:0], dAtA[iNdEx:postIndex]...)
+			if m.Stderr == nil {
+				m.Stderr = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransform(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FailedDatums) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowTransform
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FailedDatums: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FailedDatums: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Datums", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Datums = append(m.Datums, &FailedDatum{})
+			if err := m.Datums[len(m.Datums)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipTransform(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *HashtreeInfo) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -2558,6 +3130,38 @@ func (m *DatumData) Unmarshal(dAtA []byte) error {
 			}
 			m.DatumsObject = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailedDatumsObject", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTransform
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTransform
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTransform
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.FailedDatumsObject = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTransform(dAtA[iNdEx:])
@@ -467,3 +467,91 @@ func TestJobEgress(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestJobKilledWhileQueued(t *testing.T) {
+	pi := defaultPipelineInfo()
+	pi.Queue = "testQueue"
+	err := withWorkerSpawnerPair(pi, func(env *testEnv) error {
+		// Fill the queue's only slot with another pipeline's job, so that this
+		// pipeline's job has to wait for it
+		other := client.NewJob(uuid.NewWithoutDashes())
+		if _, err := env.driver.NewSTM(func(stm col.STM) error {
+			jobs := env.driver.Jobs().ReadWrite(stm)
+			queues := env.driver.JobQueues().ReadWrite(stm)
+			if err := jobs.Put(other.ID, &pps.EtcdJobInfo{
+				Job:          other,
+				Pipeline:     client.NewPipeline("otherPipeline"),
+				OutputCommit: client.NewCommit("otherPipeline", other.ID),
+				State:        pps.JobState_JOB_RUNNING,
+			}); err != nil {
+				return err
+			}
+			if err := ppsutil.SetQueueLimit(queues, pi.Queue, 1); err != nil {
+				return err
+			}
+			_, _, err := ppsutil.AcquireQueueSlot(queues, jobs, pi.Queue, &pps.JobQueueEntry{Job: other})
+			return err
+		}); err != nil {
+			return err
+		}
+
+		_, etcdJobInfo := mockBasicJob(t, env, pi)
+		triggerJob(t, env, pi, []*inputFile{newInput("file", "foobar")})
+		require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+			if etcdJobInfo.State != pps.JobState_JOB_QUEUED {
+				return errors.Errorf("expected job to be queued, but it's %v", etcdJobInfo.State)
+			}
+			return nil
+		})
+		etcdJobInfo.State = pps.JobState_JOB_KILLED
+
+		// The killed job's output commit is finished without it ever running,
+		// and it no longer waits in the queue
+		require.NoErrorWithinTRetry(t, 10*time.Second, func() error {
+			commitInfo, err := env.PachClient.InspectCommit(pi.Pipeline.Name, etcdJobInfo.OutputCommit.ID)
+			if err != nil {
+				return err
+			}
+			if commitInfo.Finished == nil {
+				return errors.New("output commit isn't finished")
+			}
+			queue := &pps.JobQueue{}
+			if err := env.driver.JobQueues().ReadOnly(env.PachClient.Ctx()).Get(pi.Queue, queue); err != nil {
+				return err
+			}
+			if len(queue.Waiting) != 0 {
+				return errors.Errorf("expected no waiting jobs, but found %d", len(queue.Waiting))
+			}
+			return nil
+		})
+		require.Equal(t, pps.JobState_JOB_KILLED, etcdJobInfo.State)
+		files, err := env.PachClient.ListFile(pi.Pipeline.Name, etcdJobInfo.OutputCommit.ID, "/")
+		require.NoError(t, err)
+		require.Equal(t, 0, len(files))
+		return nil
+	})
+	require.NoError(t, err)
+}
+
+func TestGetNamedOutputCommits(t *testing.T) {
+	pipelineInfo := &pps.PipelineInfo{
+		Pipeline: client.NewPipeline("split"),
+		Outputs:  []string{"good", "rejected"},
+	}
+	commitRange := func(repo, id string) *pfs.CommitRange {
+		return &pfs.CommitRange{Lower: client.NewCommit(repo, id), Upper: client.NewCommit(repo, id)}
+	}
+	commitInfo := &pfs.CommitInfo{
+		Commit: client.NewCommit("split", "a"),
+		Subvenance: []*pfs.CommitRange{
+			commitRange("split", "stats"),
+			commitRange("split_good", "b"),
+			commitRange("split_rejected", "c"),
+			commitRange("downstream", "d"),
+		},
+	}
+	require.Equal(t, map[string]*pfs.Commit{
+		"good":     client.NewCommit("split_good", "b"),
+		"rejected": client.NewCommit("split_rejected", "c"),
+	}, getNamedOutputCommits(pipelineInfo, commitInfo))
+}
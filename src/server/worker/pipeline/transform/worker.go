@@ -3,11 +3,14 @@ package transform
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"os"
 	"path"
+	"path/filepath"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -26,6 +29,7 @@ import (
 	"github.com/pachyderm/pachyderm/src/client/pps"
 	pfsserver "github.com/pachyderm/pachyderm/src/server/pfs/server"
 	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/pachyderm/src/server/pkg/errutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
 	"github.com/pachyderm/pachyderm/src/server/pkg/ppsutil"
 	"github.com/pachyderm/pachyderm/src/server/pkg/uuid"
@@ -48,6 +52,10 @@ func jobArtifactRecoveredDatums(jobID string, subtaskID string) string {
 	return path.Join(jobArtifactPrefix(jobID), fmt.Sprintf("recovered-%s", subtaskID))
 }
 
+func jobArtifactFailedDatums(jobID string, subtaskID string) string {
+	return path.Join(jobArtifactPrefix(jobID), fmt.Sprintf("failed-%s", subtaskID))
+}
+
 func jobArtifactChunkStats(jobID string, subtaskID string) string {
 	return path.Join(jobArtifactPrefix(jobID), fmt.Sprintf("chunk-stats-%s", subtaskID))
 }
@@ -95,6 +103,15 @@ func mergeStats(x, y *DatumStats) error {
 		}
 		xps.DownloadBytes += yps.DownloadBytes
 		xps.UploadBytes += yps.UploadBytes
+		if xps.CpuTime, err = plusDuration(xps.CpuTime, yps.CpuTime); err != nil {
+			return err
+		}
+		if yps.PeakMemoryBytes > xps.PeakMemoryBytes {
+			xps.PeakMemoryBytes = yps.PeakMemoryBytes
+		}
+		if yps.DiskBytes > xps.DiskBytes {
+			xps.DiskBytes = yps.DiskBytes
+		}
 	}
 
 	x.DatumsProcessed += y.DatumsProcessed
@@ -201,6 +218,26 @@ func uploadRecoveredDatums(driver driver.Driver, logger logs.TaggedLogger, recov
 	})
 }
 
+func uploadFailedDatums(driver driver.Driver, logger logs.TaggedLogger, failedDatums []*FailedDatum, object string) (retErr error) {
+	return logger.LogStep("uploading failed datums", func() error {
+		message := &FailedDatums{Datums: failedDatums}
+
+		writer, err := driver.PachClient().DirectObjWriter(object)
+		if err != nil {
+			return errors.EnsureStack(err)
+		}
+		defer func() {
+			if err := writer.Close(); err != nil && retErr == nil {
+				retErr = errors.EnsureStack(err)
+			}
+		}()
+
+		protoWriter := pbutil.NewWriter(writer)
+		_, err = protoWriter.Write(message)
+		return err
+	})
+}
+
 func uploadChunk(
 	driver driver.Driver,
 	logger logs.TaggedLogger,
@@ -278,6 +315,7 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 		// statsMutex controls access to stats so that they can be safely merged
 		statsMutex := &sync.Mutex{}
 		recoveredDatums := []string{}
+		failedDatums := []*FailedDatum{}
 		data.Stats = &DatumStats{
 			ProcessStats: &pps.ProcessStats{},
 		}
@@ -308,7 +346,7 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 						logger = logger.WithJob(jobID).WithData(inputs)
 
 						// subStats is still valid even on an error, merge those in before proceeding
-						subStats, subRecovered, err := processDatum(driver, logger, index, inputs, data.OutputCommit, datumCache, statsCache, status)
+						subStats, subRecovered, subFailed, err := processDatum(driver, logger, index, inputs, data.OutputCommit, datumCache, statsCache, status)
 
 						statsMutex.Lock()
 						defer statsMutex.Unlock()
@@ -317,6 +355,9 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 							return err
 						}
 						recoveredDatums = append(recoveredDatums, subRecovered...)
+						if subFailed != nil {
+							failedDatums = append(failedDatums, subFailed)
+						}
 						if len(subRecovered) == 0 {
 							atomic.AddInt64(&dataProcessed, 1)
 						}
@@ -336,7 +377,17 @@ func handleDatumTask(driver driver.Driver, logger logs.TaggedLogger, data *Datum
 			return err
 		}
 
-		if data.Stats.DatumsFailed == 0 && !driver.PipelineInfo().S3Out {
+		// Failed datums only fail the job if the pipeline has nowhere to put them
+		deadLetter := driver.PipelineInfo().FailedDatumBranch != ""
+		if deadLetter && len(failedDatums) > 0 {
+			failedDatumsObject := jobArtifactFailedDatums(logger.JobID(), subtaskID)
+			if err := uploadFailedDatums(driver, logger, failedDatums, failedDatumsObject); err != nil {
+				return err
+			}
+			data.FailedDatumsObject = failedDatumsObject
+		}
+
+		if (data.Stats.DatumsFailed == 0 || deadLetter) && !driver.PipelineInfo().S3Out {
 			if len(recoveredDatums) > 0 {
 				recoveredDatumsObject := jobArtifactRecoveredDatums(logger.JobID(), subtaskID)
 				if err := uploadRecoveredDatums(driver, logger, recoveredDatums, recoveredDatumsObject); err != nil {
@@ -384,33 +435,38 @@ func processDatum(
 	datumCache *hashtree.MergeCache,
 	datumStatsCache *hashtree.MergeCache,
 	status *Status,
-) (_ *DatumStats, _ []string, retErr error) {
+) (_ *DatumStats, _ []string, _ *FailedDatum, retErr error) {
 	recoveredDatums := []string{}
 	stats := &DatumStats{}
 	tag := common.HashDatum(driver.PipelineInfo().Pipeline.Name, driver.PipelineInfo().Salt, inputs)
 	datumID := common.DatumID(inputs)
 
+	if canary := driver.PipelineInfo().Canary; canary != nil && !common.InCanarySample(datumID, canary.SamplePercent) {
+		stats.DatumsSkipped++
+		return stats, recoveredDatums, nil, nil
+	}
+
 	if _, err := driver.PachClient().InspectTag(driver.PachClient().Ctx(), client.NewTag(tag)); err == nil {
 		buf := &bytes.Buffer{}
 		if err := driver.PachClient().GetTag(tag, buf); err != nil {
-			return stats, recoveredDatums, err
+			return stats, recoveredDatums, nil, err
 		}
 		if err := datumCache.Put(uuid.NewWithoutDashes(), buf); err != nil {
-			return stats, recoveredDatums, err
+			return stats, recoveredDatums, nil, err
 		}
 		if driver.PipelineInfo().EnableStats {
 			buf.Reset()
 			if err := driver.PachClient().GetTag(tag+statsTagSuffix, buf); err != nil {
 				// We are okay with not finding the stats hashtree. This allows users to
 				// enable stats on a pipeline with pre-existing jobs.
-				return stats, recoveredDatums, nil
+				return stats, recoveredDatums, nil, nil
 			}
 			if err := datumStatsCache.Put(uuid.NewWithoutDashes(), buf); err != nil {
-				return stats, recoveredDatums, err
+				return stats, recoveredDatums, nil, err
 			}
 		}
 		stats.DatumsSkipped++
-		return stats, recoveredDatums, nil
+		return stats, recoveredDatums, nil, nil
 	}
 
 	statsRoot := path.Join("/", datumID)
@@ -425,15 +481,15 @@ func processDatum(
 		// Write index in datum factory to stats tree
 		object, size, err := driver.PachClient().PutObject(strings.NewReader(fmt.Sprint(int(datumIndex))))
 		if err != nil {
-			return stats, recoveredDatums, err
+			return stats, recoveredDatums, nil, err
 		}
 		objectInfo, err := driver.PachClient().InspectObject(object.Hash)
 		if err != nil {
-			return stats, recoveredDatums, err
+			return stats, recoveredDatums, nil, err
 		}
 		h, err := pfs.DecodeHash(object.Hash)
 		if err != nil {
-			return stats, recoveredDatums, err
+			return stats, recoveredDatums, nil, err
 		}
 		statsTree.PutFile("index", h, size, objectInfo.BlockRef)
 		defer func() {
@@ -444,9 +500,40 @@ func processDatum(
 		}()
 	}
 
+	// This comes after the stats are set up, so that datums reused from the
+	// global datum cache are listed with the job's other datums
+	var globalTag string
+	if driver.PipelineInfo().GlobalDatumCache && common.ImageDigest(driver.PipelineInfo().Transform.Image) != "" {
+		spec, err := globalDatumSpec(driver.PipelineInfo())
+		if err != nil {
+			logger.Logf("not using the global datum cache: %v", err)
+		} else {
+			globalTag = common.HashGlobalDatum(spec, inputs)
+			reusedTag, err := reuseGlobalDatum(driver, tag, spec, inputs, datumCache)
+			if err != nil {
+				return stats, recoveredDatums, nil, err
+			}
+			if reusedTag != "" {
+				logger.Logf("reusing output of datum %s from the global datum cache", reusedTag)
+				stats.ProcessStats = &pps.ProcessStats{}
+				stats.DatumsSkipped++
+				return stats, recoveredDatums, nil, nil
+			}
+		}
+	}
+
+	retryPolicy := driver.PipelineInfo().RetryPolicy
+	retryBackOff, err := newRetryBackOff(retryPolicy)
+	if err != nil {
+		return stats, recoveredDatums, nil, err
+	}
 	var failures int64
+	// fatal is set when the user code fails in a way that the pipeline's retry
+	// policy doesn't retry
+	var fatal bool
 	if err := backoff.RetryUntilCancel(driver.PachClient().Ctx(), func() error {
 		var err error
+		fatal = false
 
 		// WithData will download the inputs for this datum
 		stats.ProcessStats, err = driver.WithData(inputs, inputTree, logger, func(dir string, processStats *pps.ProcessStats) error {
@@ -461,7 +548,8 @@ func processDatum(
 				return status.withDatum(inputs, cancel, func() error {
 					env := driver.UserCodeEnv(logger.JobID(), outputCommit, inputs)
 					if err := driver.RunUserCode(logger, env, processStats, driver.PipelineInfo().DatumTimeout); err != nil {
-						if driver.PipelineInfo().Transform.ErrCmd != nil && failures == driver.PipelineInfo().DatumTries-1 {
+						fatal = !retryable(retryPolicy, err)
+						if driver.PipelineInfo().Transform.ErrCmd != nil && (failures == driver.PipelineInfo().DatumTries-1 || fatal) {
 							if err = driver.RunUserErrorHandlingCode(logger, env, processStats, driver.PipelineInfo().DatumTimeout); err != nil {
 								return errors.Wrap(err, "RunUserErrorHandlingCode")
 							}
@@ -483,14 +571,19 @@ func processDatum(
 			if err != nil {
 				return err
 			}
+			if globalTag != "" {
+				if err := shareGlobalDatum(driver, tag, globalTag); err != nil {
+					return err
+				}
+			}
 
 			// Cache datum hashtree locally
 			return datumCache.Put(uuid.NewWithoutDashes(), bytes.NewReader(hashtreeBytes))
 		})
 		return err
-	}, &backoff.ZeroBackOff{}, func(err error, d time.Duration) error {
+	}, retryBackOff, func(err error, d time.Duration) error {
 		failures++
-		if failures >= driver.PipelineInfo().DatumTries {
+		if failures >= driver.PipelineInfo().DatumTries || fatal {
 			logger.Logf("failed to process datum with error: %+v", err)
 			if statsTree != nil {
 				object, size, err := driver.PachClient().PutObject(strings.NewReader(err.Error()))
@@ -524,10 +617,173 @@ func processDatum(
 	} else if err != nil {
 		stats.FailedDatumID = datumID
 		stats.DatumsFailed++
+		if driver.PipelineInfo().FailedDatumBranch != "" {
+			// Dead-lettered datums aren't considered processed, so that they're
+			// retried by the next job, like recovered datums
+			recoveredDatums = []string{tag}
+			return stats, recoveredDatums, &FailedDatum{
+				DatumID: datumID,
+				Inputs:  inputs,
+				Error:   err.Error(),
+				Stderr:  userCodeStderr(err),
+			}, nil
+		}
 	} else {
 		stats.DatumsProcessed++
 	}
-	return stats, recoveredDatums, nil
+	return stats, recoveredDatums, nil, nil
+}
+
+// globalDatumSpec returns everything besides a datum's inputs that's part of
+// its key in the global datum cache
+func globalDatumSpec(pipelineInfo *pps.PipelineInfo) (*common.GlobalDatumSpec, error) {
+	secrets, err := mountedSecrets(pipelineInfo.Transform)
+	if err != nil {
+		return nil, err
+	}
+	return &common.GlobalDatumSpec{
+		Owner:     pipelineInfo.GlobalDatumCacheOwner,
+		Transform: pipelineInfo.Transform,
+		PodSpec:   pipelineInfo.PodSpec,
+		PodPatch:  pipelineInfo.PodPatch,
+		Env:       common.GlobalDatumEnv(os.Environ()),
+		Secrets:   secrets,
+	}, nil
+}
+
+// mountedSecrets returns the path and content hash of each key of the secrets
+// that are mounted in the user container
+func mountedSecrets(transform *pps.Transform) ([]string, error) {
+	var result []string
+	for _, secret := range transform.Secrets {
+		if secret.MountPath == "" {
+			continue
+		}
+		entries, err := ioutil.ReadDir(secret.MountPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not read secret %q", secret.Name)
+		}
+		for _, entry := range entries {
+			// Kubernetes links each key to a hidden directory, e.g. '..data'
+			if strings.HasPrefix(entry.Name(), "..") {
+				continue
+			}
+			content, err := ioutil.ReadFile(filepath.Join(secret.MountPath, entry.Name()))
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read secret %q", secret.Name)
+			}
+			result = append(result, fmt.Sprintf("%s=%x", filepath.Join(secret.MountPath, entry.Name()), sha256.Sum256(content)))
+		}
+	}
+	return result, nil
+}
+
+// reuseGlobalDatum looks up the output of a datum in the global datum cache,
+// under the key of each of the owners that the pipeline trusts, starting with
+// its own, and returns the key that it was found under, or "" if it wasn't.
+// If it's found, the output is also tagged with the pipeline's own datum tag
+// 'tag', so that later jobs skip the datum as usual, and is added to
+// 'datumCache'.
+func reuseGlobalDatum(driver driver.Driver, tag string, spec *common.GlobalDatumSpec, inputs []*common.Input, datumCache *hashtree.MergeCache) (string, error) {
+	pachClient := driver.PachClient()
+	owners := append([]string{spec.Owner}, driver.PipelineInfo().GlobalDatumCacheTrust...)
+	for _, owner := range owners {
+		ownerSpec := *spec
+		ownerSpec.Owner = owner
+		globalTag := common.HashGlobalDatum(&ownerSpec, inputs)
+		objectInfo, err := pachClient.InspectTag(pachClient.Ctx(), client.NewTag(globalTag))
+		if err != nil {
+			if errutil.IsNotFoundError(err) {
+				continue
+			}
+			return "", err
+		}
+		if err := pachClient.TagObject(objectInfo.Object.Hash, tag); err != nil {
+			return "", err
+		}
+		buf := &bytes.Buffer{}
+		if err := pachClient.GetObject(objectInfo.Object.Hash, buf); err != nil {
+			return "", err
+		}
+		return globalTag, datumCache.Put(uuid.NewWithoutDashes(), buf)
+	}
+	return "", nil
+}
+
+// shareGlobalDatum adds the output of a datum, which was uploaded under the
+// pipeline's datum tag 'tag', to the global datum cache under 'globalTag'
+func shareGlobalDatum(driver driver.Driver, tag, globalTag string) error {
+	pachClient := driver.PachClient()
+	objectInfo, err := pachClient.InspectTag(pachClient.Ctx(), client.NewTag(tag))
+	if err != nil {
+		return err
+	}
+	return pachClient.TagObject(objectInfo.Object.Hash, globalTag)
+}
+
+// newRetryBackOff returns the backoff between a datum's retries under a
+// pipeline's retry policy. Without an initial backoff, datums are retried
+// immediately.
+func newRetryBackOff(policy *pps.RetryPolicy) (backoff.BackOff, error) {
+	if policy == nil || policy.InitialBackoff == nil {
+		return &backoff.ZeroBackOff{}, nil
+	}
+	b := backoff.NewExponentialBackOff()
+	initial, err := types.DurationFromProto(policy.InitialBackoff)
+	if err != nil {
+		return nil, errors.EnsureStack(err)
+	}
+	b.InitialInterval = initial
+	b.Multiplier = 2
+	b.MaxElapsedTime = 0 // datum_tries bounds the number of retries
+	if policy.MaxBackoff != nil {
+		if b.MaxInterval, err = types.DurationFromProto(policy.MaxBackoff); err != nil {
+			return nil, errors.EnsureStack(err)
+		}
+	} else if b.MaxInterval < initial {
+		b.MaxInterval = initial
+	}
+	return b, nil
+}
+
+// retryable returns whether a datum whose user code failed with err should be
+// retried under a pipeline's retry policy. Without a policy, every failure is
+// retried.
+func retryable(policy *pps.RetryPolicy, err error) bool {
+	if policy == nil {
+		return true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return policy.RetryTimeouts
+	}
+	userCodeErr := &driver.UserCodeError{}
+	if !errors.As(err, &userCodeErr) {
+		return true
+	}
+	for _, returnCode := range policy.FatalReturnCode {
+		if int(returnCode) == userCodeErr.ReturnCode {
+			return false
+		}
+	}
+	if len(policy.RetryReturnCode) == 0 {
+		return true
+	}
+	for _, returnCode := range policy.RetryReturnCode {
+		if int(returnCode) == userCodeErr.ReturnCode {
+			return true
+		}
+	}
+	return false
+}
+
+// userCodeStderr returns the end of the user code's stderr, if err was
+// returned by the user code
+func userCodeStderr(err error) []byte {
+	userCodeErr := &driver.UserCodeError{}
+	if errors.As(err, &userCodeErr) {
+		return userCodeErr.Stderr
+	}
+	return nil
 }
 
 func writeStats(
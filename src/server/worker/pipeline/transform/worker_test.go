@@ -0,0 +1,149 @@
+package transform
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+
+	"github.com/pachyderm/pachyderm/src/client"
+	"github.com/pachyderm/pachyderm/src/client/pfs"
+	"github.com/pachyderm/pachyderm/src/client/pkg/errors"
+	"github.com/pachyderm/pachyderm/src/client/pkg/require"
+	"github.com/pachyderm/pachyderm/src/client/pps"
+	"github.com/pachyderm/pachyderm/src/server/pkg/backoff"
+	"github.com/pachyderm/pachyderm/src/server/pkg/hashtree"
+	"github.com/pachyderm/pachyderm/src/server/worker/common"
+	"github.com/pachyderm/pachyderm/src/server/worker/driver"
+)
+
+func userCodeError(returnCode int) error {
+	return errors.Wrap(&driver.UserCodeError{Err: errors.New("exit status"), ReturnCode: returnCode}, "user code")
+}
+
+func TestRetryable(t *testing.T) {
+	timeout := errors.EnsureStack(context.DeadlineExceeded)
+	other := errors.New("could not download datum")
+
+	// Without a policy, every failure is retried
+	require.True(t, retryable(nil, userCodeError(1)))
+	require.True(t, retryable(nil, timeout))
+
+	policy := &pps.RetryPolicy{FatalReturnCode: []int64{2}}
+	require.True(t, retryable(policy, userCodeError(1)))
+	require.False(t, retryable(policy, userCodeError(2)))
+	require.True(t, retryable(policy, other))
+	require.False(t, retryable(policy, timeout))
+
+	// Only the listed return codes are retried
+	policy = &pps.RetryPolicy{RetryReturnCode: []int64{75}, RetryTimeouts: true}
+	require.True(t, retryable(policy, userCodeError(75)))
+	require.False(t, retryable(policy, userCodeError(1)))
+	require.True(t, retryable(policy, timeout))
+}
+
+func TestRetryBackOff(t *testing.T) {
+	b, err := newRetryBackOff(nil)
+	require.NoError(t, err)
+	require.Equal(t, time.Duration(0), b.NextBackOff())
+
+	b, err = newRetryBackOff(&pps.RetryPolicy{
+		InitialBackoff: types.DurationProto(time.Second),
+		MaxBackoff:     types.DurationProto(3 * time.Second),
+	})
+	require.NoError(t, err)
+	exponential := b.(*backoff.ExponentialBackOff)
+	exponential.RandomizationFactor = 0
+	exponential.Reset()
+	require.Equal(t, time.Second, b.NextBackOff())
+	require.Equal(t, 2*time.Second, b.NextBackOff())
+	require.Equal(t, 3*time.Second, b.NextBackOff())
+	require.Equal(t, 3*time.Second, b.NextBackOff())
+}
+
+func TestGlobalDatumCache(t *testing.T) {
+	pi := defaultPipelineInfo()
+	pi.GlobalDatumCache = true
+	pi.GlobalDatumCacheOwner = "alice"
+	require.NoError(t, withTestEnv(pi, func(env *testEnv) error {
+		inputs := []*common.Input{{
+			Name:     "images",
+			FileInfo: &pfs.FileInfo{File: client.NewFile("images", "master", "/a.png"), Hash: []byte("abc")},
+		}}
+		spec, err := globalDatumSpec(pi)
+		require.NoError(t, err)
+
+		// Another of alice's pipelines has shared its output for the datum
+		otherTag := client.DatumTagPrefix("otherSalt") + "datum"
+		object, _, err := env.PachClient.PutObject(strings.NewReader("output"), otherTag)
+		require.NoError(t, err)
+		globalTag := common.HashGlobalDatum(spec, inputs)
+		require.NoError(t, shareGlobalDatum(env.driver, otherTag, globalTag))
+
+		return env.driver.WithDatumCache(func(datumCache, _ *hashtree.MergeCache) error {
+			// This pipeline reuses the output, under its own datum tag
+			tag := client.DatumTagPrefix(pi.Salt) + "datum"
+			reusedTag, err := reuseGlobalDatum(env.driver, tag, spec, inputs, datumCache)
+			require.NoError(t, err)
+			require.Equal(t, globalTag, reusedTag)
+			objectInfo, err := env.PachClient.InspectTag(env.PachClient.Ctx(), client.NewTag(tag))
+			require.NoError(t, err)
+			require.Equal(t, object.Hash, objectInfo.Object.Hash)
+			require.Equal(t, 1, len(datumCache.Keys()))
+
+			// bob's pipelines only reuse alice's outputs if they trust her
+			bobSpec := *spec
+			bobSpec.Owner = "bob"
+			reusedTag, err = reuseGlobalDatum(env.driver, tag, &bobSpec, inputs, datumCache)
+			require.NoError(t, err)
+			require.Equal(t, "", reusedTag)
+			pi.GlobalDatumCacheTrust = []string{"alice"}
+			reusedTag, err = reuseGlobalDatum(env.driver, tag, &bobSpec, inputs, datumCache)
+			require.NoError(t, err)
+			require.Equal(t, globalTag, reusedTag)
+			pi.GlobalDatumCacheTrust = nil
+
+			// A datum that isn't in the cache is a miss, but other errors are
+			// returned rather than treated as misses
+			reusedTag, err = reuseGlobalDatum(env.driver, tag, spec, []*common.Input{{
+				Name:     "images",
+				FileInfo: &pfs.FileInfo{File: client.NewFile("images", "master", "/b.png"), Hash: []byte("abc")},
+			}}, datumCache)
+			require.NoError(t, err)
+			require.Equal(t, "", reusedTag)
+			ctx, cancel := context.WithCancel(env.PachClient.Ctx())
+			cancel()
+			_, err = reuseGlobalDatum(env.driver.WithContext(ctx), tag, spec, inputs, datumCache)
+			require.YesError(t, err)
+			return nil
+		})
+	}))
+}
+
+func TestMountedSecrets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "secret")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "password"), []byte("hunter2"), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..data"), 0700))
+
+	transform := &pps.Transform{Secrets: []*pps.SecretMount{
+		{Name: "db", MountPath: dir},
+		{Name: "token", Key: "token", EnvVar: "TOKEN"},
+	}}
+	secrets, err := mountedSecrets(transform)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(secrets))
+	require.True(t, strings.HasPrefix(secrets[0], filepath.Join(dir, "password")+"="))
+
+	// The secrets' contents are part of the key
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "password"), []byte("hunter3"), 0600))
+	changed, err := mountedSecrets(transform)
+	require.NoError(t, err)
+	require.NotEqual(t, secrets, changed)
+}
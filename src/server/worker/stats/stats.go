@@ -20,6 +20,9 @@ var (
 	bucketFactor = 2.0
 	bucketCount  = 20 // Which makes the max bucket 2^20 seconds or ~12 days in size
 
+	// Resource usage buckets start at 1MiB, which makes the max bucket 512GiB
+	resourceBucketStart = 1024.0 * 1024.0
+
 	// DatumCount is a counter tracking the number of datums processed by a pipeline
 	DatumCount = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -65,6 +68,50 @@ var (
 		},
 	)
 
+	// DatumCPUSecondsCount is a counter tracking the total CPU time used by user code in a pipeline
+	DatumCPUSecondsCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "pachyderm",
+			Subsystem: "worker",
+			Name:      "datum_cpu_seconds_count",
+			Help:      "Cumulative number of seconds of CPU time used by user code (user and system)",
+		},
+		[]string{
+			"pipeline",
+			"job",
+		},
+	)
+
+	// DatumPeakMemory is a histogram tracking the peak memory used by user code for datums processed by a pipeline
+	DatumPeakMemory = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "pachyderm",
+			Subsystem: "worker",
+			Name:      "datum_peak_memory_bytes",
+			Help:      "Peak resident set size of user code",
+			Buckets:   prometheus.ExponentialBuckets(resourceBucketStart, bucketFactor, bucketCount),
+		},
+		[]string{
+			"pipeline",
+			"job",
+		},
+	)
+
+	// DatumDiskSize is a histogram tracking the size on disk of datums processed by a pipeline
+	DatumDiskSize = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "pachyderm",
+			Subsystem: "worker",
+			Name:      "datum_disk_bytes",
+			Help:      "Size of a datum's inputs and outputs on disk",
+			Buckets:   prometheus.ExponentialBuckets(resourceBucketStart, bucketFactor, bucketCount),
+		},
+		[]string{
+			"pipeline",
+			"job",
+		},
+	)
+
 	// DatumDownloadTime is a histogram tracking the time spent downloading input data by a pipeline
 	DatumDownloadTime = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -189,6 +236,9 @@ func InitPrometheus() {
 		DatumCount,
 		DatumProcTime,
 		DatumProcSecondsCount,
+		DatumCPUSecondsCount,
+		DatumPeakMemory,
+		DatumDiskSize,
 		DatumDownloadTime,
 		DatumDownloadSecondsCount,
 		DatumUploadTime,